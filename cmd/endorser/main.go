@@ -15,20 +15,29 @@
 package main
 
 import (
+	"context"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
 	"time"
 
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+
 	"github.com/project-oak/transparent-release/internal/endorser"
+	"github.com/project-oak/transparent-release/internal/model"
 	"github.com/project-oak/transparent-release/internal/verifier"
 	"github.com/project-oak/transparent-release/pkg/claims"
 	"github.com/project-oak/transparent-release/pkg/intoto"
+	pb "github.com/project-oak/transparent-release/pkg/proto/oak/release"
 )
 
 // ISO 8601 layout for representing input dates.
@@ -45,8 +54,26 @@ func (f *provenanceURIsFlag) Set(value string) error {
 	return nil
 }
 
+type localMirrorsFlag map[string]string
+
+func (f *localMirrorsFlag) String() string {
+	return "Local mirrors"
+}
+
+func (f *localMirrorsFlag) Set(value string) error {
+	prefix, dir, found := strings.Cut(value, "=")
+	if !found || prefix == "" || dir == "" {
+		return fmt.Errorf("want <URI prefix>=<directory>, got %q", value)
+	}
+	(*f)[prefix] = dir
+	return nil
+}
+
 //nolint:gochecknoglobals
-var provenanceURIs provenanceURIsFlag
+var (
+	provenanceURIs provenanceURIsFlag
+	localMirrors   = localMirrorsFlag{}
+)
 
 //nolint:cyclop
 func main() {
@@ -65,7 +92,39 @@ func main() {
 	notAfter := flag.String("not_after", "",
 		"The expiry date of the endorsement, formatted as YYYY-MM-DD. Defaults to 90 day after the issuance date.")
 	outputPath := flag.String("output_path", "",
-		"Full path to store the generated endorsement statement as JSON.")
+		"Full path to store the generated endorsement statement.")
+	resultPath := flag.String("result_path", "",
+		"Optional path to store a JSON record of the provenances, verification options, and warnings used to generate the endorsement, for auditing.")
+	outputFormat := flag.String("output_format", "json",
+		"The format of the generated endorsement statement, either json or cbor.")
+	issuerName := flag.String("issuer_name", "",
+		"Optional name of the issuer of the endorsement.")
+	issuerURI := flag.String("issuer_uri", "",
+		"Optional URI identifying the issuer of the endorsement.")
+	oauth2TokenURL := flag.String("oauth2_token_url", "",
+		"Optional token endpoint for fetching provenances using the OAuth2 client credentials flow. The client secret is read from the OAUTH2_CLIENT_SECRET environment variable.")
+	oauth2ClientID := flag.String("oauth2_client_id", "",
+		"Client ID for the OAuth2 client credentials flow. Required if --oauth2_token_url is set.")
+	oauth2Hosts := flag.String("oauth2_hosts", "",
+		"Comma-separated host names that the OAuth2 token is sent to, over HTTPS only. Defaults to the host of --oauth2_token_url.")
+	useNetrc := flag.Bool("use_netrc", false,
+		"Authenticate fetches of provenances over HTTPS with HTTP Basic auth, using the credentials from the netrc file at $NETRC, or ~/.netrc.")
+	s3Region := flag.String("s3_region", "",
+		"Optional AWS region for fetching provenances with s3:// URIs. Defaults to the region in the AWS configuration.")
+	includeVerOpts := flag.Bool("include_verification_options", false,
+		"Record the verification options in the endorsement, for auditing.")
+	verifyOnly := flag.Bool("verify_only", false,
+		"Only verify the provenances as for generating the endorsement, without generating it. --output_path is not required.")
+	rejectExpired := flag.Bool("reject_expired_provenances", false,
+		"Reject provenances whose own validity window does not contain the current time. Implied if --verification_options sets all_within_own_validity.")
+	fulcioRootsPath := flag.String("fulcio_roots", "",
+		"Optional path to PEM-encoded Fulcio certificates. If set with --rekor_public_keys, provenances in Sigstore bundles are verified against them, and only then carry a signing time and a signer identity.")
+	rekorPublicKeysPath := flag.String("rekor_public_keys", "",
+		"Optional path to PEM-encoded public keys of trusted Rekor logs. Required if --fulcio_roots is set.")
+	signerPublicKeysPath := flag.String("signer_public_keys", "",
+		"Optional path to PEM-encoded public keys. If set, provenances in DSSE envelopes must be signed with one of them, and the signers are recorded for the distinct_signers step.")
+	flag.Var(&localMirrors, "local_mirror",
+		"A local directory with copies of remote provenances, as <URI prefix>=<directory>. May be repeated.")
 	flag.Parse()
 
 	// Make sure required flags are set.
@@ -75,9 +134,13 @@ func main() {
 	if len(*binaryPath) == 0 {
 		log.Fatalf("--binary_path not set")
 	}
-	if len(*outputPath) == 0 {
+	if len(*outputPath) == 0 && !*verifyOnly {
 		log.Fatalf("--output_path not set")
 	}
+	serializer, err := claims.StatementSerializerFor(*outputFormat)
+	if err != nil {
+		log.Fatalf("Invalid --output_format: %v", err)
+	}
 	if *verOptsTextproto == "" && !*skipVerification {
 		log.Fatalf("--verification_options empty, use --skip_verification to overrule")
 	}
@@ -85,6 +148,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Couldn't map parse verification options: %v", err)
 	}
+	if *rejectExpired && verOpts.AllWithinOwnValidity == nil {
+		verOpts.AllWithinOwnValidity = &pb.VerifyAllWithinOwnValidity{}
+	}
 
 	digests, err := computeBinaryDigests(*binaryPath)
 	if err != nil {
@@ -96,27 +162,140 @@ func main() {
 		log.Fatalf("Failed creating claimValidity: %v", err)
 	}
 
-	provenances, err := endorser.LoadProvenances(provenanceURIs)
+	var loadOptions []func(o *endorser.LoadOptions)
+	if *oauth2TokenURL != "" {
+		if *oauth2ClientID == "" {
+			log.Fatalf("--oauth2_client_id not set")
+		}
+		credentials := endorser.OAuth2ClientCredentials{
+			TokenURL:     *oauth2TokenURL,
+			ClientID:     *oauth2ClientID,
+			ClientSecret: os.Getenv("OAUTH2_CLIENT_SECRET"),
+		}
+		if *oauth2Hosts != "" {
+			credentials.Hosts = strings.Split(*oauth2Hosts, ",")
+		}
+		loadOptions = append(loadOptions, endorser.WithOAuth2ClientCredentials(credentials))
+	}
+
+	if *useNetrc {
+		config, err := endorser.LoadNetrc("")
+		if err != nil {
+			log.Fatalf("Couldn't load the netrc file: %v", err)
+		}
+		loadOptions = append(loadOptions, endorser.WithNetrc(config))
+	}
+
+	if *s3Region != "" {
+		loadOptions = append(loadOptions, endorser.WithS3Region(*s3Region))
+	}
+
+	for prefix, dir := range localMirrors {
+		loadOptions = append(loadOptions, endorser.WithLocalMirror(prefix, dir))
+	}
+
+	if *fulcioRootsPath != "" || *rekorPublicKeysPath != "" {
+		root, err := loadSigstoreTrustRoot(*fulcioRootsPath, *rekorPublicKeysPath)
+		if err != nil {
+			log.Fatalf("Couldn't load the Sigstore trust root: %v", err)
+		}
+		loadOptions = append(loadOptions, endorser.WithSigstoreTrustRoot(root))
+	}
+	if *signerPublicKeysPath != "" {
+		verifiers, err := loadSignatureVerifiers(*signerPublicKeysPath)
+		if err != nil {
+			log.Fatalf("Couldn't load the signer public keys: %v", err)
+		}
+		loadOptions = append(loadOptions, endorser.WithSignatureVerifiers(verifiers...))
+	}
+
+	// Stop fetching provenances when interrupted, e.g., with Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	provenances, err := endorser.LoadProvenancesWithContext(ctx, provenanceURIs, loadOptions...)
+	stop()
 	if err != nil {
 		log.Fatalf("Failed loading provenances: %v", err)
 	}
 
-	endorsement, err := endorser.GenerateEndorsement(*binaryName, *digests, verOpts, *validity, provenances)
+	if *verifyOnly {
+		if err := endorser.VerifyProvenances(*binaryName, *digests, verOpts, provenances); err != nil {
+			log.Fatalf("Failed to verify provenances: %v", err)
+		}
+		log.Printf("Verified %d provenance(s) for %s", len(provenances), *binaryName)
+		return
+	}
+
+	var options []func(p *claims.ClaimPredicate)
+	if *issuerName != "" || *issuerURI != "" {
+		options = append(options, claims.WithIssuer(claims.ClaimIssuer{Name: *issuerName, URI: *issuerURI}))
+	}
+	if *includeVerOpts {
+		option, err := endorser.WithVerificationOptions(verOpts)
+		if err != nil {
+			log.Fatalf("Failed recording the verification options: %v", err)
+		}
+		options = append(options, option)
+	}
+
+	endorsement, result, err := endorser.GenerateEndorsementWithResult(*binaryName, *digests, verOpts, *validity, provenances, options...)
 	if err != nil {
 		log.Fatalf("Failed to generate endorsement: %v", err)
 	}
+	for _, warning := range result.Warnings {
+		log.Printf("Warning: %v", warning)
+	}
 
-	bytes, err := json.MarshalIndent(endorsement, "", "    ")
+	bytes, err := serializer.Serialize(endorsement)
 	if err != nil {
 		log.Fatalf("Failed marshalling the endorsement: %v", err)
 	}
-
-	// Add a newline at the end of the file.
-	newline := byte('\n')
-	bytes = append(bytes, newline)
 	if err := os.WriteFile(*outputPath, bytes, 0600); err != nil {
 		log.Fatalf("Failed writing the endorsement statement to file: %v", err)
 	}
+
+	if *resultPath != "" {
+		resultBytes, err := json.MarshalIndent(result, "", "    ")
+		if err != nil {
+			log.Fatalf("Failed marshalling the endorsement result: %v", err)
+		}
+		if err := os.WriteFile(*resultPath, append(resultBytes, '\n'), 0600); err != nil {
+			log.Fatalf("Failed writing the endorsement result to file: %v", err)
+		}
+	}
+}
+
+func loadSigstoreTrustRoot(fulcioRootsPath, rekorPublicKeysPath string) (*model.SigstoreTrustRoot, error) {
+	if fulcioRootsPath == "" || rekorPublicKeysPath == "" {
+		return nil, fmt.Errorf("both --fulcio_roots and --rekor_public_keys must be set")
+	}
+	fulcioPEM, err := os.ReadFile(fulcioRootsPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading the Fulcio certificates: %v", err)
+	}
+	rekorPEM, err := os.ReadFile(rekorPublicKeysPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading the Rekor public keys: %v", err)
+	}
+	return model.ParseSigstoreTrustRoot(fulcioPEM, rekorPEM)
+}
+
+func loadSignatureVerifiers(path string) ([]dsse.Verifier, error) {
+	keysPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the public keys: %v", err)
+	}
+	var verifiers []dsse.Verifier
+	for block, rest := pem.Decode(keysPEM); block != nil; block, rest = pem.Decode(rest) {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key: %v", err)
+		}
+		verifiers = append(verifiers, model.NewPublicKeyVerifier(key))
+	}
+	if len(verifiers) == 0 {
+		return nil, fmt.Errorf("no public key found in %s", path)
+	}
+	return verifiers, nil
 }
 
 func getClaimValidity(notBefore string, notAfter string) (*claims.ClaimValidity, error) {
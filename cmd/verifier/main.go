@@ -41,7 +41,7 @@ func main() {
 	// Map to internal provenance representation based on the predicate/build type.
 	provenanceIR, err := model.FromValidatedProvenance(validatedProvenance)
 	if err != nil {
-		log.Fatalf("couldn't map from %s to internal representation: %v", validatedProvenance, err)
+		log.Fatalf("couldn't map from %s to internal representation: %v", *provenancePath, err)
 	}
 	verOpts, err := verifier.ParseVerificationOptions(*verOptsTextproto)
 	if err != nil {
@@ -15,16 +15,26 @@
 package endorser
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"go.uber.org/multierr"
 
 	"github.com/project-oak/transparent-release/internal/model"
@@ -41,132 +51,1507 @@ import (
 type ParsedProvenance struct {
 	Provenance     model.ProvenanceIR
 	SourceMetadata claims.ProvenanceData
+	// NormalizedStatement is the provenance re-emitted as a SLSA v1 statement
+	// with model.ToStatement, if it was loaded with WithSLSAv1Normalization,
+	// and nil otherwise.
+	NormalizedStatement *intoto.Statement
+}
+
+// GroupProvenancesBySubject groups the given provenances by the artifact they
+// describe, e.g., when loading the provenances for a release with multiple
+// artifacts. The keys of the returned map are canonical subject IDs as
+// computed by claims.SubjectIDOf from the SHA2-256 digest of the binary, or
+// from all its digests if it has no SHA2-256 digest. Within each group,
+// provenances retain their relative order.
+func GroupProvenancesBySubject(provenances []ParsedProvenance) map[string][]ParsedProvenance {
+	groups := make(map[string][]ParsedProvenance)
+	for _, p := range provenances {
+		id := claims.SubjectIDOf(intoto.Subject{
+			Name:   p.Provenance.BinaryName(),
+			Digest: subjectDigests(&p.Provenance),
+		})
+		groups[id] = append(groups[id], p)
+	}
+	return groups
+}
+
+// subjectDigests returns the digests identifying the binary of the given
+// provenance in GroupProvenancesBySubject.
+func subjectDigests(p *model.ProvenanceIR) intoto.DigestSet {
+	if digest := p.BinarySHA256Digest(); digest != "" {
+		return intoto.DigestSet{"sha2-256": digest}
+	}
+	digests, err := p.BinaryDigests()
+	if err != nil {
+		return intoto.DigestSet{}
+	}
+	return intoto.DigestSet(digests)
 }
 
 // GenerateEndorsement generates an endorsement statement for the given binary
 // and validity duration, using the given provenances as evidence and
-// user-specified VerificationOptions to verify them.
-func GenerateEndorsement(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance) (*intoto.Statement, error) {
-	provenanceIRs := make([]model.ProvenanceIR, 0, len(provenances))
+// user-specified VerificationOptions to verify them. Optional fields of the
+// endorsement predicate, such as the issuer, can be set using the given
+// options, e.g., claims.WithIssuer. Failures of verification steps with
+// severity WARN do not prevent generating the endorsement, see
+// GenerateEndorsementWithWarnings. The provenances must list digests of the
+// binary that match the given digests, with all algorithms in common.
+func GenerateEndorsement(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) (*intoto.Statement, error) {
+	statement, _, err := GenerateEndorsementWithWarnings(binaryName, digests, verOpts, validityDuration, provenances, options...)
+	return statement, err
+}
+
+// GenerateEndorsementWithWarnings works like GenerateEndorsement, but in
+// addition returns the failures of verification steps with severity WARN as
+// a list of warnings.
+func GenerateEndorsementWithWarnings(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) (*intoto.Statement, []error, error) {
+	warnings, err := verifyProvenances(binaryName, digests, verOpts, provenances)
+	if err != nil {
+		return nil, nil, err
+	}
+	statement, err := endorsementStatement(binaryName, digests, verOpts, validityDuration, provenances, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return statement, warnings, nil
+}
+
+// endorsementStatement generates the endorsement statement for the given
+// binary from the given provenances, which must have been verified, after
+// checking the validity against the max_validity of the given options.
+func endorsementStatement(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) (*intoto.Statement, error) {
+	if err := checkValidity(validityDuration, verOpts.GetMaxValidity()); err != nil {
+		return nil, err
+	}
+
 	provenancesData := make([]claims.ProvenanceData, 0, len(provenances))
 	for _, p := range provenances {
-		provenanceIRs = append(provenanceIRs, p.Provenance)
 		provenancesData = append(provenancesData, p.SourceMetadata)
 	}
+	verifiedProvenances := claims.VerifiedProvenanceSet{
+		Digests:     digests,
+		BinaryName:  binaryName,
+		Provenances: provenancesData,
+	}
 
-	// First verify the non-negiotiable: binary name and digest.
-	err := verifier.Verify(provenanceIRs, &pb.VerificationOptions{
+	return claims.GenerateEndorsementStatement(validityDuration, verifiedProvenances, options...), nil
+}
+
+// VerifyProvenances runs the same verification as GenerateEndorsement, i.e.,
+// checks that the given provenances match the given binary name and digests,
+// and verifies them using the given VerificationOptions, but does not
+// generate an endorsement. This is useful for checking whether an endorsement
+// could be generated, e.g., in CI. Failures of verification steps with
+// severity WARN are ignored. Since no validity is given, max_validity is not
+// checked.
+func VerifyProvenances(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, provenances []ParsedProvenance) error {
+	_, err := verifyProvenances(binaryName, digests, verOpts, provenances)
+	return err
+}
+
+// verifyProvenances implements VerifyProvenances, and in addition returns the
+// failures of verification steps with severity WARN as a list of warnings.
+func verifyProvenances(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, provenances []ParsedProvenance) ([]error, error) {
+	provenanceIRs, errs := verifyBinary(binaryName, digests, provenances)
+	if provenanceIRs == nil {
+		return nil, errs
+	}
+
+	// Additionally, verify any aspects requested by the caller. All failures
+	// are reported together, so that they can be fixed at once.
+	warnings, err := verifier.VerifyWithWarnings(provenanceIRs, verOpts)
+	if err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to verify provenances: %w", err))
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return warnings, nil
+}
+
+// verifyBinary verifies the non-negotiable aspects of the given provenances,
+// i.e., that they match the given binary name and digests. Returns the
+// provenances as ProvenanceIR, for verifying them further, and the failures.
+// If the given digests are invalid, returns no provenances.
+func verifyBinary(binaryName string, digests intoto.DigestSet, provenances []ParsedProvenance) ([]model.ProvenanceIR, error) {
+	provenanceIRs := make([]model.ProvenanceIR, 0, len(provenances))
+	for _, p := range provenances {
+		provenanceIRs = append(provenanceIRs, p.Provenance)
+	}
+
+	expectedDigests, err := expectedBinaryDigests(digests, provenanceIRs)
+	if err != nil {
+		return nil, err
+	}
+	nameOpts := &pb.VerificationOptions{
 		AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+	}
+	var errs error
+	if err := verifier.Verify(provenanceIRs, nameOpts); err != nil {
+		var observed []string
+		for _, p := range failingProvenances(provenances, nameOpts) {
+			observed = append(observed, fmt.Sprintf("%s has %q", p.SourceMetadata.URI, p.Provenance.BinaryName()))
+		}
+		errs = multierr.Append(errs, fmt.Errorf("failed to verify the binary name of provenances: want %q, but %s: %w", binaryName, strings.Join(observed, ", "), err))
+	}
+	digestOpts := &pb.VerificationOptions{
 		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
-			Digests: []*pb.Digest{
-				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): digests["sha2-256"]}},
-			},
+			Digests: []*pb.Digest{{Hexadecimal: expectedDigests}},
 		},
-	})
+	}
+	if err := verifier.Verify(provenanceIRs, digestOpts); err != nil {
+		var observed []string
+		for _, p := range failingProvenances(provenances, digestOpts) {
+			observed = append(observed, fmt.Sprintf("%s has %v", p.SourceMetadata.URI, normalizedBinaryDigests(&p.Provenance)))
+		}
+		errs = multierr.Append(errs, fmt.Errorf("failed to verify the binary digests of provenances: want %v, but %s: %w", digests, strings.Join(observed, ", "), err))
+	}
+	return provenanceIRs, errs
+}
+
+// GenerateEndorsementWithDetails works like GenerateEndorsementWithWarnings,
+// but instead of the warnings returns a verifier.VerificationResult with the
+// warnings and which of the given provenances satisfied which of the given
+// VerificationOptions, see verifier.VerifyDetailed, e.g., to record in audit
+// logs. The endorsement is generated from the same verification as the
+// result.
+func GenerateEndorsementWithDetails(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) (*intoto.Statement, *verifier.VerificationResult, error) {
+	provenanceIRs, errs := verifyBinary(binaryName, digests, provenances)
+	if provenanceIRs == nil {
+		return nil, nil, errs
+	}
+	result, err := verifier.VerifyDetailed(provenanceIRs, verOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify provenances: %v", err)
+		errs = multierr.Append(errs, fmt.Errorf("failed to verify provenances: %w", err))
+	}
+	if errs != nil {
+		return nil, nil, errs
 	}
 
-	// Additionally, verify any aspects requested by the caller.
-	err = verifier.Verify(provenanceIRs, verOpts)
+	statement, err := endorsementStatement(binaryName, digests, verOpts, validityDuration, provenances, options...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify provenances: %v", err)
+		return nil, nil, err
 	}
+	return statement, result, nil
+}
 
-	verifiedProvenances := claims.VerifiedProvenanceSet{
-		Digests:     digests,
-		BinaryName:  binaryName,
-		Provenances: provenancesData,
+// EndorsementResult records how an endorsement was generated, as returned by
+// GenerateEndorsementWithResult, e.g., for writing a machine-readable audit
+// record next to the endorsement. It is serializable to JSON.
+type EndorsementResult struct {
+	// GeneratedOn is when the endorsement was generated, i.e., the issuance
+	// time recorded in the endorsement.
+	GeneratedOn time.Time `json:"generatedOn"`
+	// Provenances are the provenances used as evidence, in order.
+	Provenances []ProvenanceRecord `json:"provenances"`
+	// VerificationOptions is the canonical form of the verification options
+	// the provenances were verified against, see
+	// verifier.CanonicalVerificationOptions.
+	VerificationOptions json.RawMessage `json:"verificationOptions"`
+	// Warnings are the failures of verification steps with severity WARN.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ProvenanceRecord identifies a provenance in an EndorsementResult, see
+// claims.ProvenanceData.
+type ProvenanceRecord struct {
+	URI          string `json:"uri"`
+	SHA256Digest string `json:"sha256Digest"`
+	Line         int    `json:"line,omitempty"`
+	BuilderID    string `json:"builderId,omitempty"`
+}
+
+// GenerateEndorsementWithResult works like GenerateEndorsementWithWarnings,
+// but instead of the warnings returns an EndorsementResult recording the
+// provenances used as evidence, the verification options, the warnings, and
+// the time the endorsement was generated.
+func GenerateEndorsementWithResult(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) (*intoto.Statement, *EndorsementResult, error) {
+	verOptsJSON, err := verifier.CanonicalVerificationOptions(verOpts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not encode the verification options: %v", err)
+	}
+	statement, warnings, err := GenerateEndorsementWithWarnings(binaryName, digests, verOpts, validityDuration, provenances, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &EndorsementResult{
+		Provenances:         make([]ProvenanceRecord, 0, len(provenances)),
+		VerificationOptions: verOptsJSON,
+	}
+	if issuedOn := statement.Predicate.(claims.ClaimPredicate).IssuedOn; issuedOn != nil {
+		result.GeneratedOn = *issuedOn
+	}
+	for _, p := range provenances {
+		result.Provenances = append(result.Provenances, ProvenanceRecord{
+			URI:          p.SourceMetadata.URI,
+			SHA256Digest: p.SourceMetadata.SHA256Digest,
+			Line:         p.SourceMetadata.Line,
+			BuilderID:    p.SourceMetadata.BuilderID,
+		})
+	}
+	for _, warning := range warnings {
+		result.Warnings = append(result.Warnings, warning.Error())
+	}
+	return statement, result, nil
+}
+
+// GenerateEndorsements works like GenerateEndorsement, but generates a single
+// endorsement statement for all the given subjects, e.g., the artifacts of a
+// release bundle, with the given provenances as the shared evidence. Each
+// provenance must cover one of the subjects, and, unless there are no
+// provenances, each subject must be covered by at least one of the
+// provenances, see VerifySubjectsCovered. The given VerificationOptions are
+// verified once, against all the provenances. Failures of verification steps
+// with severity WARN do not prevent generating the endorsement, see
+// GenerateEndorsementsWithWarnings.
+func GenerateEndorsements(subjects []intoto.Subject, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) (*intoto.Statement, error) {
+	statement, _, err := GenerateEndorsementsWithWarnings(subjects, verOpts, validityDuration, provenances, options...)
+	return statement, err
+}
+
+// GenerateEndorsementsWithWarnings works like GenerateEndorsements, but in
+// addition returns the failures of verification steps with severity WARN as
+// a list of warnings.
+func GenerateEndorsementsWithWarnings(subjects []intoto.Subject, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) (*intoto.Statement, []error, error) {
+	if len(subjects) == 0 {
+		return nil, nil, fmt.Errorf("no subjects given")
+	}
+	for _, subject := range subjects {
+		if _, err := expectedBinaryDigests(subject.Digest, nil); err != nil {
+			return nil, nil, fmt.Errorf("invalid subject %q: %v", subject.Name, err)
+		}
+	}
+
+	provenanceIRs := make([]model.ProvenanceIR, 0, len(provenances))
+	provenancesData := make([]claims.ProvenanceData, 0, len(provenances))
+	for i, p := range provenances {
+		covered := false
+		for _, subject := range subjects {
+			if covers(&provenances[i].Provenance, subject) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return nil, nil, fmt.Errorf("provenance #%d (%s) does not match any of the subjects", i, p.SourceMetadata.URI)
+		}
+		provenanceIRs = append(provenanceIRs, p.Provenance)
+		provenancesData = append(provenancesData, p.SourceMetadata)
+	}
+	if len(provenances) > 0 {
+		if err := VerifySubjectsCovered(subjects, provenances); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	warnings, err := verifier.VerifyWithWarnings(provenanceIRs, verOpts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify provenances: %w", err)
+	}
+
+	if err := checkValidity(validityDuration, verOpts.GetMaxValidity()); err != nil {
+		return nil, nil, err
+	}
+
+	return claims.GenerateEndorsementStatementForSubjects(validityDuration, subjects, provenancesData, options...), warnings, nil
+}
+
+// SignEndorsement serializes the given endorsement as JSON, and signs it with
+// the given signer, e.g., backed by a local key or a KMS, into a DSSE envelope
+// with the in-toto payload type. The signature is computed over the
+// pre-authentication encoding (PAE) of the payload, as expected by
+// model.VerifyEnvelopeSignatures, and carries the key ID of the signer, if
+// any.
+func SignEndorsement(stmt *intoto.Statement, signer dsse.Signer) (*dsse.Envelope, error) {
+	return SignEndorsementWithContext(context.Background(), stmt, signer)
+}
+
+// SignEndorsementWithContext works like SignEndorsement, but passes the given
+// context to the signer.
+func SignEndorsementWithContext(ctx context.Context, stmt *intoto.Statement, signer dsse.Signer) (*dsse.Envelope, error) {
+	if stmt == nil {
+		return nil, fmt.Errorf("cannot sign a nil endorsement")
+	}
+	payload, err := json.Marshal(stmt)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the endorsement: %v", err)
+	}
+	sig, err := signer.Sign(ctx, dsse.PAE(intoto.PayloadType, payload))
+	if err != nil {
+		return nil, fmt.Errorf("could not sign the endorsement: %w", err)
 	}
+	keyID, err := signer.KeyID()
+	if err != nil {
+		keyID = ""
+	}
+	return &dsse.Envelope{
+		PayloadType: intoto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []dsse.Signature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}, nil
+}
 
-	return claims.GenerateEndorsementStatement(validityDuration, verifiedProvenances), nil
+// failingProvenances returns the provenances that individually fail the
+// verification with the given options, in order.
+func failingProvenances(provenances []ParsedProvenance, verOpts *pb.VerificationOptions) []ParsedProvenance {
+	var failing []ParsedProvenance
+	for _, p := range provenances {
+		if err := verifier.Verify([]model.ProvenanceIR{p.Provenance}, verOpts); err != nil {
+			failing = append(failing, p)
+		}
+	}
+	return failing
+}
+
+// WithVerificationOptions records the canonical form of the given
+// verification options (see verifier.CanonicalVerificationOptions) in the
+// endorsement, so that auditors can reconstruct the policy the provenances
+// were verified against. Use it as an option to GenerateEndorsement, passing
+// the same options as used for verification.
+func WithVerificationOptions(verOpts *pb.VerificationOptions) (func(p *claims.ClaimPredicate), error) {
+	verOptsJSON, err := verifier.CanonicalVerificationOptions(verOpts)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode the verification options: %v", err)
+	}
+	return claims.WithVerificationOptions(verOptsJSON), nil
+}
+
+// expectedBinaryDigests returns the digests in the given set with algorithms
+// supported by the verifier, keyed by digest type. Returns an error if there
+// are no such digests, or if none of the given provenances lists a digest
+// with any of their algorithms.
+func expectedBinaryDigests(digests intoto.DigestSet, provenances []model.ProvenanceIR) (map[int32]string, error) {
+	algorithms := make([]string, 0, len(digests))
+	expectedDigests := make(map[int32]string)
+	for alg, digest := range digests {
+		if digestType, found := verifier.DigestType(alg); found && digest != "" {
+			expectedDigests[int32(digestType)] = digest
+			algorithms = append(algorithms, alg)
+		}
+	}
+	if len(expectedDigests) == 0 {
+		return nil, fmt.Errorf("no binary digests with supported algorithms given: %v", digests)
+	}
+	if len(provenances) == 0 {
+		return expectedDigests, nil
+	}
+
+	for i := range provenances {
+		for alg := range normalizedBinaryDigests(&provenances[i]) {
+			if digestType, found := verifier.DigestType(alg); found && expectedDigests[int32(digestType)] != "" {
+				return expectedDigests, nil
+			}
+		}
+	}
+	sort.Strings(algorithms)
+	return nil, fmt.Errorf("none of the provenances has a binary digest with any of the algorithms %v", algorithms)
+}
+
+// VerifySubjectDigestsAttested checks that every digest in the subject of the
+// given endorsement is attested by at least one of the given provenances, i.e.,
+// that one of them lists the same digest with the same algorithm for its
+// subject. This is meant as a post-generation check for endorsements whose
+// subject has digests computed with several algorithms. Algorithm names are
+// compared after normalization with claims.NormalizeDigestAlgorithm.
+func VerifySubjectDigestsAttested(endorsement *intoto.Statement, provenances []ParsedProvenance) error {
+	attested := make(map[string]map[string]bool)
+	for i := range provenances {
+		for alg, digest := range normalizedBinaryDigests(&provenances[i].Provenance) {
+			if attested[alg] == nil {
+				attested[alg] = make(map[string]bool)
+			}
+			attested[alg][digest] = true
+		}
+	}
+
+	var errs error
+	for _, subject := range endorsement.Subject {
+		algs := make([]string, 0, len(subject.Digest))
+		for alg := range subject.Digest {
+			algs = append(algs, alg)
+		}
+		sort.Strings(algs)
+		for _, alg := range algs {
+			if !attested[claims.NormalizeDigestAlgorithm(alg)][subject.Digest[alg]] {
+				errs = multierr.Append(errs, fmt.Errorf("the %s digest %q of subject %q is not attested by any provenance", alg, subject.Digest[alg], subject.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// UncoveredSubjectsError is returned by VerifySubjectsCovered if some of the
+// subjects are not covered by any provenance.
+type UncoveredSubjectsError struct {
+	// Subjects lists the uncovered subjects, in the order they were given.
+	Subjects []intoto.Subject
+}
+
+func (e *UncoveredSubjectsError) Error() string {
+	ids := make([]string, 0, len(e.Subjects))
+	for _, subject := range e.Subjects {
+		ids = append(ids, claims.SubjectIDOf(subject))
+	}
+	return fmt.Sprintf("%d subject(s) not covered by any provenance: %s", len(e.Subjects), strings.Join(ids, ", "))
+}
+
+// VerifySubjectsCovered checks that each of the given subjects, e.g., the
+// subjects of an endorsement for several artifacts, is independently covered
+// by at least one of the given provenances. A provenance covers a subject if
+// its binary name equals the name of the subject, and their digests agree on
+// all algorithms they have in common, of which there must be at least one.
+// Returns an UncoveredSubjectsError listing all subjects that are not
+// covered.
+func VerifySubjectsCovered(subjects []intoto.Subject, provenances []ParsedProvenance) error {
+	var uncovered []intoto.Subject
+	for _, subject := range subjects {
+		covered := false
+		for i := range provenances {
+			if covers(&provenances[i].Provenance, subject) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, subject)
+		}
+	}
+	if len(uncovered) > 0 {
+		return &UncoveredSubjectsError{Subjects: uncovered}
+	}
+	return nil
+}
+
+// covers reports whether the given provenance covers the given subject, see
+// VerifySubjectsCovered.
+func covers(provenance *model.ProvenanceIR, subject intoto.Subject) bool {
+	if provenance.BinaryName() != subject.Name {
+		return false
+	}
+	digests := normalizedBinaryDigests(provenance)
+	common := 0
+	for alg, digest := range subject.Digest {
+		provenanceDigest, found := digests[claims.NormalizeDigestAlgorithm(alg)]
+		if !found {
+			continue
+		}
+		if provenanceDigest != digest {
+			return false
+		}
+		common++
+	}
+	return common > 0
+}
+
+// normalizedBinaryDigests returns the digests of the binary in the given
+// provenance, keyed by algorithm names normalized with
+// claims.NormalizeDigestAlgorithm.
+func normalizedBinaryDigests(provenance *model.ProvenanceIR) map[string]string {
+	digests, err := provenance.BinaryDigests()
+	if err != nil {
+		return map[string]string{"sha2-256": provenance.BinarySHA256Digest()}
+	}
+	normalized := make(map[string]string, len(digests))
+	for alg, digest := range digests {
+		normalized[claims.NormalizeDigestAlgorithm(alg)] = digest
+	}
+	return normalized
+}
+
+// checkValidity returns an error if the given validity exceeds the maximum
+// allowed by the given option, if set.
+func checkValidity(validity claims.ClaimValidity, maxValidity *pb.VerifyMaxValidity) error {
+	if maxValidity == nil {
+		return nil
+	}
+	if validity.NotBefore == nil || validity.NotAfter == nil {
+		return fmt.Errorf("the validity must have both notBefore and notAfter to check it against max_validity")
+	}
+	duration := validity.NotAfter.Sub(*validity.NotBefore)
+	limit := time.Duration(maxValidity.Days) * 24 * time.Hour
+	if duration > limit {
+		return fmt.Errorf("the requested validity of %v exceeds the maximum of %d days", duration, maxValidity.Days)
+	}
+	return nil
+}
+
+// CompanionGenerator generates an additional in-toto statement, such as a
+// link attestation, from an endorsement and the provenances used as evidence
+// for it.
+type CompanionGenerator func(endorsement *intoto.Statement, provenances []ParsedProvenance) (*intoto.Statement, error)
+
+//nolint:gochecknoglobals
+var (
+	companionGeneratorsMu sync.Mutex
+	companionGenerators   = map[string]CompanionGenerator{}
+)
+
+// RegisterCompanionGenerator registers a CompanionGenerator under the given
+// name, to be used by GenerateEndorsementWithCompanions. Registering a
+// generator under an existing name replaces the previous generator.
+func RegisterCompanionGenerator(name string, generator CompanionGenerator) {
+	companionGeneratorsMu.Lock()
+	defer companionGeneratorsMu.Unlock()
+	companionGenerators[name] = generator
+}
+
+// UnregisterCompanionGenerator removes the CompanionGenerator registered under
+// the given name, if any.
+func UnregisterCompanionGenerator(name string) {
+	companionGeneratorsMu.Lock()
+	defer companionGeneratorsMu.Unlock()
+	delete(companionGenerators, name)
+}
+
+// GenerateEndorsementWithCompanions works like GenerateEndorsement, but in
+// addition runs all registered companion generators on the endorsement.
+// Returns the endorsement statement first, followed by the companion
+// statements in the alphabetical order of the names of their generators.
+func GenerateEndorsementWithCompanions(binaryName string, digests intoto.DigestSet, verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) ([]*intoto.Statement, error) {
+	endorsement, err := GenerateEndorsement(binaryName, digests, verOpts, validityDuration, provenances, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	companionGeneratorsMu.Lock()
+	names := make([]string, 0, len(companionGenerators))
+	for name := range companionGenerators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	generators := make([]CompanionGenerator, 0, len(names))
+	for _, name := range names {
+		generators = append(generators, companionGenerators[name])
+	}
+	companionGeneratorsMu.Unlock()
+
+	statements := []*intoto.Statement{endorsement}
+	for i, generate := range generators {
+		statement, err := generate(endorsement, provenances)
+		if err != nil {
+			return nil, fmt.Errorf("companion generator %q failed: %v", names[i], err)
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+// GenerateEndorsementsFromChecksums generates an endorsement for each artifact
+// listed in the checksums file at the given URI, e.g., a `SHA256SUMS` file
+// shipped with a release. Each artifact is endorsed as with
+// GenerateEndorsement, using as evidence those of the given provenances whose
+// subject has the name and SHA256 digest of the artifact. The endorsements are
+// returned in the order of the artifacts in the checksums file. The given load
+// options apply to loading the checksums file. Returns an error if the
+// checksums file cannot be loaded or parsed, or if generating the endorsement
+// for any of the artifacts fails.
+func GenerateEndorsementsFromChecksums(checksumsURI string, loadOptions []func(o *LoadOptions), verOpts *pb.VerificationOptions, validityDuration claims.ClaimValidity, provenances []ParsedProvenance, options ...func(p *claims.ClaimPredicate)) ([]*intoto.Statement, error) {
+	checksumsBytes, err := GetProvenanceBytes(checksumsURI, loadOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load checksums file from %s: %v", checksumsURI, err)
+	}
+	subjects, err := ParseChecksums(checksumsBytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse checksums file from %s: %v", checksumsURI, err)
+	}
+
+	groups := GroupProvenancesBySubject(provenances)
+	endorsements := make([]*intoto.Statement, 0, len(subjects))
+	for _, subject := range subjects {
+		endorsement, err := GenerateEndorsement(subject.Name, subject.Digest, verOpts, validityDuration, groups[claims.SubjectIDOf(subject)], options...)
+		if err != nil {
+			return nil, fmt.Errorf("could not endorse %q: %v", subject.Name, err)
+		}
+		endorsements = append(endorsements, endorsement)
+	}
+	return endorsements, nil
+}
+
+// ParseChecksums parses the content of a checksums file in the format of
+// `sha256sum`, i.e., one `<hex digest>  <name>` pair per line, where the name
+// may be prefixed by `*` to indicate binary mode. Empty lines are ignored.
+// Returns a subject with a "sha2-256" digest for each line, in the order of the
+// lines. Returns an error for malformed lines and duplicate names.
+func ParseChecksums(content []byte) ([]intoto.Subject, error) {
+	var subjects []intoto.Subject
+	seen := make(map[string]bool)
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		digest, name, found := strings.Cut(line, " ")
+		name = strings.TrimPrefix(strings.TrimPrefix(name, " "), "*")
+		if !found || name == "" {
+			return nil, fmt.Errorf("line %d: want `<digest>  <name>`, got %q", i+1, line)
+		}
+		if decoded, err := hex.DecodeString(digest); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("line %d: invalid SHA256 digest %q", i+1, digest)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("line %d: duplicate artifact name %q", i+1, name)
+		}
+		seen[name] = true
+		subjects = append(subjects, intoto.Subject{
+			Name:   name,
+			Digest: intoto.DigestSet{"sha2-256": strings.ToLower(digest)},
+		})
+	}
+	return subjects, nil
 }
 
 // LoadProvenances loads a number of provenance from the give URIs. Returns an
 // array of ParsedProvenance instances, or an error if loading or parsing any
-// of the provenances fails. See LoadProvenance for more details.
-func LoadProvenances(provenanceURIs []string) ([]ParsedProvenance, error) {
+// of the provenances fails. See LoadProvenance for more details, and
+// LoadOptions for what can be configured.
+func LoadProvenances(provenanceURIs []string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	return LoadProvenancesWithContext(context.Background(), provenanceURIs, options...)
+}
+
+// LoadProvenancesWithContext works like LoadProvenances, but fetches remote
+// provenances using the given context, see LoadProvenanceWithContext.
+func LoadProvenancesWithContext(ctx context.Context, provenanceURIs []string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	provenances := make([]ParsedProvenance, 0, len(provenanceURIs))
+	for _, uri := range provenanceURIs {
+		parsedProvenances, err := loadProvenancesFromURI(ctx, uri, options...)
+		if err != nil {
+			return nil, err
+		}
+		provenances = append(provenances, parsedProvenances...)
+	}
+	return provenances, nil
+}
+
+// LoadProvenancesConcurrent works like LoadProvenances, but loads the
+// provenances from up to `parallelism` URIs at a time. The provenances are
+// returned in the order of the URIs. If loading from any of the URIs fails,
+// returns the errors for all failed URIs, combined with multierr, in the order
+// of the URIs.
+func LoadProvenancesConcurrent(provenanceURIs []string, parallelism int, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results := make([][]ParsedProvenance, len(provenanceURIs))
+	errs := make([]error, len(provenanceURIs))
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism && w < len(provenanceURIs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i], errs[i] = loadProvenancesFromURI(context.Background(), provenanceURIs[i], options...)
+			}
+		}()
+	}
+	for i := range provenanceURIs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	if err := multierr.Combine(errs...); err != nil {
+		return nil, err
+	}
+	provenances := make([]ParsedProvenance, 0, len(provenanceURIs))
+	for _, result := range results {
+		provenances = append(provenances, result...)
+	}
+	return provenances, nil
+}
+
+// LoadProvenancesPartial works like LoadProvenances, but instead of failing
+// on the first URI that cannot be loaded, attempts to load the provenances
+// from all URIs. Returns the provenances that were loaded successfully, in
+// order, and an error for every URI that could not be loaded, so that the
+// caller can decide whether enough provenances were loaded to proceed.
+func LoadProvenancesPartial(provenanceURIs []string, options ...func(o *LoadOptions)) ([]ParsedProvenance, []error) {
 	provenances := make([]ParsedProvenance, 0, len(provenanceURIs))
+	var errs []error
 	for _, uri := range provenanceURIs {
-		parsedProvenance, err := LoadProvenance(uri)
+		parsedProvenances, err := loadProvenancesFromURI(context.Background(), uri, options...)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't load the provenance from %s: %v", uri, err)
+			errs = append(errs, err)
+			continue
 		}
+		provenances = append(provenances, parsedProvenances...)
+	}
+	return provenances, errs
+}
+
+// loadProvenancesFromURI loads the provenance from the given URI, or all
+// provenances if it is a JSONL file.
+func loadProvenancesFromURI(ctx context.Context, uri string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	if isJSONL(uri) {
+		return loadProvenancesFromJSONL(ctx, uri, options...)
+	}
+	parsedProvenance, err := LoadProvenanceWithContext(ctx, uri, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the provenance from %s: %w", uri, err)
+	}
+	return []ParsedProvenance{*parsedProvenance}, nil
+}
+
+// LoadProvenancesFromSBOM loads the SBOM from the given URI, and then loads
+// all provenances referenced in the SBOM. Relative references are resolved
+// against the URI of the SBOM, see ResolveProvenanceURI. See model.ParseSBOM
+// for the supported SBOM formats, and LoadProvenance for the supported URIs.
+// The given options apply to loading both the SBOM and the provenances.
+func LoadProvenancesFromSBOM(sbomURI string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	sbomBytes, err := GetProvenanceBytes(sbomURI, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the SBOM bytes from %s: %v", sbomURI, err)
+	}
+	sbom, err := model.ParseSBOM(sbomBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse the SBOM from %s: %v", sbomURI, err)
+	}
+	provenanceURIs := make([]string, 0, len(sbom.ProvenanceURIs))
+	for _, reference := range sbom.ProvenanceURIs {
+		uri, err := ResolveProvenanceURI(sbomURI, reference)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't resolve the provenance reference %q in %s: %v", reference, sbomURI, err)
+		}
+		provenanceURIs = append(provenanceURIs, uri)
+	}
+	return LoadProvenances(provenanceURIs, options...)
+}
+
+// VerifyConsistentWithSBOM checks that the digests in the given provenance are
+// consistent with the components listed in the SBOM at the given URI, in the
+// SPDX or CycloneDX JSON format. The binary digests of the subject, the digest
+// of the builder image, if any, and the digests of the resolved dependencies
+// must each match the digests of a component of the SBOM, i.e., agree for all
+// algorithms in common. Dependencies fetched from version control, i.e., with
+// a "git+" URI, are identified by commit rather than listed as components, and
+// are therefore skipped. Returns an error listing all digests without a
+// matching component. The given options apply to loading the SBOM.
+func VerifyConsistentWithSBOM(provenance *ParsedProvenance, sbomURI string, options ...func(o *LoadOptions)) error {
+	sbomBytes, err := GetProvenanceBytes(sbomURI, options...)
+	if err != nil {
+		return fmt.Errorf("couldn't load the SBOM bytes from %s: %v", sbomURI, err)
+	}
+	sbom, err := model.ParseSBOM(sbomBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse the SBOM from %s: %v", sbomURI, err)
+	}
+
+	p := &provenance.Provenance
+	binaryDigests, err := p.BinaryDigests()
+	if err != nil {
+		binaryDigests = map[string]string{"sha256": p.BinarySHA256Digest()}
+	}
+	var errs error
+	if _, found := sbom.FindComponent(binaryDigests); !found {
+		errs = multierr.Append(errs, fmt.Errorf("no component of the SBOM matches the digests %v of the subject %q", binaryDigests, p.BinaryName()))
+	}
+	if builderImageDigest, err := p.BuilderImageSHA256Digest(); err == nil {
+		if _, found := sbom.FindComponent(map[string]string{"sha256": builderImageDigest}); !found {
+			errs = multierr.Append(errs, fmt.Errorf("no component of the SBOM matches the SHA256 digest %s of the builder image", builderImageDigest))
+		}
+	}
+	dependencies, _ := p.ResolvedDependencies()
+	for _, dependency := range dependencies {
+		if strings.HasPrefix(dependency.URI, "git+") || len(dependency.Digest) == 0 {
+			continue
+		}
+		if _, found := sbom.FindComponent(dependency.Digest); !found {
+			errs = multierr.Append(errs, fmt.Errorf("no component of the SBOM matches the digests %v of the dependency %q", dependency.Digest, dependency.URI))
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("the provenance is inconsistent with the SBOM from %s: %v", sbomURI, errs)
+	}
+	return nil
+}
+
+// ResolveProvenanceURI resolves the given provenance reference, as listed in a
+// manifest such as an SBOM, against the URI of the manifest, as described in
+// RFC 3986. The manifest URI must be absolute, e.g., a file or an HTTP(S) URI.
+// Relative references, such as "provenance.json" or "../provenances/1.json",
+// are resolved relative to the location of the manifest, while absolute
+// references are returned unchanged.
+func ResolveProvenanceURI(manifestURI string, reference string) (string, error) {
+	base, err := url.Parse(manifestURI)
+	if err != nil {
+		return "", fmt.Errorf("could not parse the manifest URI %q: %v", manifestURI, err)
+	}
+	if !base.IsAbs() {
+		return "", fmt.Errorf("the manifest URI %q is not absolute", manifestURI)
+	}
+	ref, err := url.Parse(reference)
+	if err != nil {
+		return "", fmt.Errorf("could not parse the provenance reference %q: %v", reference, err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// isJSONL reports whether the path of the given URI has the ".jsonl"
+// extension.
+func isJSONL(provenanceURI string) bool {
+	uri, err := url.Parse(provenanceURI)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(filepath.Ext(uri.Path), ".jsonl")
+}
+
+// LoadProvenancesFromJSONL loads a JSONL file from the given URI, and parses
+// each of its non-blank lines as a provenance, like LoadProvenance. The
+// SourceMetadata of each provenance records the line it was parsed from.
+// LoadProvenances uses this for URIs with the ".jsonl" extension.
+func LoadProvenancesFromJSONL(jsonlURI string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	return loadProvenancesFromJSONL(context.Background(), jsonlURI, options...)
+}
+
+func loadProvenancesFromJSONL(ctx context.Context, jsonlURI string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	jsonlBytes, err := GetProvenanceBytesWithContext(ctx, jsonlURI, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the JSONL bytes from %s: %w", jsonlURI, err)
+	}
+	return parseProvenancesFromJSONL(jsonlURI, jsonlBytes, newLoadOptions(options))
+}
+
+// parseProvenancesFromJSONL parses each non-blank line of the given bytes,
+// loaded from the given URI, as a provenance.
+func parseProvenancesFromJSONL(jsonlURI string, jsonlBytes []byte, opts LoadOptions) ([]ParsedProvenance, error) {
+	var provenances []ParsedProvenance
+	for i, line := range bytes.Split(jsonlBytes, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		parsedProvenance, err := parseProvenance(jsonlURI, line, opts)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load the provenance from line %d of %s: %v", i+1, jsonlURI, err)
+		}
+		parsedProvenance.SourceMetadata.Line = i + 1
 		provenances = append(provenances, *parsedProvenance)
 	}
 	return provenances, nil
 }
 
+// LoadProvenanceBundle loads a file from the given URI that contains either a
+// single provenance, or several provenances as newline-delimited JSON
+// (JSONL), e.g., an aggregate evidence file, and returns one ParsedProvenance
+// per provenance. Unlike LoadProvenances, JSONL is detected from the content
+// rather than the extension of the URI. As with LoadProvenancesFromJSONL, the
+// SHA256Digest in the SourceMetadata of a provenance from a JSONL file is
+// the digest of its line, and Line records the line. A single provenance is
+// parsed as by LoadProvenance, and may span several lines.
+func LoadProvenanceBundle(bundleURI string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	opts := newLoadOptions(options)
+	bundleBytes, err := GetProvenanceBytes(bundleURI, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the bundle bytes from %s: %w", bundleURI, err)
+	}
+	if isJSONStream(bundleBytes) {
+		return parseProvenancesFromJSONL(bundleURI, bundleBytes, opts)
+	}
+	provenance, err := parseProvenance(bundleURI, bundleBytes, opts)
+	if err != nil {
+		return nil, err
+	}
+	return []ParsedProvenance{*provenance}, nil
+}
+
+// isJSONStream reports whether the given bytes start with a JSON value that is
+// followed by more content, i.e., are not a single JSON document.
+func isJSONStream(content []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	var value json.RawMessage
+	if err := decoder.Decode(&value); err != nil {
+		return false
+	}
+	return !errors.Is(decoder.Decode(&value), io.EOF)
+}
+
+// LoadOptions configures the loading of provenances in LoadProvenance, and
+// the other functions taking options of this type. The options apply to a
+// single call, so that concurrent callers can use different options.
+type LoadOptions struct {
+	// HTTPClient is the client used for fetching provenances over HTTP(S),
+	// e.g., to configure a proxy, custom TLS roots, or additional headers. If
+	// OAuth2ClientCredentials is set, the client is also used for obtaining
+	// tokens, and fetches are authenticated on top of it. If nil, a default
+	// client with a timeout of DefaultHTTPTimeout is used.
+	HTTPClient *http.Client
+	// OAuth2ClientCredentials, if set with WithOAuth2ClientCredentials, makes
+	// fetches over HTTPS from the hosts of the credentials authenticate with
+	// a bearer token obtained using the OAuth2 client credentials flow. If
+	// nil, fetches are unauthenticated.
+	OAuth2ClientCredentials *OAuth2ClientCredentials
+	// Netrc holds the credentials that fetches over HTTPS authenticate with,
+	// see WithNetrc. If nil, no credentials from a netrc file are used.
+	Netrc *Netrc
+	// RetryPolicy configures how fetches over HTTP(S) are retried after
+	// transient failures. If nil, DefaultRetryPolicy is used.
+	RetryPolicy *RetryPolicy
+	// S3Client is the client used for fetching provenances with "s3" URIs. If
+	// nil, a client using the default AWS credential chain and configuration,
+	// and S3Region, is created for every fetch.
+	S3Client S3GetObjectAPI
+	// S3Region is the AWS region of the S3 client created if S3Client is nil.
+	// If empty, the region is taken from the default AWS configuration, e.g.,
+	// the AWS_REGION environment variable.
+	S3Region string
+	// WebSocketTimeout is the timeout for connecting to a WebSocket endpoint
+	// and receiving the provenance from it, when fetching provenances with
+	// "ws" or "wss" URIs. If zero, DefaultWebSocketTimeout is used.
+	WebSocketTimeout time.Duration
+	// LocalMirrors maps URI prefixes to local directories containing copies
+	// of the remote files whose URIs start with them, see WithLocalMirror.
+	LocalMirrors map[string]string
+	// NormalizeToSLSAv1 makes LoadProvenance set the NormalizedStatement of
+	// the result.
+	NormalizeToSLSAv1 bool
+	// SigstoreTrustRoot is the trust root that provenances in Sigstore
+	// bundles are verified against, see model.WithSigstoreTrustRoot. If nil,
+	// bundles are not verified, and provenances carry neither a signing time
+	// nor a signer identity.
+	SigstoreTrustRoot *model.SigstoreTrustRoot
+	// SignatureVerifiers are the verifiers that the signatures of DSSE
+	// envelopes are verified with, see model.WithSignatureVerifiers. If
+	// empty, signatures are not verified, and provenances have no signers.
+	SignatureVerifiers []dsse.Verifier
+
+	// oauth2Tokens caches the tokens for OAuth2ClientCredentials.
+	oauth2Tokens *oauth2Tokens
+}
+
+// newLoadOptions applies the given options to the zero LoadOptions.
+func newLoadOptions(options []func(o *LoadOptions)) LoadOptions {
+	var opts LoadOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.OAuth2ClientCredentials != nil && opts.oauth2Tokens == nil {
+		opts.oauth2Tokens = newOAuth2Tokens(*opts.OAuth2ClientCredentials)
+	}
+	return opts
+}
+
+// WithHTTPClient makes remote provenances be fetched over HTTP(S) with the
+// given client, see LoadOptions.HTTPClient.
+func WithHTTPClient(client *http.Client) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.HTTPClient = client
+	}
+}
+
+// WithSigstoreTrustRoot makes provenances in Sigstore bundles be verified
+// against the given trust root, see LoadOptions.SigstoreTrustRoot. Bundles
+// that do not verify fail to load.
+func WithSigstoreTrustRoot(root *model.SigstoreTrustRoot) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.SigstoreTrustRoot = root
+	}
+}
+
+// WithSignatureVerifiers makes the signatures of DSSE envelopes be verified
+// with the given verifiers, see LoadOptions.SignatureVerifiers, so that the
+// signers of the provenances are known, e.g., for the distinct_signers step.
+// Envelopes none of whose signatures verify fail to load.
+func WithSignatureVerifiers(verifiers ...dsse.Verifier) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.SignatureVerifiers = append(o.SignatureVerifiers, verifiers...)
+	}
+}
+
+// envelopeOptions returns the options for parsing envelopes with
+// model.ParseEnvelope.
+func (o LoadOptions) envelopeOptions() []func(o *model.EnvelopeOptions) {
+	var options []func(o *model.EnvelopeOptions)
+	if o.SigstoreTrustRoot != nil {
+		options = append(options, model.WithSigstoreTrustRoot(o.SigstoreTrustRoot))
+	}
+	if len(o.SignatureVerifiers) > 0 {
+		options = append(options, model.WithSignatureVerifiers(o.SignatureVerifiers...))
+	}
+	return options
+}
+
+// WithSLSAv1Normalization makes LoadProvenance re-emit the loaded provenance,
+// after mapping it to ProvenanceIR, as a SLSA v1 statement with
+// model.ToStatement, so that all provenances are presented uniformly
+// regardless of the version of their format. The statement is returned as the
+// NormalizedStatement of the result.
+func WithSLSAv1Normalization() func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.NormalizeToSLSAv1 = true
+	}
+}
+
 // LoadProvenance loads a provenance from the give URI (either a local file or
 // a remote file on an HTTP/HTTPS server). Returns an instance of
 // ParsedProvenance if loading and parsing is successful, or an error Otherwise.
-func LoadProvenance(provenanceURI string) (*ParsedProvenance, error) {
-	provenanceBytes, err := GetProvenanceBytes(provenanceURI)
+// The provenance must have a single subject, see LoadProvenancePerSubject for
+// provenances with several subjects. See LoadOptions for what can be
+// configured.
+func LoadProvenance(provenanceURI string, options ...func(o *LoadOptions)) (*ParsedProvenance, error) {
+	return LoadProvenanceWithContext(context.Background(), provenanceURI, options...)
+}
+
+// LoadProvenanceWithContext works like LoadProvenance, but fetches remote
+// provenances using the given context, so that slow fetches can be canceled
+// or time out. If the context is done before the provenance has been fetched,
+// the returned error wraps the error of the context.
+func LoadProvenanceWithContext(ctx context.Context, provenanceURI string, options ...func(o *LoadOptions)) (*ParsedProvenance, error) {
+	opts := newLoadOptions(options)
+	provenanceBytes, err := GetProvenanceBytesWithContext(ctx, provenanceURI, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the provenance bytes from %s: %w", provenanceURI, err)
+	}
+	provenance, err := parseProvenance(provenanceURI, provenanceBytes, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.NormalizeToSLSAv1 {
+		provenance.NormalizedStatement, err = model.ToStatement(&provenance.Provenance)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't normalize the provenance from %s: %v", provenanceURI, err)
+		}
+	}
+	return provenance, nil
+}
+
+// LoadProvenanceFromReader works like LoadProvenance, but reads the provenance
+// from the given reader, e.g., the standard input, hashing it while reading.
+// The given source URI is only used as a label, in errors and as the URI in the
+// SourceMetadata of the result. At most MaxProvenanceSize bytes are read.
+func LoadProvenanceFromReader(r io.Reader, sourceURI string, options ...func(o *LoadOptions)) (*ParsedProvenance, error) {
+	hash := sha256.New()
+	provenanceBytes, err := readAllLimited(io.TeeReader(r, hash))
 	if err != nil {
-		return nil, fmt.Errorf("couldn't load the provenance bytes from %s: %v", provenanceURI, err)
+		return nil, fmt.Errorf("couldn't read the provenance bytes from %s: %w", sourceURI, err)
 	}
+	return parseProvenanceWithDigest(sourceURI, provenanceBytes, hex.EncodeToString(hash.Sum(nil)), newLoadOptions(options))
+}
 
+// parseProvenance parses the given bytes, loaded from the given URI, as a
+// statement or an envelope, using the given options, and maps it to a
+// ParsedProvenance.
+func parseProvenance(provenanceURI string, provenanceBytes []byte, opts LoadOptions) (*ParsedProvenance, error) {
+	sum256 := sha256.Sum256(provenanceBytes)
+	return parseProvenanceWithDigest(provenanceURI, provenanceBytes, hex.EncodeToString(sum256[:]), opts)
+}
+
+// parseProvenanceWithDigest works like parseProvenance, given the hex-encoded
+// SHA256 digest of the bytes.
+func parseProvenanceWithDigest(provenanceURI string, provenanceBytes []byte, sha256Digest string, opts LoadOptions) (*ParsedProvenance, error) {
 	// Parse into a validated provenance to get the predicate/build type of the provenance.
 	var errs error
 	validatedProvenance, err := model.ParseStatementData(provenanceBytes)
 	if err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("parsing bytes as an in-toto statement: %v", err))
-		validatedProvenance, err = model.ParseEnvelope(provenanceBytes)
+		validatedProvenance, err = model.ParseEnvelope(provenanceBytes, opts.envelopeOptions()...)
 		if err != nil {
 			errs = multierr.Append(errs, fmt.Errorf("parsing bytes as a DSSE envelop: %v", err))
 			return nil, fmt.Errorf("couldn't parse bytes from %s into a validated provenance: %v", provenanceURI, errs)
 		}
 	}
 
+	return toParsedProvenance(provenanceURI, validatedProvenance, sha256Digest)
+}
+
+// LoadProvenancePerSubject works like LoadProvenance, but accepts provenances
+// with several subjects, e.g., SLSA v1 provenances of builds producing
+// several binaries, and returns one ParsedProvenance per subject, in the
+// order of the subjects. All returned provenances have the same
+// SourceMetadata. Use GroupProvenancesBySubject or GenerateEndorsements to
+// endorse the subjects.
+func LoadProvenancePerSubject(provenanceURI string, options ...func(o *LoadOptions)) ([]ParsedProvenance, error) {
+	opts := newLoadOptions(options)
+	provenanceBytes, err := GetProvenanceBytes(provenanceURI, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the provenance bytes from %s: %w", provenanceURI, err)
+	}
+	sum256 := sha256.Sum256(provenanceBytes)
+	sha256Digest := hex.EncodeToString(sum256[:])
+
+	var errs error
+	validatedProvenances, err := model.ParseStatementDataPerSubject(provenanceBytes)
+	if err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("parsing bytes as an in-toto statement: %v", err))
+		validatedProvenances, err = model.ParseEnvelopePerSubject(provenanceBytes, opts.envelopeOptions()...)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("parsing bytes as a DSSE envelop: %v", err))
+			return nil, fmt.Errorf("couldn't parse bytes from %s into validated provenances: %v", provenanceURI, errs)
+		}
+	}
+
+	provenances := make([]ParsedProvenance, 0, len(validatedProvenances))
+	for _, validatedProvenance := range validatedProvenances {
+		parsedProvenance, err := toParsedProvenance(provenanceURI, validatedProvenance, sha256Digest)
+		if err != nil {
+			return nil, err
+		}
+		provenances = append(provenances, *parsedProvenance)
+	}
+	return provenances, nil
+}
+
+// toParsedProvenance maps the given validated provenance, loaded from the
+// given URI, to a ParsedProvenance.
+func toParsedProvenance(provenanceURI string, validatedProvenance *model.ValidatedProvenance, sha256Digest string) (*ParsedProvenance, error) {
 	// Map to internal provenance representation based on the predicate/build type.
 	provenanceIR, err := model.FromValidatedProvenance(validatedProvenance)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't map from %s to internal representation: %v", validatedProvenance, err)
+		return nil, fmt.Errorf("couldn't map from %s to internal representation: %v", provenanceURI, err)
 	}
-	sum256 := sha256.Sum256(provenanceBytes)
+	// The builder ID is empty if the mapper does not extract it.
+	builderID, _ := provenanceIR.TrustedBuilder()
 	return &ParsedProvenance{
 		Provenance: *provenanceIR,
 		SourceMetadata: claims.ProvenanceData{
 			URI:          provenanceURI,
-			SHA256Digest: hex.EncodeToString(sum256[:]),
+			SHA256Digest: sha256Digest,
+			BuilderID:    builderID,
 		},
 	}, nil
 }
 
+// WithLocalMirror adds a local directory containing copies of the remote
+// files whose URIs start with the given prefix, e.g., for use in air-gapped
+// environments, see LoadOptions.LocalMirrors. The local copy of a URI is
+// found by replacing the prefix with the directory. Adding a directory for an
+// existing prefix replaces the previous directory.
+func WithLocalMirror(uriPrefix string, dir string) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		mirrors := make(map[string]string, len(o.LocalMirrors)+1)
+		for p, d := range o.LocalMirrors {
+			mirrors[p] = d
+		}
+		mirrors[uriPrefix] = dir
+		o.LocalMirrors = mirrors
+	}
+}
+
+// localMirrorPath returns the path of the local copy of the given URI, using
+// the given mirror with the longest matching prefix. Returns false if no
+// mirror matches, or if the resulting path would be outside the mirror.
+func localMirrorPath(uri string, localMirrors map[string]string) (string, bool) {
+	prefix := ""
+	for p := range localMirrors {
+		if strings.HasPrefix(uri, p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	if prefix == "" {
+		return "", false
+	}
+	dir := localMirrors[prefix]
+	rest, _, _ := strings.Cut(strings.TrimPrefix(uri, prefix), "?")
+	path := filepath.Join(dir, filepath.FromSlash(rest))
+	if rel, err := filepath.Rel(dir, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return path, true
+}
+
 // GetProvenanceBytes fetches provenance bytes from the give URI. Supported URI
-// schemes are "http", "https", and "file". Only local files are supported.
-func GetProvenanceBytes(provenanceURI string) ([]byte, error) {
+// schemes are "http", "https", "file", "s3", "ws", "wss", and "nix". Only
+// local files are supported. Objects in S3 are fetched using the default AWS
+// credential chain, see WithS3Region and WithS3Client. From WebSocket
+// endpoints, a single message is received, see WithWebSocketTimeout. With
+// "nix" URIs of
+// the form `nix://<cache>/<hash>-<name>`, the provenance of a store path is
+// fetched from a Nix binary cache, at the location given in the
+// NarinfoProvenanceKey field of its narinfo.
+// If a local mirror is configured for the URI (see WithLocalMirror), and
+// contains a copy of the file, the local copy is read instead.
+// Gzip-compressed files are decompressed transparently: HTTP responses with
+// the "gzip" Content-Encoding, local files with the ".gz" extension, and files
+// starting with the gzip magic bytes. The returned bytes, and hence the
+// recorded digests, are those of the decompressed files.
+// See LoadOptions for what can be configured.
+func GetProvenanceBytes(provenanceURI string, options ...func(o *LoadOptions)) ([]byte, error) {
+	return GetProvenanceBytesWithContext(context.Background(), provenanceURI, options...)
+}
+
+// GetProvenanceBytesWithContext works like GetProvenanceBytes, but fetches
+// remote provenances using the given context. If the context is done before
+// the provenance has been fetched completely, returns an error wrapping the
+// error of the context, and no bytes.
+func GetProvenanceBytesWithContext(ctx context.Context, provenanceURI string, options ...func(o *LoadOptions)) ([]byte, error) {
+	opts := newLoadOptions(options)
+	if path, found := localMirrorPath(provenanceURI, opts.LocalMirrors); found {
+		if _, err := os.Stat(path); err == nil {
+			return readLocalFile(path)
+		}
+	}
+
 	uri, err := url.Parse(provenanceURI)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse the URI (%q): %v", provenanceURI, err)
 	}
 
 	if uri.Scheme == "http" || uri.Scheme == "https" {
-		return getJSONOverHTTP(provenanceURI)
+		return getJSONOverHTTP(ctx, provenanceURI, opts)
 	} else if uri.Scheme == "file" {
 		return getLocalJSONFile(uri)
+	} else if uri.Scheme == "s3" {
+		return getS3Object(ctx, uri, opts)
+	} else if uri.Scheme == "ws" || uri.Scheme == "wss" {
+		return getOverWebSocket(ctx, uri, opts)
+	} else if uri.Scheme == "nix" {
+		return getFromNixCache(ctx, uri, opts)
 	}
 
 	return nil, fmt.Errorf("unsupported URI scheme (%q)", uri.Scheme)
 }
 
-func getJSONOverHTTP(uri string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, nil)
+// ProvenanceMeta contains metadata about a provenance, obtained without
+// downloading its content.
+type ProvenanceMeta struct {
+	// Size of the provenance in bytes, or -1 if unknown.
+	Size int64
+	// ContentType is the media type of the provenance, if known.
+	ContentType string
+	// ModTime is the time the provenance was last modified. Zero if unknown.
+	ModTime time.Time
+}
+
+// ProbeProvenance fetches metadata about the provenance at the given URI, for
+// pre-flight checks before downloading it. For "http" and "https" URIs a HEAD
+// request is issued, for "file" URIs the file is stat'ed and its content type
+// is guessed from the file extension. See LoadOptions for what can be
+// configured, e.g., the HTTP client and its authentication.
+func ProbeProvenance(ctx context.Context, provenanceURI string, options ...func(o *LoadOptions)) (ProvenanceMeta, error) {
+	uri, err := url.Parse(provenanceURI)
+	if err != nil {
+		return ProvenanceMeta{}, fmt.Errorf("could not parse the URI (%q): %v", provenanceURI, err)
+	}
+
+	if uri.Scheme == "http" || uri.Scheme == "https" {
+		return probeOverHTTP(ctx, provenanceURI, newLoadOptions(options))
+	} else if uri.Scheme == "file" {
+		return probeLocalFile(uri)
+	}
+
+	return ProvenanceMeta{}, fmt.Errorf("unsupported URI scheme (%q)", uri.Scheme)
+}
+
+// ProbeResult is the result of probing a single provenance URI, see
+// ProbeProvenances.
+type ProbeResult struct {
+	URI string
+	// Meta is the metadata of the provenance, if it is available.
+	Meta ProvenanceMeta
+	// Err is the error probing the provenance, or nil if it is available.
+	Err error
+}
+
+// Available reports whether the probed provenance is available.
+func (r ProbeResult) Available() bool {
+	return r.Err == nil
+}
+
+// ProbeProvenances probes the provenances at the given URIs using
+// ProbeProvenance, e.g., to check which provenances are available before a
+// large verification run. Probes up to `concurrency` URIs at a time. Returns
+// one result per URI, in the order of the URIs. URIs that have not been probed
+// when the context is done fail with the error of the context. The given
+// options are passed to ProbeProvenance.
+func ProbeProvenances(ctx context.Context, provenanceURIs []string, concurrency int, options ...func(o *LoadOptions)) []ProbeResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make([]ProbeResult, len(provenanceURIs))
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(provenanceURIs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i].URI = provenanceURIs[i]
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				results[i].Meta, results[i].Err = ProbeProvenance(ctx, provenanceURIs[i], options...)
+			}
+		}()
+	}
+	for i := range provenanceURIs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return results
+}
+
+func probeOverHTTP(ctx context.Context, uri string, opts LoadOptions) (ProvenanceMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
+	if err != nil {
+		return ProvenanceMeta{}, fmt.Errorf("could not create HTTP request: %v", err)
+	}
+
+	resp, err := doHTTPRequest(req, opts)
+	if err != nil {
+		return ProvenanceMeta{}, fmt.Errorf("could not receive response from server: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return ProvenanceMeta{}, fmt.Errorf("probing %s: %w (status %s)", uri, ErrURLExpired, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return ProvenanceMeta{}, fmt.Errorf("probing %s: unexpected status %s", uri, resp.Status)
+	}
+
+	meta := ProvenanceMeta{
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+		modTime, err := http.ParseTime(lastModified)
+		if err != nil {
+			return ProvenanceMeta{}, fmt.Errorf("invalid Last-Modified header %q: %v", lastModified, err)
+		}
+		meta.ModTime = modTime
+	}
+	return meta, nil
+}
+
+func probeLocalFile(uri *url.URL) (ProvenanceMeta, error) {
+	if uri.Host != "" {
+		return ProvenanceMeta{}, fmt.Errorf("invalid scheme (%q) and host (%q) combination", uri.Scheme, uri.Host)
+	}
+	info, err := os.Stat(uri.Path)
+	if err != nil {
+		return ProvenanceMeta{}, fmt.Errorf("could not stat %q: %v", uri.Path, err)
+	}
+	if info.IsDir() {
+		return ProvenanceMeta{}, fmt.Errorf("%q is a directory", uri.Path)
+	}
+	return ProvenanceMeta{
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(filepath.Ext(uri.Path)),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// ErrURLExpired is returned when a server denies access to a URL, which for
+// pre-signed URLs usually means that the URL has expired.
+var ErrURLExpired = errors.New("URL expired or access denied")
+
+// URLRefresher returns a fresh URL to use in place of the given expired one,
+// e.g., by pre-signing the underlying object again.
+type URLRefresher func(expiredURI string) (string, error)
+
+// GetProvenanceBytesWithRefresh works like GetProvenanceBytesWithContext, but
+// if the server denies access to the URI, calls `refresh` to obtain a fresh
+// URI and retries once with it. The given context and options apply to both
+// attempts.
+func GetProvenanceBytesWithRefresh(ctx context.Context, provenanceURI string, refresh URLRefresher, options ...func(o *LoadOptions)) ([]byte, error) {
+	provenanceBytes, err := GetProvenanceBytesWithContext(ctx, provenanceURI, options...)
+	if err == nil || !errors.Is(err, ErrURLExpired) || refresh == nil {
+		return provenanceBytes, err
+	}
+
+	refreshedURI, refreshErr := refresh(provenanceURI)
+	if refreshErr != nil {
+		return nil, fmt.Errorf("%v; could not refresh the URL: %v", err, refreshErr)
+	}
+	return GetProvenanceBytesWithContext(ctx, refreshedURI, options...)
+}
+
+// maxErrorBodyBytes is the maximum number of bytes of the body of an error
+// response included in errors.
+const maxErrorBodyBytes = 256
+
+// getJSONOverHTTP fetches the content at the given URI as configured by the
+// given options, e.g., with their client, or with the default client if not
+// set. If the given context is canceled, or its deadline is exceeded, before
+// the content has been read completely, returns an error wrapping the error
+// of the context, and no bytes. Responses with a status other than 2xx result in an error. Network
+// errors and responses with a 5xx status are retried as configured with
+// WithRetryPolicy. The response body is closed in any case.
+func getJSONOverHTTP(ctx context.Context, uri string, opts LoadOptions) ([]byte, error) {
+	return withRetries(ctx, uri, opts.retryPolicy(), func() ([]byte, error) {
+		return getJSONOverHTTPOnce(ctx, uri, opts)
+	})
+}
+
+// getJSONOverHTTPOnce makes a single attempt at fetching the content at the
+// given URI for getJSONOverHTTP. Transient failures are returned as a
+// retryableError.
+func getJSONOverHTTPOnce(ctx context.Context, uri string, opts LoadOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("could not create HTTP request: %v", err)
 	}
 
 	req.Header.Set("Accept", "application/json")
+	// Setting Accept-Encoding disables the transparent decompression of the
+	// transport, so that gzip is handled the same with any HTTP client.
+	req.Header.Set("Accept-Encoding", "gzip")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := doHTTPRequest(req, opts)
 	if err != nil {
-		return nil, fmt.Errorf("could not receive response from server: %v", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("fetching %s: %w", uri, ctxErr)
+		}
+		if errors.Is(err, errTokenRequest) {
+			return nil, fmt.Errorf("could not receive response from server: %v", err)
+		}
+		return nil, &retryableError{fmt.Errorf("could not receive response from server: %v", err)}
 	}
 
 	defer resp.Body.Close()
 
-	return io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Include the beginning of the body, which usually explains the error.
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		if resp.StatusCode == http.StatusForbidden {
+			return nil, fmt.Errorf("unexpected HTTP status %d fetching %s: %w: %q", resp.StatusCode, uri, ErrURLExpired, redactHTTPSecrets(string(snippet), opts))
+		}
+		err := fmt.Errorf("unexpected HTTP status %d fetching %s: %q", resp.StatusCode, uri, redactHTTPSecrets(string(snippet), opts))
+		if resp.StatusCode >= 500 {
+			return nil, &retryableError{err}
+		}
+		return nil, err
+	}
+
+	if resp.ContentLength > MaxProvenanceSize {
+		return nil, errTooLarge()
+	}
+	counter := &countingReader{r: resp.Body}
+	body, err := readAllLimited(counter)
+	if errors.Is(err, ErrProvenanceTooLarge) {
+		return nil, err
+	}
+	if err != nil {
+		// Never return the partially read body.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("fetching %s: %w after reading %d bytes", uri, ctxErr, counter.n)
+		}
+		return nil, &retryableError{fmt.Errorf("reading the response from %s: %v", uri, err)}
+	}
+	// Compressed files, e.g., ".json.gz", may also be served as is.
+	if isGzipEncoding(resp.Header.Get("Content-Encoding")) || isGzip(body) {
+		return gunzipLimited(body)
+	}
+	return body, nil
+}
+
+// countingReader counts the bytes read from the wrapped reader, so that the
+// progress of failed reads can be reported.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
 }
 
 func getLocalJSONFile(uri *url.URL) ([]byte, error) {
@@ -176,5 +1561,53 @@ func getLocalJSONFile(uri *url.URL) ([]byte, error) {
 	if _, err := os.Stat(uri.Path); errors.Is(err, os.ErrNotExist) {
 		return nil, fmt.Errorf("%q does not exist", uri.Path)
 	}
-	return os.ReadFile(uri.Path)
+	return readLocalFile(uri.Path)
+}
+
+// MaxProvenanceSize is the maximum size in bytes of provenances, and other
+// files such as SBOMs, read by GetProvenanceBytes. Reading larger files fails,
+// which protects against running out of memory when reading from untrusted
+// sources. It may be increased to handle larger legitimate documents.
+//
+//nolint:gochecknoglobals
+var MaxProvenanceSize int64 = 16 << 20
+
+// ErrProvenanceTooLarge is returned when a file exceeds MaxProvenanceSize.
+var ErrProvenanceTooLarge = errors.New("provenance exceeds maximum size")
+
+func errTooLarge() error {
+	return fmt.Errorf("%w of %d bytes", ErrProvenanceTooLarge, MaxProvenanceSize)
+}
+
+// readAllLimited reads from the given reader until EOF, or until more than
+// MaxProvenanceSize bytes have been read, in which case it returns an error.
+func readAllLimited(r io.Reader) ([]byte, error) {
+	limit := MaxProvenanceSize
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, errTooLarge()
+	}
+	return content, nil
+}
+
+// readLocalFile reads the file at the given path, like os.ReadFile, but at
+// most MaxProvenanceSize bytes. Files with the ".gz" extension, or with
+// gzip-compressed content, are decompressed.
+func readLocalFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	content, err := readAllLimited(file)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".gz") || isGzip(content) {
+		return gunzipLimited(content)
+	}
+	return content, nil
 }
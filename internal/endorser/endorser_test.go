@@ -15,22 +15,53 @@
 package endorser
 
 import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+
+	"github.com/project-oak/transparent-release/internal/model"
 	"github.com/project-oak/transparent-release/internal/testutil"
+	"github.com/project-oak/transparent-release/internal/verifier"
 	"github.com/project-oak/transparent-release/pkg/claims"
+	"github.com/project-oak/transparent-release/pkg/intoto"
+	slsav02 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
+	slsav1 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v1"
 	pb "github.com/project-oak/transparent-release/pkg/proto/oak/release"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
 	provenancePath          = "../../testdata/slsa_v02_provenance.json"
 	differentProvenancePath = "../../testdata/different_slsa_v02_provenance.json"
-	binaryDigest            = "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"
-	binaryName              = "oak_functions_freestanding_bin"
+	slsav1ProvenancePath    = "../../testdata/slsa_v1_provenance.json"
+	// A SLSA v1 provenance with two subjects.
+	slsav1GenericProvenancePath = "../../testdata/slsa_v1_generic_provenance.json"
+	checksumsPath               = "../../testdata/SHA256SUMS"
+	binaryDigest                = "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"
+	binaryName                  = "oak_functions_freestanding_bin"
+	otherDigest                 = "813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"
 )
 
 func createClaimValidity(days int) claims.ClaimValidity {
@@ -86,6 +117,33 @@ func TestGenerateEndorsement_SingleProvenanceSucess(t *testing.T) {
 	testutil.AssertEq(t, "evidence length", len(predicate.Evidence), 1)
 }
 
+func TestGenerateEndorsement_RecordsBuilderID(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	wantBuilderID, err := provenances[0].Provenance.TrustedBuilder()
+	if err != nil {
+		t.Fatalf("Could not get the builder of the provenance: %v", err)
+	}
+	testutil.AssertEq(t, "builder ID", provenances[0].SourceMetadata.BuilderID, wantBuilderID)
+
+	digests := map[string]string{"sha2-256": binaryDigest}
+	statement, err := GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	predicate := statement.Predicate.(claims.ClaimPredicate)
+	testutil.AssertEq(t, "evidence builder ID", predicate.Evidence[0].BuilderID, wantBuilderID)
+
+	statementBytes, err := json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("Could not marshal the endorsement: %v", err)
+	}
+	parsed, err := claims.ParseEndorsementV2Bytes(statementBytes)
+	if err != nil {
+		t.Fatalf("Could not parse the endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "parsed builder ID", parsed.Predicate.(claims.ClaimPredicate).Evidence[0].BuilderID, wantBuilderID)
+}
+
 func TestGenerateEndorsement_BinaryNameMismatchFailure(t *testing.T) {
 	verOpts := pb.VerificationOptions{}
 	provenances := createProvenanceList(t, []string{provenancePath})
@@ -99,6 +157,176 @@ func TestGenerateEndorsement_BinaryNameMismatchFailure(t *testing.T) {
 	}
 }
 
+func TestVerifyProvenances(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	digests := map[string]string{"sha2-256": binaryDigest}
+	verOpts := pb.VerificationOptions{
+		AllWithRepository: &pb.VerifyAllWithRepository{RepositoryUri: "git+https://github.com/project-oak/oak@refs/heads/main"},
+		// Not checked without a validity.
+		MaxValidity: &pb.VerifyMaxValidity{Days: 1},
+	}
+	if err := VerifyProvenances(binaryName, digests, &verOpts, provenances); err != nil {
+		t.Errorf("Failed to verify provenances: %v", err)
+	}
+
+	tests := map[string]struct {
+		binaryName string
+		verOpts    *pb.VerificationOptions
+	}{
+		"binary name mismatch": {binaryName: "other_binary", verOpts: &pb.VerificationOptions{}},
+		"option failure": {binaryName: binaryName, verOpts: &pb.VerificationOptions{
+			AllWithRepository: &pb.VerifyAllWithRepository{RepositoryUri: "git+https://github.com/project-oak/other"},
+		}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyProvenances(tt.binaryName, digests, tt.verOpts, provenances)
+			_, _, wantErr := GenerateEndorsementWithWarnings(tt.binaryName, digests, tt.verOpts, createClaimValidity(7), provenances)
+			if err == nil || wantErr == nil || err.Error() != wantErr.Error() {
+				t.Errorf("got %v, want the same error as GenerateEndorsement: %v", err, wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateEndorsementWithDetails(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, provenancePath})
+	verOpts := pb.VerificationOptions{
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 1},
+		AllWithRepository:      &pb.VerifyAllWithRepository{RepositoryUri: "git+https://github.com/project-oak/oak@refs/heads/main"},
+		AllBuiltWithinDays:     &pb.VerifyAllBuiltWithinDays{Days: 30},
+		Severities:             map[string]pb.Severity{"all_built_within_days": pb.Severity_WARN},
+	}
+	digests := map[string]string{"sha2-256": binaryDigest}
+
+	statement, result, err := GenerateEndorsementWithDetails(binaryName, digests, &verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "binary name", statement.Subject[0].Name, binaryName)
+	want := map[string][]int{
+		"provenance_count_at_least": {0, 1},
+		"all_with_repository":       {0, 1},
+		"all_built_within_days":     {},
+	}
+	if diff := cmp.Diff(result.SatisfiedBy, want); diff != "" {
+		t.Errorf("unexpected satisfied steps: %s", diff)
+	}
+	// The provenances were built long ago.
+	testutil.AssertEq(t, "number of warnings", len(result.Warnings), 2)
+
+	if _, _, err := GenerateEndorsementWithDetails("other_binary", digests, &verOpts, createClaimValidity(7), provenances); err == nil {
+		t.Errorf("expected failure for another binary name")
+	}
+}
+
+func TestGenerateEndorsementWithResult(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, provenancePath})
+	verOpts := pb.VerificationOptions{
+		AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 30},
+		Severities:         map[string]pb.Severity{"all_built_within_days": pb.Severity_WARN},
+	}
+	digests := map[string]string{"sha2-256": binaryDigest}
+
+	statement, result, err := GenerateEndorsementWithResult(binaryName, digests, &verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "generated on", result.GeneratedOn, *statement.Predicate.(claims.ClaimPredicate).IssuedOn)
+	testutil.AssertEq(t, "number of provenances", len(result.Provenances), 2)
+	for i, p := range provenances {
+		testutil.AssertEq(t, fmt.Sprintf("provenance #%d", i), result.Provenances[i], ProvenanceRecord{
+			URI:          p.SourceMetadata.URI,
+			SHA256Digest: p.SourceMetadata.SHA256Digest,
+			BuilderID:    p.SourceMetadata.BuilderID,
+		})
+	}
+	testutil.AssertEq(t, "number of warnings", len(result.Warnings), 2)
+	wantVerOpts, err := verifier.CanonicalVerificationOptions(&verOpts)
+	if err != nil {
+		t.Fatalf("Could not encode the verification options: %v", err)
+	}
+	testutil.AssertEq(t, "verification options", string(result.VerificationOptions), string(wantVerOpts))
+
+	// The result is serializable to JSON.
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Could not marshal the result: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(resultJSON, &decoded); err != nil {
+		t.Fatalf("Could not unmarshal the result: %v", err)
+	}
+	for _, key := range []string{"generatedOn", "provenances", "verificationOptions", "warnings"} {
+		if _, found := decoded[key]; !found {
+			t.Errorf("got %s, want a %q field", resultJSON, key)
+		}
+	}
+
+	if _, _, err := GenerateEndorsementWithResult("other_binary", digests, &verOpts, createClaimValidity(7), provenances); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestGenerateEndorsement_NameAndDigestMismatchDiagnostics(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, differentProvenancePath})
+	verOpts := pb.VerificationOptions{}
+	digests := map[string]string{"sha2-256": binaryDigest}
+	differentDigest := "e8e05d1d09af8952919bf6ab38e0cc5a6414ee2b5e21f4765b12421c5db0037e"
+
+	_, err := GenerateEndorsement("other_binary", digests, &verOpts, createClaimValidity(7), provenances[:1])
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	for _, want := range []string{"binary name", `"other_binary"`, provenances[0].SourceMetadata.URI, binaryName} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got %q, want error message containing %q", err, want)
+		}
+	}
+	testutil.AssertEq(t, "reason code", verifier.ReasonCodes(err)[0], verifier.BinaryNameMismatch)
+
+	_, err = GenerateEndorsement(binaryName, digests, &verOpts, createClaimValidity(7), provenances)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	for _, want := range []string{"binary digests", binaryDigest, differentDigest, provenances[1].SourceMetadata.URI} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got %q, want error message containing %q", err, want)
+		}
+	}
+	// Only the provenance with the mismatching digest is blamed.
+	if strings.Count(err.Error(), "file://") != 1 {
+		t.Errorf("got %q, want exactly one provenance URI in the error message", err)
+	}
+	testutil.AssertEq(t, "reason code", verifier.ReasonCodes(err)[0], verifier.DigestMismatch)
+}
+
+func TestGenerateEndorsement_ReportsAllFailures(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, differentProvenancePath})
+	verOpts := pb.VerificationOptions{
+		AllWithRepository: &pb.VerifyAllWithRepository{RepositoryUri: "git+https://github.com/project-oak/other"},
+	}
+	digests := map[string]string{"sha2-256": binaryDigest}
+
+	_, err := GenerateEndorsement("other_binary", digests, &verOpts, createClaimValidity(7), provenances)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	for _, want := range []string{"binary name", "binary digests", "repository mismatch"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got %q, want error message containing %q", err, want)
+		}
+	}
+	want := []verifier.ReasonCode{
+		verifier.BinaryNameMismatch, verifier.BinaryNameMismatch,
+		verifier.DigestMismatch,
+		verifier.RepositoryMismatch, verifier.RepositoryMismatch,
+	}
+	if diff := cmp.Diff(verifier.ReasonCodes(err), want); diff != "" {
+		t.Errorf("unexpected reason codes: %s", diff)
+	}
+}
+
 func TestLoadAndVerifyProvenances_TwoProvenancesSuccess(t *testing.T) {
 	provenances := createProvenanceList(t, []string{provenancePath, provenancePath})
 	verOpts := pb.VerificationOptions{}
@@ -148,6 +376,272 @@ func TestGenerateEndorsement_SingleValidProvenanceSuccess(t *testing.T) {
 	testutil.AssertEq(t, "notAfter date", predicate.Validity.NotAfter, validity.NotAfter)
 }
 
+func TestGenerateEndorsement_WithIssuer(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	digests := map[string]string{"sha2-256": binaryDigest}
+	issuer := claims.ClaimIssuer{Name: "Project Oak", URI: "https://github.com/project-oak"}
+	statement, err := GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances, claims.WithIssuer(issuer))
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+
+	predicate := statement.Predicate.(claims.ClaimPredicate)
+	testutil.AssertEq(t, "issuer", *predicate.Issuer, issuer)
+
+	bytes, err := json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("Failed to marshal endorsement: %v", err)
+	}
+	want := `"issuer":{"name":"Project Oak","uri":"https://github.com/project-oak"}`
+	if !strings.Contains(string(bytes), want) {
+		t.Fatalf("got %s, want statement containing %s", bytes, want)
+	}
+}
+
+func TestGenerateEndorsement_OversizedProvenanceFailure(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	verOpts := pb.VerificationOptions{
+		AllWithinDocumentSize: &pb.VerifyAllWithinDocumentSize{MaxBytes: 512},
+	}
+	digests := map[string]string{"sha2-256": binaryDigest}
+
+	_, err := GenerateEndorsement(binaryName, digests, &verOpts, createClaimValidity(7), provenances)
+	want := "too large"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %q, want error message containing %q,", err, want)
+	}
+}
+
+func TestGenerateEndorsementWithWarnings_WarnLevelFreshnessViolation(t *testing.T) {
+	// The test provenance does not have a build time, so it fails the
+	// freshness check.
+	provenances := createProvenanceList(t, []string{provenancePath})
+	verOpts := pb.VerificationOptions{
+		AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 30},
+		Severities:         map[string]pb.Severity{"all_built_within_days": pb.Severity_WARN},
+	}
+	digests := map[string]string{"sha2-256": binaryDigest}
+
+	statement, warnings, err := GenerateEndorsementWithWarnings(binaryName, digests, &verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "binary name", statement.Subject[0].Name, binaryName)
+	testutil.AssertEq(t, "number of warnings", len(warnings), 1)
+
+	// With the default severity, the same violation is fatal.
+	verOpts.Severities = nil
+	if _, err := GenerateEndorsement(binaryName, digests, &verOpts, createClaimValidity(7), provenances); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestGenerateEndorsementWithCompanions_RegisteredGenerator(t *testing.T) {
+	const linkPredicateType = "https://in-toto.io/Link/v1"
+	RegisterCompanionGenerator("link", func(endorsement *intoto.Statement, provenances []ParsedProvenance) (*intoto.Statement, error) {
+		return &intoto.Statement{
+			StatementHeader: intoto.StatementHeader{
+				Type:          intoto.StatementInTotoV01,
+				PredicateType: linkPredicateType,
+				Subject:       endorsement.Subject,
+			},
+			Predicate: map[string]int{"materials": len(provenances)},
+		}, nil
+	})
+	defer UnregisterCompanionGenerator("link")
+
+	provenances := createProvenanceList(t, []string{provenancePath})
+	digests := map[string]string{"sha2-256": binaryDigest}
+	statements, err := GenerateEndorsementWithCompanions(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+
+	testutil.AssertEq(t, "number of statements", len(statements), 2)
+	testutil.AssertEq(t, "endorsement predicate type", statements[0].PredicateType, claims.ClaimV1)
+	testutil.AssertEq(t, "companion predicate type", statements[1].PredicateType, linkPredicateType)
+	testutil.AssertEq(t, "companion subject", statements[1].Subject[0].Name, binaryName)
+}
+
+func TestGroupProvenancesBySubject_ThreeArtifacts(t *testing.T) {
+	// The first two provenances have the same binary name but different
+	// digests, so they describe different artifacts.
+	provenances := createProvenanceList(t, []string{provenancePath, differentProvenancePath, slsav1ProvenancePath, provenancePath})
+
+	groups := GroupProvenancesBySubject(provenances)
+
+	testutil.AssertEq(t, "number of groups", len(groups), 3)
+	id := binaryName + "@sha2-256:" + binaryDigest
+	testutil.AssertEq(t, "size of group "+id, len(groups[id]), 2)
+	for _, p := range provenances {
+		id := claims.SubjectIDOf(intoto.Subject{
+			Name:   p.Provenance.BinaryName(),
+			Digest: intoto.DigestSet{"sha256": p.Provenance.BinarySHA256Digest()},
+		})
+		if _, found := groups[id]; !found {
+			t.Errorf("no group found for %q", id)
+		}
+	}
+}
+
+func TestGroupProvenancesBySubject_OtherDigestAlgorithms(t *testing.T) {
+	withDigest := func(digest string) ParsedProvenance {
+		return ParsedProvenance{Provenance: *model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName,
+			model.WithBinaryDigests(map[string]string{"sha512": digest}))}
+	}
+	provenances := []ParsedProvenance{withDigest("aa"), withDigest("bb"), withDigest("aa")}
+
+	groups := GroupProvenancesBySubject(provenances)
+
+	testutil.AssertEq(t, "number of groups", len(groups), 2)
+	testutil.AssertEq(t, "size of group aa", len(groups[binaryName+"@sha2-512:aa"]), 2)
+	testutil.AssertEq(t, "size of group bb", len(groups[binaryName+"@sha2-512:bb"]), 1)
+}
+
+func TestGetProvenanceBytesWithRefresh_ExpiredThenRefreshed(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("signature") != "fresh" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "<Error><Code>AccessDenied</Code></Error>")
+			return
+		}
+		_, _ = w.Write(provenanceBytes)
+	}))
+	defer server.Close()
+	expiredURI := server.URL + "/provenance.json?signature=stale"
+	withTLS := WithHTTPClient(server.Client())
+
+	_, err = GetProvenanceBytes(expiredURI, withTLS)
+	if !errors.Is(err, ErrURLExpired) {
+		t.Fatalf("got %v, want error wrapping %v", err, ErrURLExpired)
+	}
+	if want := "<Code>AccessDenied</Code>"; !strings.Contains(err.Error(), want) {
+		t.Errorf("got %q, want error message containing %q", err, want)
+	}
+
+	refreshes := 0
+	refresh := func(uri string) (string, error) {
+		refreshes++
+		return strings.Replace(uri, "stale", "fresh", 1), nil
+	}
+	bytes, err := GetProvenanceBytesWithRefresh(context.Background(), expiredURI, refresh, withTLS)
+	if err != nil {
+		t.Fatalf("Could not get provenance bytes: %v", err)
+	}
+	testutil.AssertEq(t, "number of refreshes", refreshes, 1)
+	testutil.AssertEq(t, "provenance bytes", string(bytes), string(provenanceBytes))
+}
+
+func TestGenerateEndorsements_TwoSubjects(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, slsav1ProvenancePath})
+	subjects := []intoto.Subject{
+		{Name: binaryName, Digest: intoto.DigestSet{"sha2-256": binaryDigest}},
+		{Name: "oak_functions_enclave_app", Digest: intoto.DigestSet{"sha2-256": otherDigest}},
+	}
+	verOpts := pb.VerificationOptions{ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 2}}
+
+	statement, err := GenerateEndorsements(subjects, &verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	if diff := cmp.Diff(statement.Subject, subjects); diff != "" {
+		t.Errorf("unexpected subjects: %s", diff)
+	}
+	predicate := statement.Predicate.(claims.ClaimPredicate)
+	testutil.AssertEq(t, "evidence length", len(predicate.Evidence), 2)
+}
+
+func TestGenerateEndorsementsWithWarnings(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, slsav1ProvenancePath})
+	subjects := []intoto.Subject{
+		{Name: binaryName, Digest: intoto.DigestSet{"sha2-256": binaryDigest}},
+		{Name: "oak_functions_enclave_app", Digest: intoto.DigestSet{"sha2-256": otherDigest}},
+	}
+	verOpts := pb.VerificationOptions{
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 3},
+		Severities:             map[string]pb.Severity{"provenance_count_at_least": pb.Severity_WARN},
+	}
+
+	statement, warnings, err := GenerateEndorsementsWithWarnings(subjects, &verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "number of subjects", len(statement.Subject), 2)
+	testutil.AssertEq(t, "number of warnings", len(warnings), 1)
+}
+
+func TestGenerateEndorsements_Failures(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, slsav1ProvenancePath})
+	subject := intoto.Subject{Name: binaryName, Digest: intoto.DigestSet{"sha2-256": binaryDigest}}
+	otherSubject := intoto.Subject{Name: "oak_functions_enclave_app", Digest: intoto.DigestSet{"sha2-256": otherDigest}}
+	uncoveredSubject := intoto.Subject{Name: "other_binary", Digest: intoto.DigestSet{"sha2-256": otherDigest}}
+
+	tests := []struct {
+		name     string
+		subjects []intoto.Subject
+		want     string
+	}{
+		{name: "no subjects", want: "no subjects"},
+		{name: "unmatched provenance", subjects: []intoto.Subject{subject}, want: "does not match any of the subjects"},
+		{name: "uncovered subject", subjects: []intoto.Subject{subject, otherSubject, uncoveredSubject}, want: "other_binary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GenerateEndorsements(tt.subjects, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want error message containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateEndorsementsFromChecksums_TwoArtifacts(t *testing.T) {
+	provenances := createProvenanceList(t, []string{slsav1ProvenancePath, provenancePath, differentProvenancePath})
+	checksumsAbsPath, err := filepath.Abs(checksumsPath)
+	if err != nil {
+		t.Fatalf("Could not resolve path: %v", err)
+	}
+	verOpts := pb.VerificationOptions{}
+
+	endorsements, err := GenerateEndorsementsFromChecksums("file://"+checksumsAbsPath, nil, &verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsements: %v", err)
+	}
+	// The checksums file can only be read from the mirror.
+	const prefix = "https://releases.invalid/"
+	mirrored, err := GenerateEndorsementsFromChecksums(prefix+filepath.Base(checksumsAbsPath), []func(o *LoadOptions){WithLocalMirror(prefix, filepath.Dir(checksumsAbsPath))}, &verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsements with the mirrored checksums file: %v", err)
+	}
+	testutil.AssertEq(t, "number of mirrored endorsements", len(mirrored), 2)
+	testutil.AssertEq(t, "number of endorsements", len(endorsements), 2)
+	testutil.AssertEq(t, "first binary name", endorsements[0].Subject[0].Name, binaryName)
+	testutil.AssertEq(t, "first binary hash", endorsements[0].Subject[0].Digest["sha2-256"], binaryDigest)
+	testutil.AssertEq(t, "first evidence length", len(endorsements[0].Predicate.(claims.ClaimPredicate).Evidence), 1)
+	testutil.AssertEq(t, "second binary name", endorsements[1].Subject[0].Name, "oak_functions_enclave_app")
+	testutil.AssertEq(t, "second binary hash", endorsements[1].Subject[0].Digest["sha2-256"], otherDigest)
+	testutil.AssertEq(t, "second evidence length", len(endorsements[1].Predicate.(claims.ClaimPredicate).Evidence), 1)
+}
+
+func TestParseChecksums_Malformed(t *testing.T) {
+	tests := map[string]string{
+		"missing name":   binaryDigest + "\n",
+		"invalid digest": "abcd  " + binaryName + "\n",
+		"duplicate name": binaryDigest + "  " + binaryName + "\n" + otherDigest + "  " + binaryName + "\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseChecksums([]byte(content)); err == nil {
+				t.Errorf("expected failure")
+			}
+		})
+	}
+}
+
 func TestLoadProvenances_FailingSingleRemoteProvenanceEndorsement(t *testing.T) {
 	_, err := LoadProvenances([]string{"https://github.com/project-oak/transparent-release/blob/main/testdata/missing_provenance.json"})
 	want := "couldn't load the provenance"
@@ -156,23 +650,1112 @@ func TestLoadProvenances_FailingSingleRemoteProvenanceEndorsement(t *testing.T)
 	}
 }
 
-// copyToTemp creates a copy of the given file in `/tmp`.
-// This is used for creating URLs with `file` as the scheme.
-func copyToTemp(path string) (string, error) {
-	bytes, err := os.ReadFile(path)
+func TestLoadProvenancesPartial_OneMissing(t *testing.T) {
+	tempPath, err := copyToTemp(provenancePath)
 	if err != nil {
-		return "", err
+		t.Fatalf("Could not load provenance: %v", err)
+	}
+	otherPath, err := copyToTemp(slsav1ProvenancePath)
+	if err != nil {
+		t.Fatalf("Could not load provenance: %v", err)
 	}
+	missingURI := "file://" + filepath.Join(t.TempDir(), "missing.json")
 
-	tmpfile, err := os.CreateTemp("", "provenance.json")
+	provenances, errs := LoadProvenancesPartial([]string{"file://" + tempPath, missingURI, "file://" + otherPath})
+	testutil.AssertEq(t, "number of provenances", len(provenances), 2)
+	testutil.AssertEq(t, "first binary name", provenances[0].Provenance.BinaryName(), binaryName)
+	testutil.AssertEq(t, "second binary name", provenances[1].Provenance.BinaryName(), "oak_functions_enclave_app")
+	testutil.AssertEq(t, "number of errors", len(errs), 1)
+	if !strings.Contains(errs[0].Error(), missingURI) {
+		t.Errorf("got %q, want error message containing %q", errs[0], missingURI)
+	}
+
+	// LoadProvenances still fails as a whole.
+	if _, err := LoadProvenances([]string{"file://" + tempPath, missingURI}); err == nil {
+		t.Errorf("expected failure")
+	}
+}
+
+func TestLoadProvenancesConcurrent_BoundedAndOrdered(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
 	if err != nil {
-		return "", fmt.Errorf("couldn't create tempfile: %v", err)
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	otherBytes, err := os.ReadFile(slsav1ProvenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
 	}
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		switch r.URL.Path {
+		case "/provenance.json":
+			_, _ = w.Write(provenanceBytes)
+		case "/other.json":
+			_, _ = w.Write(otherBytes)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
 
-	if _, err := tmpfile.Write(bytes); err != nil {
-		tmpfile.Close()
-		return "", fmt.Errorf("couldn't write bytes to tempfile: %v", err)
+	var uris []string
+	for i := 0; i < 6; i++ {
+		uris = append(uris, server.URL+"/provenance.json", server.URL+"/other.json")
+	}
+	provenances, err := LoadProvenancesConcurrent(uris, 3)
+	if err != nil {
+		t.Fatalf("Could not load provenances: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), len(uris))
+	for i, provenance := range provenances {
+		testutil.AssertEq(t, "URI", provenance.SourceMetadata.URI, uris[i])
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > 3 {
+		t.Errorf("got %d concurrent requests, want at most 3", got)
 	}
 
-	return tmpfile.Name(), nil
+	missingURI := server.URL + "/missing.json"
+	if _, err := LoadProvenancesConcurrent([]string{uris[0], missingURI}, 0); err == nil || !strings.Contains(err.Error(), missingURI) {
+		t.Errorf("got %v, want error message containing %q", err, missingURI)
+	}
+}
+
+func TestLoadProvenanceFromReader(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	want := createProvenanceList(t, []string{provenancePath})[0]
+
+	got, err := LoadProvenanceFromReader(bytes.NewReader(provenanceBytes), "stdin")
+	if err != nil {
+		t.Fatalf("Could not load provenance from reader: %v", err)
+	}
+	testutil.AssertEq(t, "URI", got.SourceMetadata.URI, "stdin")
+	testutil.AssertEq(t, "SHA256 digest", got.SourceMetadata.SHA256Digest, want.SourceMetadata.SHA256Digest)
+	testutil.AssertEq(t, "binary name", got.Provenance.BinaryName(), binaryName)
+
+	if _, err := LoadProvenanceFromReader(strings.NewReader("not a provenance"), "stdin"); err == nil || !strings.Contains(err.Error(), "stdin") {
+		t.Errorf("got %v, want error message containing %q", err, "stdin")
+	}
+}
+
+func TestLoadProvenance_SigstoreTrustRoot(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	// An unsigned bundle without verification material.
+	bundleBytes, err := json.Marshal(map[string]interface{}{
+		"dsseEnvelope": dsse.Envelope{
+			PayloadType: intoto.PayloadType,
+			Payload:     base64.StdEncoding.EncodeToString(provenanceBytes),
+			Signatures:  []dsse.Signature{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Could not marshal the bundle: %v", err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bundle.json")
+	if err := os.WriteFile(bundlePath, bundleBytes, 0600); err != nil {
+		t.Fatalf("Could not write the bundle: %v", err)
+	}
+
+	if _, err := LoadProvenance("file://" + bundlePath); err != nil {
+		t.Fatalf("Could not load the bundle without a trust root: %v", err)
+	}
+	root := &model.SigstoreTrustRoot{FulcioRoots: []*x509.Certificate{{}}}
+	if _, err := LoadProvenance("file://"+bundlePath, WithSigstoreTrustRoot(root)); err == nil {
+		t.Errorf("expected failure loading an unverified bundle with a trust root")
+	}
+}
+
+func TestLoadProvenance_SignatureVerifiers(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err)
+	}
+	envelopeBytes, err := json.Marshal(dsse.Envelope{
+		PayloadType: intoto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(provenanceBytes),
+		Signatures: []dsse.Signature{{
+			Sig: base64.StdEncoding.EncodeToString(ed25519.Sign(private, dsse.PAE(intoto.PayloadType, provenanceBytes))),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Could not marshal the envelope: %v", err)
+	}
+	envelopePath := filepath.Join(t.TempDir(), "envelope.json")
+	if err := os.WriteFile(envelopePath, envelopeBytes, 0600); err != nil {
+		t.Fatalf("Could not write the envelope: %v", err)
+	}
+
+	provenance, err := LoadProvenance("file://"+envelopePath, WithSignatureVerifiers(model.NewPublicKeyVerifier(public)))
+	if err != nil {
+		t.Fatalf("Could not load the envelope: %v", err)
+	}
+	verOpts := &pb.VerificationOptions{DistinctSigners: &pb.VerifyDistinctSigners{Count: 1}}
+	digests := map[string]string{"sha2-256": binaryDigest}
+	if err := VerifyProvenances(binaryName, digests, verOpts, []ParsedProvenance{*provenance}); err != nil {
+		t.Errorf("Failed to verify provenances: %v", err)
+	}
+
+	otherPublic, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err)
+	}
+	if _, err := LoadProvenance("file://"+envelopePath, WithSignatureVerifiers(model.NewPublicKeyVerifier(otherPublic))); err == nil {
+		t.Errorf("expected failure loading an envelope signed with an unknown key")
+	}
+}
+
+func TestLoadProvenancePerSubject(t *testing.T) {
+	tempPath, err := copyToTemp(slsav1GenericProvenancePath)
+	if err != nil {
+		t.Fatalf("Could not copy provenance: %v", err)
+	}
+	uri := "file://" + tempPath
+	if _, err := LoadProvenance(uri); err == nil || !strings.Contains(err.Error(), "exactly one subject") {
+		t.Errorf("got %v, want error message containing %q", err, "exactly one subject")
+	}
+
+	provenances, err := LoadProvenancePerSubject(uri)
+	if err != nil {
+		t.Fatalf("Could not load provenances: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 2)
+	testutil.AssertEq(t, "source metadata", provenances[1].SourceMetadata, provenances[0].SourceMetadata)
+
+	subjects := []intoto.Subject{
+		{Name: "oak_restricted_kernel", Digest: intoto.DigestSet{"sha2-256": otherDigest}},
+		{Name: "oak_stage0.bin", Digest: intoto.DigestSet{"sha2-256": "322527c0260e25f0e9a2595bd0d71a52294fe2397a7af76165190fd98de8920d"}},
+	}
+	verOpts := &pb.VerificationOptions{
+		AllWithRepository: &pb.VerifyAllWithRepository{RepositoryUri: "git+https://github.com/project-oak/oak@refs/heads/main"},
+	}
+	statement, err := GenerateEndorsements(subjects, verOpts, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	if diff := cmp.Diff(statement.Subject, subjects); diff != "" {
+		t.Errorf("unexpected subjects: %s", diff)
+	}
+}
+
+func TestLoadProvenancesFromSBOM_CycloneDX(t *testing.T) {
+	tempPath, err := copyToTemp(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not load provenance: %v", err)
+	}
+	sbom := fmt.Sprintf(`{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"metadata": {"component": {"name": %q, "externalReferences": [{"type": "attestation", "url": "file://%s"}]}}
+	}`, binaryName, tempPath)
+	sbomPath := filepath.Join(t.TempDir(), "sbom.json")
+	if err := os.WriteFile(sbomPath, []byte(sbom), 0o600); err != nil {
+		t.Fatalf("Could not write SBOM: %v", err)
+	}
+
+	provenances, err := LoadProvenancesFromSBOM("file://" + sbomPath)
+	if err != nil {
+		t.Fatalf("Could not load provenances from SBOM: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 1)
+	testutil.AssertEq(t, "binary name", provenances[0].Provenance.BinaryName(), binaryName)
+}
+
+func TestLoadProvenancesFromSBOM_RelativeReference(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "provenances"), 0o700); err != nil {
+		t.Fatalf("Could not create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "provenances", "provenance.json"), provenanceBytes, 0o600); err != nil {
+		t.Fatalf("Could not write provenance: %v", err)
+	}
+	sbom := fmt.Sprintf(`{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"metadata": {"component": {"name": %q, "externalReferences": [{"type": "attestation", "url": "../provenances/provenance.json"}]}}
+	}`, binaryName)
+	sbomPath := filepath.Join(dir, "sboms", "sbom.json")
+	if err := os.Mkdir(filepath.Dir(sbomPath), 0o700); err != nil {
+		t.Fatalf("Could not create directory: %v", err)
+	}
+	if err := os.WriteFile(sbomPath, []byte(sbom), 0o600); err != nil {
+		t.Fatalf("Could not write SBOM: %v", err)
+	}
+
+	provenances, err := LoadProvenancesFromSBOM("file://" + sbomPath)
+	if err != nil {
+		t.Fatalf("Could not load provenances from SBOM: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 1)
+	testutil.AssertEq(t, "source URI", provenances[0].SourceMetadata.URI, "file://"+filepath.Join(dir, "provenances", "provenance.json"))
+
+	// The options apply to both the SBOM and the provenances, which can only
+	// be read from the mirror.
+	const prefix = "https://releases.invalid/"
+	provenances, err = LoadProvenancesFromSBOM(prefix+"sboms/sbom.json", WithLocalMirror(prefix, dir))
+	if err != nil {
+		t.Fatalf("Could not load provenances from the mirrored SBOM: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 1)
+	testutil.AssertEq(t, "source URI", provenances[0].SourceMetadata.URI, prefix+"provenances/provenance.json")
+}
+
+func TestVerifyConsistentWithSBOM(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath, slsav1ProvenancePath})
+	tests := []struct {
+		name       string
+		provenance ParsedProvenance
+		sbomPath   string
+		wantErr    bool
+	}{
+		{name: "consistent SPDX", provenance: provenances[0], sbomPath: "../../testdata/sbom_spdx.json"},
+		{name: "consistent CycloneDX", provenance: provenances[0], sbomPath: "../../testdata/sbom_cyclonedx.json"},
+		{name: "inconsistent SPDX", provenance: provenances[1], sbomPath: "../../testdata/sbom_spdx.json", wantErr: true},
+		{name: "inconsistent CycloneDX", provenance: provenances[1], sbomPath: "../../testdata/sbom_cyclonedx.json", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbomPath, err := filepath.Abs(tt.sbomPath)
+			if err != nil {
+				t.Fatalf("Could not resolve path: %v", err)
+			}
+			err = VerifyConsistentWithSBOM(&tt.provenance, "file://"+sbomPath)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr && !strings.Contains(err.Error(), otherDigest) {
+				t.Errorf("got %q, want error message containing %q", err, otherDigest)
+			}
+		})
+	}
+}
+
+func TestVerifyConsistentWithSBOM_Dependencies(t *testing.T) {
+	sbom := fmt.Sprintf(`{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"metadata": {"component": {"name": %q, "hashes": [{"alg": "SHA-256", "content": %q}]}},
+		"components": [
+			{"name": "builder", "hashes": [{"alg": "SHA-256", "content": "51532c757d1008bbff696d053a1d05226f6387cf232aa80b6f9c13b0759ccea0"}]},
+			{"name": "serde", "hashes": [{"alg": "SHA-256", "content": "aaaa"}]}
+		]
+	}`, binaryName, binaryDigest)
+	sbomPath := filepath.Join(t.TempDir(), "sbom.json")
+	if err := os.WriteFile(sbomPath, []byte(sbom), 0o600); err != nil {
+		t.Fatalf("Could not write SBOM: %v", err)
+	}
+
+	withDependencies := func(dependencies ...model.Dependency) *ParsedProvenance {
+		return &ParsedProvenance{Provenance: *model.NewProvenanceIR(binaryDigest, "", binaryName,
+			model.WithBuilderImageSHA256Digest("51532c757d1008bbff696d053a1d05226f6387cf232aa80b6f9c13b0759ccea0"),
+			model.WithResolvedDependencies(dependencies))}
+	}
+	source := model.Dependency{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": "6bac02b6b0442ed944f57b7cba9a5f1119863ca4"}}
+	serde := model.Dependency{URI: "pkg:cargo/serde@1.0.0", Digest: map[string]string{"sha256": "aaaa"}}
+	unlisted := model.Dependency{URI: "pkg:cargo/unlisted@1.0.0", Digest: map[string]string{"sha256": "bbbb"}}
+
+	if err := VerifyConsistentWithSBOM(withDependencies(source, serde), "file://"+sbomPath); err != nil {
+		t.Errorf("Failed to verify consistency: %v", err)
+	}
+	err := VerifyConsistentWithSBOM(withDependencies(source, serde, unlisted), "file://"+sbomPath)
+	if err == nil || !strings.Contains(err.Error(), unlisted.URI) {
+		t.Errorf("got %v, want error message containing %q", err, unlisted.URI)
+	}
+
+	// The SBOM can only be read from the mirror.
+	const prefix = "https://sboms.invalid/"
+	if err := VerifyConsistentWithSBOM(withDependencies(source, serde), prefix+filepath.Base(sbomPath), WithLocalMirror(prefix, filepath.Dir(sbomPath))); err != nil {
+		t.Errorf("Failed to verify consistency with the mirrored SBOM: %v", err)
+	}
+}
+
+func TestResolveProvenanceURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		manifestURI string
+		reference   string
+		want        string
+	}{
+		{
+			name:        "file base",
+			manifestURI: "file:///releases/v1/manifest.json",
+			reference:   "provenance.json",
+			want:        "file:///releases/v1/provenance.json",
+		},
+		{
+			name:        "file base with parent directory",
+			manifestURI: "file:///releases/v1/manifest.json",
+			reference:   "../provenances/provenance.json",
+			want:        "file:///releases/provenances/provenance.json",
+		},
+		{
+			name:        "http base",
+			manifestURI: "https://example.com/releases/v1/manifest.json?token=abc",
+			reference:   "provenances/provenance.json",
+			want:        "https://example.com/releases/v1/provenances/provenance.json",
+		},
+		{
+			name:        "http base with absolute path",
+			manifestURI: "https://example.com/releases/v1/manifest.json",
+			reference:   "/provenance.json",
+			want:        "https://example.com/provenance.json",
+		},
+		{
+			name:        "absolute reference",
+			manifestURI: "file:///releases/v1/manifest.json",
+			reference:   "https://example.com/provenance.json",
+			want:        "https://example.com/provenance.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveProvenanceURI(tt.manifestURI, tt.reference)
+			if err != nil {
+				t.Fatalf("Could not resolve reference: %v", err)
+			}
+			testutil.AssertEq(t, "resolved URI", got, tt.want)
+		})
+	}
+
+	if _, err := ResolveProvenanceURI("releases/manifest.json", "provenance.json"); err == nil {
+		t.Errorf("expected an error for a relative manifest URI")
+	}
+}
+
+func TestGetJSONOverHTTP_CanceledMidStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	defer close(done)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		fmt.Fprint(w, `{"_type": "https://in-toto.io/Statement/v0.1",`)
+		w.(http.Flusher).Flush()
+		// Cancel once the client has received the first part of the body.
+		cancel()
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+
+	got, err := getJSONOverHTTP(ctx, server.URL, LoadOptions{})
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want an error wrapping %v", err, context.Canceled)
+	}
+	testutil.AssertEq(t, "number of bytes", len(got), 0)
+}
+
+func TestLoadProvenanceWithContext_DeadlineExceeded(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never respond.
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := LoadProvenanceWithContext(ctx, server.URL+"/provenance.json")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want an error wrapping %v", err, context.DeadlineExceeded)
+	}
+
+	_, err = LoadProvenancesWithContext(ctx, []string{server.URL + "/provenance.json"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want an error wrapping %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestGetProvenanceBytes_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "<html>Not Found</html>"+strings.Repeat(" ", 1000)+"end of page")
+	}))
+	defer server.Close()
+
+	_, err := GetProvenanceBytes(server.URL + "/provenance.json")
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	want := "unexpected HTTP status 404 fetching " + server.URL + "/provenance.json"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("got %q, want error message containing %q", err, want)
+	}
+	if !strings.Contains(err.Error(), "<html>Not Found</html>") || strings.Contains(err.Error(), "end of page") {
+		t.Errorf("got %q, want error message containing only the beginning of the body", err)
+	}
+}
+
+func TestGetProvenanceBytes_Retries(t *testing.T) {
+	withRetries := WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, Jitter: 0.5})
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/flaky.json":
+			if requests[r.URL.Path] < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case "/reset.json":
+			if requests[r.URL.Path] < 2 {
+				// Close the connection without a response.
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case "/down.json":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		path     string
+		wantErr  bool
+		attempts int
+	}{
+		{path: "/flaky.json", attempts: 3},
+		{path: "/reset.json", attempts: 2},
+		{path: "/down.json", wantErr: true, attempts: 3},
+		{path: "/missing.json", wantErr: true, attempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := GetProvenanceBytes(server.URL+tt.path, withRetries)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			testutil.AssertEq(t, "attempts", requests[tt.path], tt.attempts)
+		})
+	}
+}
+
+func TestGetProvenanceBytes_RetryCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := GetProvenanceBytesWithContext(ctx, server.URL+"/provenance.json", WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour}))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want an error wrapping %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		{name: "first retry", policy: RetryPolicy{BaseDelay: time.Second}, retry: 1, want: time.Second},
+		{name: "doubled", policy: RetryPolicy{BaseDelay: time.Second}, retry: 3, want: 4 * time.Second},
+		{name: "capped", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, retry: 4, want: 5 * time.Second},
+		{name: "default cap", policy: DefaultRetryPolicy(), retry: 100, want: defaultMaxRetryDelay},
+		{name: "no overflow", policy: RetryPolicy{BaseDelay: 500 * time.Millisecond, MaxDelay: math.MaxInt64}, retry: 100, want: math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.AssertEq(t, "delay", tt.policy.delay(tt.retry), tt.want)
+		})
+	}
+}
+
+func TestGetProvenanceBytes_MaxProvenanceSize(t *testing.T) {
+	defer func(size int64) { MaxProvenanceSize = size }(MaxProvenanceSize)
+	MaxProvenanceSize = 16
+
+	path := filepath.Join(t.TempDir(), "provenance.json")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 17)), 0o600); err != nil {
+		t.Fatalf("Could not write provenance: %v", err)
+	}
+	// Omit the Content-Length header, so that the limit is enforced while
+	// reading.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, strings.Repeat("x", 17))
+	}))
+	defer server.Close()
+
+	want := "provenance exceeds maximum size of 16 bytes"
+	for _, uri := range []string{"file://" + path, server.URL} {
+		got, err := GetProvenanceBytes(uri)
+		if !errors.Is(err, ErrProvenanceTooLarge) || !strings.Contains(err.Error(), want) {
+			t.Errorf("got %v, want error message containing %q for %s", err, want, uri)
+		}
+		testutil.AssertEq(t, "number of bytes", len(got), 0)
+	}
+
+	// Files within the limit can be read.
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 16)), 0o600); err != nil {
+		t.Fatalf("Could not write provenance: %v", err)
+	}
+	if _, err := GetProvenanceBytes("file://" + path); err != nil {
+		t.Errorf("Could not read provenance: %v", err)
+	}
+}
+
+// copyToTemp creates a copy of the given file in `/tmp`.
+// This is used for creating URLs with `file` as the scheme.
+func copyToTemp(path string) (string, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	tmpfile, err := os.CreateTemp("", "provenance.json")
+	if err != nil {
+		return "", fmt.Errorf("couldn't create tempfile: %v", err)
+	}
+
+	if _, err := tmpfile.Write(bytes); err != nil {
+		tmpfile.Close()
+		return "", fmt.Errorf("couldn't write bytes to tempfile: %v", err)
+	}
+
+	return tmpfile.Name(), nil
+}
+
+func TestProbeProvenance_HTTP(t *testing.T) {
+	lastModified := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("got method %s, want %s", r.Method, http.MethodHead)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "1234")
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	meta, err := ProbeProvenance(context.Background(), server.URL+"/provenance.json", WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("Failed to probe provenance: %v", err)
+	}
+	testutil.AssertEq(t, "size", meta.Size, int64(1234))
+	testutil.AssertEq(t, "content type", meta.ContentType, "application/json")
+	testutil.AssertEq(t, "modified time", meta.ModTime.Equal(lastModified), true)
+}
+
+func TestProbeProvenance_File(t *testing.T) {
+	path, err := filepath.Abs(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not get absolute path: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Could not stat %q: %v", path, err)
+	}
+
+	meta, err := ProbeProvenance(context.Background(), "file://"+path)
+	if err != nil {
+		t.Fatalf("Failed to probe provenance: %v", err)
+	}
+	testutil.AssertEq(t, "size", meta.Size, info.Size())
+	testutil.AssertEq(t, "content type", meta.ContentType, "application/json")
+	testutil.AssertEq(t, "modified time", meta.ModTime.Equal(info.ModTime()), true)
+}
+
+func TestProbeProvenances(t *testing.T) {
+	var inFlight, maxInFlight int32
+	reachable := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if r.URL.Path != "/provenance.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", "1234")
+	}))
+	defer reachable.Close()
+	// The URL of a closed server is unreachable.
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	var uris []string
+	var wantAvailable []bool
+	for i := 0; i < 3; i++ {
+		uris = append(uris, reachable.URL+"/provenance.json", reachable.URL+"/missing.json", unreachable.URL+"/provenance.json")
+		wantAvailable = append(wantAvailable, true, false, false)
+	}
+	results := ProbeProvenances(context.Background(), uris, 2, WithHTTPClient(reachable.Client()))
+	testutil.AssertEq(t, "number of results", len(results), len(uris))
+	for i, result := range results {
+		testutil.AssertEq(t, "URI", result.URI, uris[i])
+		testutil.AssertEq(t, fmt.Sprintf("availability of %s", result.URI), result.Available(), wantAvailable[i])
+		if result.Available() {
+			testutil.AssertEq(t, "size", result.Meta.Size, int64(1234))
+		}
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("got %d concurrent requests, want at most 2", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, result := range ProbeProvenances(ctx, uris[:1], 0) {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("got %v, want %v", result.Err, context.Canceled)
+		}
+	}
+}
+
+func TestGetProvenanceBytes_LocalMirror(t *testing.T) {
+	dir := t.TempDir()
+	want, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "provenances"), 0o700); err != nil {
+		t.Fatalf("Could not create the mirror: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "provenances", "oak.json"), want, 0o600); err != nil {
+		t.Fatalf("Could not populate the mirror: %v", err)
+	}
+
+	// The URI cannot be resolved, so it can only be read from the mirror.
+	const prefix = "https://provenances.invalid/"
+	got, err := GetProvenanceBytes(prefix+"provenances/oak.json?token=abc", WithLocalMirror(prefix, dir))
+	if err != nil {
+		t.Fatalf("Failed to get the provenance: %v", err)
+	}
+	testutil.AssertEq(t, "provenance bytes", string(got), string(want))
+
+	// Paths outside the mirror are not read from it.
+	if _, found := localMirrorPath(prefix+"../outside.json", map[string]string{prefix: dir}); found {
+		t.Fatalf("expected the path to be rejected")
+	}
+}
+
+func TestVerifySubjectDigestsAttested(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	digests := map[string]string{"sha2-256": binaryDigest}
+	statement, err := GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+
+	if err := VerifySubjectDigestsAttested(statement, provenances); err != nil {
+		t.Fatalf("Failed to verify subject digests: %v", err)
+	}
+}
+
+func TestVerifySubjectDigestsAttested_UnattestedAlgorithm(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	digests := map[string]string{
+		"sha2-256": binaryDigest,
+		"sha2-512": "4f8e1bcd9a8e5a0aa1f1b6b1e0be8c9b2a1c1d16cb2f6e98a5a2c0c1f4b0e0d8",
+	}
+	statement, err := GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+
+	err = VerifySubjectDigestsAttested(statement, provenances)
+	if err == nil || !strings.Contains(err.Error(), "sha2-512") {
+		t.Fatalf("got %v, want error about the unattested sha2-512 digest", err)
+	}
+}
+
+func TestGenerateEndorsement_MaxValidity(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	digests := map[string]string{"sha2-256": binaryDigest}
+	verOpts := pb.VerificationOptions{
+		MaxValidity: &pb.VerifyMaxValidity{Days: 30},
+	}
+	notBefore := time.Now()
+
+	tests := []struct {
+		name    string
+		days    int
+		wantErr bool
+	}{
+		{name: "below the cap", days: 29, wantErr: false},
+		{name: "at the cap", days: 30, wantErr: false},
+		{name: "above the cap", days: 31, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notAfter := notBefore.AddDate(0, 0, tt.days)
+			validity := claims.ClaimValidity{NotBefore: &notBefore, NotAfter: &notAfter}
+			_, err := GenerateEndorsement(binaryName, digests, &verOpts, validity, provenances)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+		})
+	}
+}
+
+func TestVerifySubjectsCovered_OneOfThreeUncovered(t *testing.T) {
+	provenances := []ParsedProvenance{
+		{Provenance: *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, "first")},
+		{Provenance: *model.NewProvenanceIR(otherDigest, slsav02.GenericSLSABuildType, "second",
+			model.WithBinaryDigests(map[string]string{"sha256": otherDigest}))},
+	}
+	subjects := []intoto.Subject{
+		{Name: "first", Digest: intoto.DigestSet{"sha2-256": binaryDigest}},
+		{Name: "second", Digest: intoto.DigestSet{"sha2-256": otherDigest}},
+		// The digest is covered, but only by a provenance for another subject.
+		{Name: "third", Digest: intoto.DigestSet{"sha2-256": binaryDigest}},
+	}
+
+	err := VerifySubjectsCovered(subjects, provenances)
+	var uncovered *UncoveredSubjectsError
+	if !errors.As(err, &uncovered) {
+		t.Fatalf("got %v, want an UncoveredSubjectsError", err)
+	}
+	testutil.AssertEq(t, "number of uncovered subjects", len(uncovered.Subjects), 1)
+	testutil.AssertEq(t, "uncovered subject", uncovered.Subjects[0].Name, "third")
+
+	if err := VerifySubjectsCovered(subjects[:2], provenances); err != nil {
+		t.Fatalf("got %v, want no error", err)
+	}
+}
+
+func TestLoadProvenances_JSONLLineNumbers(t *testing.T) {
+	var lines []string
+	for _, path := range []string{provenancePath, slsav1ProvenancePath} {
+		provenanceBytes, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read the provenance: %v", err)
+		}
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, provenanceBytes); err != nil {
+			t.Fatalf("Could not compact the provenance: %v", err)
+		}
+		lines = append(lines, compacted.String())
+	}
+	// The blank line is skipped, but still counted.
+	path := filepath.Join(t.TempDir(), "provenances.jsonl")
+	if err := os.WriteFile(path, []byte(lines[0]+"\n\n"+lines[1]+"\n"), 0o600); err != nil {
+		t.Fatalf("Could not write the JSONL file: %v", err)
+	}
+
+	provenances, err := LoadProvenances([]string{"file://" + path})
+	if err != nil {
+		t.Fatalf("Failed to load the provenances: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 2)
+	testutil.AssertEq(t, "first line", provenances[0].SourceMetadata.Line, 1)
+	testutil.AssertEq(t, "second line", provenances[1].SourceMetadata.Line, 3)
+	testutil.AssertEq(t, "first binary name", provenances[0].Provenance.BinaryName(), binaryName)
+	testutil.AssertEq(t, "second binary name", provenances[1].Provenance.BinaryName(), "oak_functions_enclave_app")
+	sum256 := sha256.Sum256([]byte(lines[1]))
+	testutil.AssertEq(t, "second digest", provenances[1].SourceMetadata.SHA256Digest, hex.EncodeToString(sum256[:]))
+
+	// Failures are traced back to their line.
+	if err := os.WriteFile(path, []byte(lines[0]+"\n{}\n"), 0o600); err != nil {
+		t.Fatalf("Could not write the JSONL file: %v", err)
+	}
+	_, err = LoadProvenances([]string{"file://" + path})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("got %v, want an error referring to line 2", err)
+	}
+}
+
+func TestLoadProvenanceBundle(t *testing.T) {
+	var lines []string
+	for _, path := range []string{provenancePath, slsav1ProvenancePath} {
+		provenanceBytes, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read the provenance: %v", err)
+		}
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, provenanceBytes); err != nil {
+			t.Fatalf("Could not compact the provenance: %v", err)
+		}
+		lines = append(lines, compacted.String())
+	}
+	// JSONL is detected regardless of the extension.
+	path := filepath.Join(t.TempDir(), "evidence.json")
+	if err := os.WriteFile(path, []byte(lines[0]+"\n"+lines[1]+"\n"), 0o600); err != nil {
+		t.Fatalf("Could not write the bundle: %v", err)
+	}
+
+	provenances, err := LoadProvenanceBundle("file://" + path)
+	if err != nil {
+		t.Fatalf("Failed to load the bundle: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 2)
+	for i, line := range lines {
+		sum256 := sha256.Sum256([]byte(line))
+		testutil.AssertEq(t, fmt.Sprintf("digest of #%d", i), provenances[i].SourceMetadata.SHA256Digest, hex.EncodeToString(sum256[:]))
+		testutil.AssertEq(t, fmt.Sprintf("line of #%d", i), provenances[i].SourceMetadata.Line, i+1)
+	}
+	testutil.AssertEq(t, "first binary name", provenances[0].Provenance.BinaryName(), binaryName)
+	testutil.AssertEq(t, "second binary name", provenances[1].Provenance.BinaryName(), "oak_functions_enclave_app")
+
+	// A single, pretty-printed provenance is one record.
+	singlePath, err := copyToTemp(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not copy the provenance: %v", err)
+	}
+	provenances, err = LoadProvenanceBundle("file://" + singlePath)
+	if err != nil {
+		t.Fatalf("Failed to load the single provenance: %v", err)
+	}
+	want, err := LoadProvenance("file://" + singlePath)
+	if err != nil {
+		t.Fatalf("Failed to load the provenance: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 1)
+	testutil.AssertEq(t, "source metadata", provenances[0].SourceMetadata, want.SourceMetadata)
+
+	// Failures are traced back to their line.
+	if err := os.WriteFile(path, []byte(lines[0]+"\n{\n"), 0o600); err != nil {
+		t.Fatalf("Could not write the bundle: %v", err)
+	}
+	_, err = LoadProvenanceBundle("file://" + path)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("got %v, want an error referring to line 2", err)
+	}
+}
+
+func TestGenerateEndorsement_SHA512OnlyProvenance(t *testing.T) {
+	statementBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("Could not unmarshal provenance: %v", err)
+	}
+	sum512 := sha512.Sum512([]byte("binary"))
+	sha512Digest := hex.EncodeToString(sum512[:])
+	statement["subject"] = []interface{}{map[string]interface{}{
+		"name":   binaryName,
+		"digest": map[string]interface{}{"sha512": sha512Digest},
+	}}
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("Could not marshal provenance: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "provenance.json")
+	if err := os.WriteFile(path, statementBytes, 0o600); err != nil {
+		t.Fatalf("Could not write provenance: %v", err)
+	}
+	provenances, err := LoadProvenances([]string{"file://" + path})
+	if err != nil {
+		t.Fatalf("Could not load provenances: %v", err)
+	}
+
+	digests := map[string]string{"sha2-512": sha512Digest}
+	endorsement, err := GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "binary hash", endorsement.Subject[0].Digest["sha2-512"], sha512Digest)
+
+	// A mismatched digest with the same algorithm fails.
+	sum512 = sha512.Sum512([]byte("other binary"))
+	digests = map[string]string{"sha2-512": hex.EncodeToString(sum512[:])}
+	if _, err := GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances); err == nil {
+		t.Fatalf("expected failure")
+	}
+
+	// None of the provenances has a digest with any of the offered algorithms.
+	digests = map[string]string{"sha2-256": binaryDigest}
+	_, err = GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+	want := "none of the provenances has a binary digest with any of the algorithms [sha2-256]"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %v, want error message containing %q", err, want)
+	}
+}
+
+func TestGenerateEndorsement_WithVerificationOptions(t *testing.T) {
+	provenances := createProvenanceList(t, []string{provenancePath})
+	verOpts := &pb.VerificationOptions{
+		AllWithBinaryName:      &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 1},
+		Severities:             map[string]pb.Severity{"provenance_count_at_least": pb.Severity_WARN},
+	}
+	option, err := WithVerificationOptions(verOpts)
+	if err != nil {
+		t.Fatalf("Could not create option: %v", err)
+	}
+	digests := map[string]string{"sha2-256": binaryDigest}
+	endorsement, err := GenerateEndorsement(binaryName, digests, verOpts, createClaimValidity(7), provenances, option)
+	if err != nil {
+		t.Fatalf("Failed to generate endorsement: %v", err)
+	}
+
+	endorsementBytes, err := json.Marshal(endorsement)
+	if err != nil {
+		t.Fatalf("Could not marshal endorsement: %v", err)
+	}
+	parsed, err := claims.ParseEndorsementV2Bytes(endorsementBytes)
+	if err != nil {
+		t.Fatalf("Could not parse endorsement: %v", err)
+	}
+	predicate := parsed.Predicate.(claims.ClaimPredicate)
+	var got pb.VerificationOptions
+	if err := protojson.Unmarshal(predicate.VerificationOptions, &got); err != nil {
+		t.Fatalf("Could not unmarshal the embedded verification options: %v", err)
+	}
+	if !proto.Equal(&got, verOpts) {
+		t.Errorf("got verification options %v, want %v", &got, verOpts)
+	}
+}
+
+// ed25519SignerVerifier is a minimal dsse.Signer and dsse.Verifier used in
+// tests, standing in for a KMS or a local key.
+type ed25519SignerVerifier struct {
+	private ed25519.PrivateKey
+	err     error
+}
+
+func (s *ed25519SignerVerifier) Sign(_ context.Context, data []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return ed25519.Sign(s.private, data), nil
+}
+
+func (s *ed25519SignerVerifier) Verify(_ context.Context, data, sig []byte) error {
+	if !ed25519.Verify(s.private.Public().(ed25519.PublicKey), data, sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (s *ed25519SignerVerifier) KeyID() (string, error) {
+	return "test-key", nil
+}
+
+func (s *ed25519SignerVerifier) Public() crypto.PublicKey {
+	return s.private.Public()
+}
+
+func TestSignEndorsement(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err)
+	}
+	signer := &ed25519SignerVerifier{private: private}
+	provenances := createProvenanceList(t, []string{provenancePath})
+	digests := map[string]string{"sha2-256": binaryDigest}
+	statement, err := GenerateEndorsement(binaryName, digests, &pb.VerificationOptions{}, createClaimValidity(7), provenances)
+	if err != nil {
+		t.Fatalf("Could not generate endorsement: %v", err)
+	}
+
+	envelope, err := SignEndorsement(statement, signer)
+	if err != nil {
+		t.Fatalf("Could not sign endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "payload type", envelope.PayloadType, intoto.PayloadType)
+	testutil.AssertEq(t, "key ID", envelope.Signatures[0].KeyID, "test-key")
+	if _, err := model.VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{signer}, model.DefaultSignatureVerificationLimits); err != nil {
+		t.Errorf("Could not verify the signature: %v", err)
+	}
+
+	payload, err := envelope.DecodeB64Payload()
+	if err != nil {
+		t.Fatalf("Could not decode the payload: %v", err)
+	}
+	endorsement, err := claims.ParseEndorsementV2Bytes(payload)
+	if err != nil {
+		t.Fatalf("Could not parse the signed endorsement: %v", err)
+	}
+	testutil.AssertEq(t, "subject ID", claims.SubjectID(*endorsement), claims.SubjectID(*statement))
+
+	signErr := errors.New("KMS unavailable")
+	if _, err := SignEndorsement(statement, &ed25519SignerVerifier{private: private, err: signErr}); !errors.Is(err, signErr) {
+		t.Errorf("got %v, want an error wrapping %v", err, signErr)
+	}
+}
+
+func TestLoadProvenance_SLSAv1Normalization(t *testing.T) {
+	tempPath, err := copyToTemp(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not copy the provenance: %v", err)
+	}
+	uri := "file://" + tempPath
+
+	provenance, err := LoadProvenance(uri)
+	if err != nil {
+		t.Fatalf("Failed to load the provenance: %v", err)
+	}
+	if provenance.NormalizedStatement != nil {
+		t.Errorf("Unexpected normalized statement without WithSLSAv1Normalization")
+	}
+
+	provenance, err = LoadProvenance(uri, WithSLSAv1Normalization())
+	if err != nil {
+		t.Fatalf("Failed to load the provenance: %v", err)
+	}
+	statement := provenance.NormalizedStatement
+	if statement == nil {
+		t.Fatalf("Missing normalized statement")
+	}
+	testutil.AssertEq(t, "predicate type", statement.PredicateType, slsav1.PredicateSLSAProvenance)
+	wantSubject := []intoto.Subject{{Name: binaryName, Digest: intoto.DigestSet{"sha256": binaryDigest}}}
+	if diff := cmp.Diff(statement.Subject, wantSubject); diff != "" {
+		t.Errorf("Unexpected subject: %s", diff)
+	}
+
+	// The normalized statement is a valid SLSA v1 provenance, describing the
+	// same build as the original one.
+	statementBytes, err := json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("Could not marshal the normalized statement: %v", err)
+	}
+	validated, err := model.ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("Could not parse the normalized statement: %v", err)
+	}
+	normalized, err := model.FromValidatedProvenance(validated)
+	if err != nil {
+		t.Fatalf("Could not map the normalized statement: %v", err)
+	}
+	original := provenance.Provenance
+	testutil.AssertEq(t, "binary digest", normalized.BinarySHA256Digest(), original.BinarySHA256Digest())
+	testutil.AssertEq(t, "repo URI", normalized.RepoURI(), original.RepoURI())
+	testutil.AssertEq(t, "commit digest", normalized.CommitSHA1Digest(), original.CommitSHA1Digest())
+	gotBuilder, err := normalized.TrustedBuilder()
+	if err != nil {
+		t.Fatalf("No builder in the normalized statement: %v", err)
+	}
+	wantBuilder, _ := original.TrustedBuilder()
+	testutil.AssertEq(t, "builder", gotBuilder, wantBuilder)
 }
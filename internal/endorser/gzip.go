@@ -0,0 +1,56 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+)
+
+// gzipMagic are the first bytes of gzip-compressed data, see RFC 1952.
+//
+//nolint:gochecknoglobals
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// isGzip reports whether the given content starts like gzip-compressed data.
+// JSON documents never do.
+func isGzip(content []byte) bool {
+	return bytes.HasPrefix(content, gzipMagic)
+}
+
+// isGzipEncoding reports whether the given Content-Encoding header of an HTTP
+// response indicates gzip-compressed content.
+func isGzipEncoding(contentEncoding string) bool {
+	encoding := strings.TrimSpace(contentEncoding)
+	return strings.EqualFold(encoding, "gzip") || strings.EqualFold(encoding, "x-gzip")
+}
+
+// gunzipLimited decompresses the given gzip-compressed content. As with
+// readAllLimited, decompressing fails if the decompressed content exceeds
+// MaxProvenanceSize, which protects against decompression bombs.
+func gunzipLimited(content []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress gzip content: %v", err)
+	}
+	defer reader.Close()
+	decompressed, err := readAllLimited(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress gzip content: %w", err)
+	}
+	return decompressed, nil
+}
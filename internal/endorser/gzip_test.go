@@ -0,0 +1,134 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(content); err != nil {
+		t.Fatalf("Could not compress: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Could not compress: %v", err)
+	}
+	return compressed.Bytes()
+}
+
+func TestGetProvenanceBytes_GzipOverHTTP(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	compressed := gzipBytes(t, provenanceBytes)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/encoded.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed)
+	})
+	mux.HandleFunc("/provenance.json.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write(compressed)
+	})
+	mux.HandleFunc("/provenance.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(provenanceBytes)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, path := range []string{"/encoded.json", "/provenance.json.gz", "/provenance.json"} {
+		t.Run(path, func(t *testing.T) {
+			got, err := GetProvenanceBytes(server.URL + path)
+			if err != nil {
+				t.Fatalf("Failed to get the provenance: %v", err)
+			}
+			if !bytes.Equal(got, provenanceBytes) {
+				t.Errorf("got %d bytes, want the %d bytes of the decompressed provenance", len(got), len(provenanceBytes))
+			}
+		})
+	}
+}
+
+func TestLoadProvenance_GzipFile(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "provenance.json.gz")
+	if err := os.WriteFile(path, gzipBytes(t, provenanceBytes), 0o600); err != nil {
+		t.Fatalf("Could not write the provenance: %v", err)
+	}
+	uncompressedPath, err := copyToTemp(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not copy the provenance: %v", err)
+	}
+
+	provenance, err := LoadProvenance("file://" + path)
+	if err != nil {
+		t.Fatalf("Failed to load the compressed provenance: %v", err)
+	}
+	want, err := LoadProvenance("file://" + uncompressedPath)
+	if err != nil {
+		t.Fatalf("Failed to load the provenance: %v", err)
+	}
+	testutil.AssertEq(t, "binary name", provenance.Provenance.BinaryName(), binaryName)
+	// The digest is that of the decompressed provenance.
+	testutil.AssertEq(t, "digest", provenance.SourceMetadata.SHA256Digest, want.SourceMetadata.SHA256Digest)
+
+	notCompressedPath := filepath.Join(dir, "not_compressed.json.gz")
+	if err := os.WriteFile(notCompressedPath, provenanceBytes, 0o600); err != nil {
+		t.Fatalf("Could not write the provenance: %v", err)
+	}
+	if _, err := LoadProvenance("file://" + notCompressedPath); err == nil {
+		t.Fatalf("expected failure for a .gz file that is not compressed")
+	}
+}
+
+func TestGetProvenanceBytes_GzipMaxProvenanceSize(t *testing.T) {
+	defer func(size int64) { MaxProvenanceSize = size }(MaxProvenanceSize)
+	// Compresses to far less than the decompressed size.
+	compressed := gzipBytes(t, bytes.Repeat([]byte(" "), 4096))
+	MaxProvenanceSize = 1024
+	if int64(len(compressed)) > MaxProvenanceSize {
+		t.Fatalf("the compressed content has %d bytes, want at most %d", len(compressed), MaxProvenanceSize)
+	}
+	path := filepath.Join(t.TempDir(), "large.json.gz")
+	if err := os.WriteFile(path, compressed, 0o600); err != nil {
+		t.Fatalf("Could not write the file: %v", err)
+	}
+
+	_, err := GetProvenanceBytes("file://" + path)
+	if !errors.Is(err, ErrProvenanceTooLarge) {
+		t.Fatalf("got %v, want %v", err, ErrProvenanceTooLarge)
+	}
+}
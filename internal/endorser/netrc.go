@@ -0,0 +1,209 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// netrcCredentials are the credentials for a machine in a netrc file.
+type netrcCredentials struct {
+	login    string
+	password string
+}
+
+// Netrc holds the credentials of a netrc file, see LoadNetrc.
+type Netrc struct {
+	// machines maps host names to their credentials.
+	machines map[string]netrcCredentials
+	// defaultCredentials are the credentials of the "default" entry, if any.
+	defaultCredentials *netrcCredentials
+}
+
+// DefaultNetrcPath returns the path of the netrc file used by LoadNetrc if no
+// path is given: the value of the NETRC environment variable if set, and
+// ".netrc" in the home directory otherwise.
+func DefaultNetrcPath() (string, error) {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get the home directory: %v", err)
+	}
+	return filepath.Join(home, ".netrc"), nil
+}
+
+// LoadNetrc reads the netrc file at the given path, or at DefaultNetrcPath if
+// the path is empty, for authenticating fetches with WithNetrc.
+func LoadNetrc(path string) (*Netrc, error) {
+	if path == "" {
+		var err error
+		path, err = DefaultNetrcPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the netrc file: %v", err)
+	}
+	config, err := parseNetrc(content)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the netrc file %s: %v", path, err)
+	}
+	return config, nil
+}
+
+// WithNetrc makes fetches of provenances over HTTPS authenticate with HTTP
+// Basic auth, using the credentials in the given netrc file of the machine
+// matching the host of the URI, or of the "default" entry, see
+// LoadOptions.Netrc. Hosts without credentials are fetched unauthenticated,
+// and credentials are never sent over plain HTTP. Bearer tokens obtained with
+// OAuth2 client credentials (see WithOAuth2ClientCredentials) take
+// precedence. Passwords are redacted from returned errors.
+func WithNetrc(config *Netrc) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.Netrc = config
+	}
+}
+
+// parseNetrc parses the content of a netrc file. Supports the "machine",
+// "default", "login", "password", "account", and "macdef" tokens, as well as
+// comment lines starting with "#". Errors do not contain passwords.
+func parseNetrc(content []byte) (*Netrc, error) {
+	config := &Netrc{machines: make(map[string]netrcCredentials)}
+	var current *netrcCredentials
+	var currentMachine string
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if currentMachine == "" {
+			config.defaultCredentials = current
+		} else if _, found := config.machines[currentMachine]; !found {
+			// As in other implementations, the first entry for a machine wins.
+			config.machines[currentMachine] = *current
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	inMacro := false
+	var tokens []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if inMacro {
+			// Macro definitions end with an empty line.
+			inMacro = strings.TrimSpace(line) != ""
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			if fields[i] == "macdef" {
+				inMacro = true
+				break
+			}
+			tokens = append(tokens, fields[i])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		switch token := tokens[i]; token {
+		case "default":
+			flush()
+			current, currentMachine = &netrcCredentials{}, ""
+		case "machine", "login", "password", "account":
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("missing value for %q", token)
+			}
+			i++
+			value := tokens[i]
+			if token == "machine" {
+				flush()
+				current, currentMachine = &netrcCredentials{}, strings.ToLower(value)
+				continue
+			}
+			if current == nil {
+				return nil, fmt.Errorf("%q outside of a machine entry", token)
+			}
+			if token == "login" {
+				current.login = value
+			} else if token == "password" {
+				current.password = value
+			}
+		default:
+			return nil, fmt.Errorf("unexpected token #%d in the netrc file", i)
+		}
+	}
+	flush()
+	return config, nil
+}
+
+// credentials returns the credentials for the given host, if any.
+func (n *Netrc) credentials(host string) (netrcCredentials, bool) {
+	if credentials, found := n.machines[strings.ToLower(host)]; found {
+		return credentials, true
+	}
+	if n.defaultCredentials != nil {
+		return *n.defaultCredentials, true
+	}
+	return netrcCredentials{}, false
+}
+
+// secrets returns the passwords in the netrc file, and the corresponding
+// encoded Basic auth credentials, for redacting them from errors.
+func (n *Netrc) secrets() []string {
+	entries := make([]netrcCredentials, 0, len(n.machines)+1)
+	for _, credentials := range n.machines {
+		entries = append(entries, credentials)
+	}
+	if n.defaultCredentials != nil {
+		entries = append(entries, *n.defaultCredentials)
+	}
+	var secrets []string
+	for _, credentials := range entries {
+		if credentials.password != "" {
+			encoded := base64.StdEncoding.EncodeToString([]byte(credentials.login + ":" + credentials.password))
+			secrets = append(secrets, encoded, credentials.password)
+		}
+	}
+	return secrets
+}
+
+// applyNetrc sets the HTTP Basic auth credentials for the host of the given
+// HTTPS request from the given netrc file, unless the request already has an
+// Authorization header.
+func applyNetrc(req *http.Request, config *Netrc) {
+	if config == nil || req.URL.Scheme != "https" || req.Header.Get("Authorization") != "" {
+		return
+	}
+	if credentials, found := config.credentials(req.URL.Hostname()); found {
+		req.SetBasicAuth(credentials.login, credentials.password)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+const (
+	netrcLogin    = "ci"
+	netrcPassword = "n3trc-p4ss"
+)
+
+func writeNetrc(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".netrc")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Could not write the netrc file: %v", err)
+	}
+	return path
+}
+
+func TestLoadProvenance_Netrc(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		login, password, ok := r.BasicAuth()
+		if !ok || login != netrcLogin || password != netrcPassword {
+			// Echo the Authorization header, as misbehaving servers might.
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+			return
+		}
+		_, _ = w.Write(provenanceBytes)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Could not parse the server URL: %v", err)
+	}
+	withTLS := WithHTTPClient(server.Client())
+
+	if _, err := LoadProvenance(server.URL+"/provenance.json", withTLS); err == nil {
+		t.Fatalf("expected failure without credentials")
+	}
+
+	// The NETRC environment variable is used if no path is given.
+	t.Setenv("NETRC", writeNetrc(t, fmt.Sprintf(`# Credentials for the test server.
+machine other.example.com login other password other
+machine %s
+  login %s
+  password %s
+macdef init
+  machine ignored login ignored password ignored
+
+`, serverURL.Hostname(), netrcLogin, netrcPassword)))
+	config, err := LoadNetrc("")
+	if err != nil {
+		t.Fatalf("Could not load the netrc file: %v", err)
+	}
+	provenance, err := LoadProvenance(server.URL+"/provenance.json", withTLS, WithNetrc(config))
+	if err != nil {
+		t.Fatalf("Failed to load the provenance: %v", err)
+	}
+	testutil.AssertEq(t, "binary name", provenance.Provenance.BinaryName(), binaryName)
+
+	// Wrong credentials are redacted from the echoed response.
+	wrongPassword := "wr0ng-p4ss"
+	wrongConfig, err := LoadNetrc(writeNetrc(t, fmt.Sprintf("default login %s password %s", netrcLogin, wrongPassword)))
+	if err != nil {
+		t.Fatalf("Could not load the netrc file: %v", err)
+	}
+	_, err = GetProvenanceBytes(server.URL+"/provenance.json", withTLS, WithNetrc(wrongConfig))
+	if err == nil {
+		t.Fatalf("expected failure with wrong credentials")
+	}
+	if !strings.Contains(err.Error(), redacted) || strings.Contains(err.Error(), wrongPassword) {
+		t.Errorf("error does not redact the credentials: %v", err)
+	}
+
+	// Credentials are not sent over plain HTTP.
+	insecure := httptest.NewServer(server.Config.Handler)
+	defer insecure.Close()
+	if _, err := LoadProvenance(insecure.URL+"/provenance.json", WithNetrc(config)); err == nil {
+		t.Fatalf("expected failure over plain HTTP")
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	config, err := parseNetrc([]byte(`machine Example.com login a password pa
+default login d password pd
+machine example.com login b password pb
+`))
+	if err != nil {
+		t.Fatalf("Could not parse the netrc file: %v", err)
+	}
+	credentials, found := config.credentials("example.COM")
+	testutil.AssertEq(t, "found example.com", found, true)
+	testutil.AssertEq(t, "example.com", credentials, netrcCredentials{login: "a", password: "pa"})
+	credentials, found = config.credentials("other.com")
+	testutil.AssertEq(t, "found default", found, true)
+	testutil.AssertEq(t, "default", credentials, netrcCredentials{login: "d", password: "pd"})
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing value", content: "machine example.com login a password"},
+		{name: "outside of machine", content: "login a password " + netrcPassword},
+		{name: "unknown token", content: "machine example.com " + netrcPassword},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseNetrc([]byte(tc.content))
+			if err == nil {
+				t.Fatalf("expected failure")
+			}
+			if strings.Contains(err.Error(), netrcPassword) {
+				t.Errorf("error contains the password: %v", err)
+			}
+		})
+	}
+}
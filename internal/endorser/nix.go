@@ -0,0 +1,105 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// NarinfoProvenanceKey is the key of the narinfo field holding the location of
+// the provenance of a store path, relative to the root of the binary cache,
+// like the standard `URL` field holding the location of the NAR.
+const NarinfoProvenanceKey = "Provenance"
+
+// nixStoreDir is the Nix store directory that store paths in narinfo files
+// are expected to be in.
+const nixStoreDir = "/nix/store"
+
+// nixCacheScheme is the scheme used for fetching from binary caches. Only
+// changed in tests.
+//
+//nolint:gochecknoglobals
+var nixCacheScheme = "https"
+
+// getFromNixCache fetches the provenance of a store path from a Nix binary
+// cache, given a URI of the form `nix://<cache>/<hash>-<name>`, or
+// `nix://<cache>/nix/store/<hash>-<name>`. The narinfo of the store path is
+// fetched from `https://<cache>/<hash>.narinfo`, and the provenance from the
+// location in its NarinfoProvenanceKey field, as configured by the given
+// options.
+func getFromNixCache(ctx context.Context, uri *url.URL, opts LoadOptions) ([]byte, error) {
+	storePath := strings.TrimPrefix(strings.TrimPrefix(uri.Path, nixStoreDir), "/")
+	hash, _, found := strings.Cut(storePath, "-")
+	if uri.Host == "" || !found || hash == "" || strings.Contains(storePath, "/") {
+		return nil, fmt.Errorf("invalid Nix URI %q, want nix://<cache>/<hash>-<name>", uri)
+	}
+
+	cacheURL := url.URL{Scheme: nixCacheScheme, Host: uri.Host}
+	narinfoURL := cacheURL
+	narinfoURL.Path = "/" + hash + ".narinfo"
+	narinfoBytes, err := getJSONOverHTTP(ctx, narinfoURL.String(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the narinfo of %s: %w", storePath, err)
+	}
+	narinfo, err := parseNarinfo(narinfoBytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the narinfo of %s: %v", storePath, err)
+	}
+
+	if got, want := narinfo["StorePath"], path.Join(nixStoreDir, storePath); got != want {
+		return nil, fmt.Errorf("the narinfo is for store path %q, want %q", got, want)
+	}
+	location, ok := narinfo[NarinfoProvenanceKey]
+	if !ok {
+		return nil, fmt.Errorf("the narinfo of %s has no %s field", storePath, NarinfoProvenanceKey)
+	}
+	ref, err := url.Parse(location)
+	if err != nil || ref.IsAbs() || ref.Host != "" {
+		return nil, fmt.Errorf("invalid %s field %q in the narinfo of %s, want a path relative to the cache", NarinfoProvenanceKey, location, storePath)
+	}
+	cacheURL.Path = "/"
+	return getJSONOverHTTP(ctx, cacheURL.ResolveReference(ref).String(), opts)
+}
+
+// parseNarinfo parses the content of a narinfo file, consisting of lines of
+// the form `<key>: <value>`, into a map from keys to values.
+func parseNarinfo(content []byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found || key == "" {
+			return nil, fmt.Errorf("line %d: want <key>: <value>, got %q", lineNumber, line)
+		}
+		if _, ok := fields[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNumber, key)
+		}
+		fields[key] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
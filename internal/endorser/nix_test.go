@@ -0,0 +1,95 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+const nixStorePath = "1b9p07z77phvv2hf6gm9f28syp39f1ag-oak_functions_freestanding_bin"
+
+func TestLoadProvenance_NixCache(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	narinfos := map[string]string{
+		"/1b9p07z77phvv2hf6gm9f28syp39f1ag.narinfo": strings.Join([]string{
+			"StorePath: /nix/store/" + nixStorePath,
+			"URL: nar/0k5q3psz4ysa8l2cvbj2s3ngjibdnmhmm3arammvmxzm4k6l1dxb.nar.xz",
+			"Compression: xz",
+			"NarHash: sha256:0k5q3psz4ysa8l2cvbj2s3ngjibdnmhmm3arammvmxzm4k6l1dxb",
+			"NarSize: 4096",
+			"Provenance: provenance/" + nixStorePath + ".json",
+		}, "\n"),
+		"/2c9p07z77phvv2hf6gm9f28syp39f1ag.narinfo": "StorePath: /nix/store/2c9p07z77phvv2hf6gm9f28syp39f1ag-other\nURL: nar/other.nar.xz\n",
+		"/3d9p07z77phvv2hf6gm9f28syp39f1ag.narinfo": "StorePath: /nix/store/4e9p07z77phvv2hf6gm9f28syp39f1ag-wrong\nProvenance: provenance/wrong.json\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if narinfo, ok := narinfos[r.URL.Path]; ok {
+			_, _ = w.Write([]byte(narinfo))
+			return
+		}
+		if r.URL.Path == "/provenance/"+nixStorePath+".json" {
+			_, _ = w.Write(provenanceBytes)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	nixCacheScheme = "http"
+	defer func() { nixCacheScheme = "https" }()
+	cache := "nix://" + strings.TrimPrefix(server.URL, "http://")
+
+	for _, uri := range []string{cache + "/" + nixStorePath, cache + "/nix/store/" + nixStorePath} {
+		provenance, err := LoadProvenance(uri)
+		if err != nil {
+			t.Fatalf("Failed to load the provenance from %s: %v", uri, err)
+		}
+		testutil.AssertEq(t, "binary name", provenance.Provenance.BinaryName(), binaryName)
+		testutil.AssertEq(t, "URI", provenance.SourceMetadata.URI, uri)
+	}
+
+	tests := map[string]string{
+		"no provenance field":  cache + "/2c9p07z77phvv2hf6gm9f28syp39f1ag-other",
+		"store path mismatch":  cache + "/3d9p07z77phvv2hf6gm9f28syp39f1ag-wrong",
+		"narinfo not found":    cache + "/5f9p07z77phvv2hf6gm9f28syp39f1ag-missing",
+		"no store path":        cache + "/",
+		"invalid store path":   cache + "/nix/store/" + nixStorePath + "/bin/main",
+		"no cache":             "nix:///" + nixStorePath,
+		"store path with dirs": cache + "/other/" + nixStorePath,
+	}
+	for name, uri := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := GetProvenanceBytes(uri); err == nil {
+				t.Errorf("expected failure for %s", uri)
+			}
+		})
+	}
+}
+
+func TestParseNarinfo_Malformed(t *testing.T) {
+	for _, content := range []string{"StorePath /nix/store/x", "URL: a\nURL: b", ": value"} {
+		if _, err := parseNarinfo([]byte(content)); err == nil {
+			t.Errorf("expected failure parsing %q", content)
+		}
+	}
+}
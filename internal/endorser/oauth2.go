@@ -0,0 +1,214 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// OAuth2ClientCredentials configures the OAuth2 client credentials flow for
+// fetching provenances over HTTP(S).
+type OAuth2ClientCredentials struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string
+	// ClientID and ClientSecret are the credentials of the client.
+	ClientID     string
+	ClientSecret string
+	// Scopes optionally specifies the requested scopes.
+	Scopes []string
+	// Hosts are the host names, without port, that the token is sent to. If
+	// empty, the token is only sent to the host of TokenURL. The token is
+	// never sent over plain HTTP, nor to other hosts, e.g., in URIs from
+	// manifests or SBOMs, or after redirects.
+	Hosts []string
+}
+
+// String returns a representation of the credentials with the client secret
+// redacted, so that the credentials can be safely logged.
+func (c OAuth2ClientCredentials) String() string {
+	return fmt.Sprintf("{TokenURL:%s ClientID:%s ClientSecret:%s Scopes:%v Hosts:%v}", c.TokenURL, c.ClientID, redacted, c.Scopes, c.Hosts)
+}
+
+const redacted = "[REDACTED]"
+
+// DefaultHTTPTimeout is the timeout of the default client used for fetching
+// provenances over HTTP(S). It bounds the time of a single fetch, including
+// reading the response body.
+const DefaultHTTPTimeout = 5 * time.Minute
+
+// defaultHTTPClient is used for fetches without a client set with
+// WithHTTPClient.
+//
+//nolint:gochecknoglobals
+var defaultHTTPClient = &http.Client{Timeout: DefaultHTTPTimeout}
+
+// WithOAuth2ClientCredentials makes fetches of provenances over HTTPS from the
+// hosts of the given credentials (see OAuth2ClientCredentials.Hosts)
+// authenticate with a bearer token obtained using the OAuth2 client
+// credentials flow, see LoadOptions.OAuth2ClientCredentials. The token is
+// obtained on first use, and refreshed automatically when it expires. Tokens
+// are reused across all calls passed the returned option, and obtained with
+// the client of the first fetch authenticated with them, see WithHTTPClient.
+func WithOAuth2ClientCredentials(credentials OAuth2ClientCredentials) func(o *LoadOptions) {
+	tokens := newOAuth2Tokens(credentials)
+	return func(o *LoadOptions) {
+		o.OAuth2ClientCredentials = &credentials
+		o.oauth2Tokens = tokens
+	}
+}
+
+// oauth2Tokens obtains and caches the bearer tokens for a set of OAuth2
+// client credentials.
+type oauth2Tokens struct {
+	config *clientcredentials.Config
+	// hosts are the host names that the tokens are sent to.
+	hosts  []string
+	mu     sync.Mutex
+	source oauth2.TokenSource
+}
+
+func newOAuth2Tokens(credentials OAuth2ClientCredentials) *oauth2Tokens {
+	hosts := credentials.Hosts
+	if len(hosts) == 0 {
+		if tokenURL, err := url.Parse(credentials.TokenURL); err == nil {
+			hosts = []string{tokenURL.Hostname()}
+		}
+	}
+	return &oauth2Tokens{
+		config: &clientcredentials.Config{
+			ClientID:     credentials.ClientID,
+			ClientSecret: credentials.ClientSecret,
+			TokenURL:     credentials.TokenURL,
+			Scopes:       credentials.Scopes,
+		},
+		hosts: hosts,
+	}
+}
+
+// authenticates reports whether the tokens are sent with requests to the
+// given URL, i.e., whether it is an HTTPS URL of one of the hosts.
+func (t *oauth2Tokens) authenticates(u *url.URL) bool {
+	if u.Scheme != "https" {
+		return false
+	}
+	for _, host := range t.hosts {
+		if host != "" && strings.EqualFold(u.Hostname(), host) {
+			return true
+		}
+	}
+	return false
+}
+
+// token returns a valid token, obtaining a new one with the given client if
+// there is none yet, or if it has expired.
+func (t *oauth2Tokens) token(client *http.Client) (*oauth2.Token, error) {
+	t.mu.Lock()
+	if t.source == nil {
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+		t.source = t.config.TokenSource(ctx)
+	}
+	source := t.source
+	t.mu.Unlock()
+	return source.Token()
+}
+
+// errTokenRequest is wrapped by errors returned by doHTTPRequest if obtaining
+// an OAuth2 token fails. Such errors are not retried.
+var errTokenRequest = errors.New("could not obtain an OAuth2 token")
+
+// doHTTPRequest sends the given request with the client of the given options,
+// or with defaultHTTPClient if not set, authenticating as configured with
+// WithOAuth2ClientCredentials and WithNetrc. Returned errors do not contain
+// the client secret, nor credentials from the netrc file.
+func doHTTPRequest(req *http.Request, opts LoadOptions) (*http.Response, error) {
+	client := opts.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	if opts.oauth2Tokens != nil && opts.oauth2Tokens.authenticates(req.URL) {
+		token, err := opts.oauth2Tokens.token(client)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errTokenRequest, redactHTTPSecrets(err.Error(), opts))
+		}
+		token.SetAuthHeader(req)
+	}
+	applyNetrc(req, opts.Netrc)
+	if req.Header.Get("Authorization") != "" {
+		client = withoutForwardedCredentials(client)
+	}
+
+	resp, err := client.Do(req)
+	if err == nil {
+		return resp, nil
+	}
+	if message := redactHTTPSecrets(err.Error(), opts); message != err.Error() {
+		return nil, errors.New(message)
+	}
+	return nil, err
+}
+
+// maxRedirects is the number of redirects followed by clients without a
+// CheckRedirect policy, as by the default policy of http.Client.
+const maxRedirects = 10
+
+// withoutForwardedCredentials returns a copy of the given client that does
+// not forward the Authorization header of a request on redirects to another
+// host, or over plain HTTP. The Authorization header is otherwise forwarded
+// to the same domain and its subdomains by http.Client.
+func withoutForwardedCredentials(client *http.Client) *http.Client {
+	checkRedirect := client.CheckRedirect
+	withoutCredentials := *client
+	withoutCredentials.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "https" || !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+			req.Header.Del("Authorization")
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+	return &withoutCredentials
+}
+
+// redactHTTPSecrets replaces the client secret and the credentials from the
+// netrc file of the given options in the given message.
+func redactHTTPSecrets(message string, opts LoadOptions) string {
+	var secrets []string
+	if opts.OAuth2ClientCredentials != nil {
+		secrets = append(secrets, opts.OAuth2ClientCredentials.ClientSecret)
+	}
+	if opts.Netrc != nil {
+		secrets = append(secrets, opts.Netrc.secrets()...)
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			message = strings.ReplaceAll(message, secret, redacted)
+		}
+	}
+	return message
+}
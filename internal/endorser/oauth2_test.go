@@ -0,0 +1,246 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+const (
+	clientID     = "endorser"
+	clientSecret = "s3cr3t"
+	accessToken  = "token-1234"
+)
+
+func TestLoadProvenance_OAuth2ClientCredentials(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	tokenRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != clientID || secret != clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": %q, "token_type": "bearer", "expires_in": 3600}`, accessToken)
+	})
+	mux.HandleFunc("/provenance.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(provenanceBytes)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	withTLS := WithHTTPClient(server.Client())
+	withOAuth2 := WithOAuth2ClientCredentials(OAuth2ClientCredentials{
+		TokenURL:     server.URL + "/token",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	})
+
+	for i := 0; i < 2; i++ {
+		provenance, err := LoadProvenance(server.URL+"/provenance.json", withTLS, withOAuth2)
+		if err != nil {
+			t.Fatalf("Failed to load the provenance: %v", err)
+		}
+		testutil.AssertEq(t, "binary name", provenance.Provenance.BinaryName(), binaryName)
+	}
+	// The token is reused until it expires.
+	testutil.AssertEq(t, "token requests", tokenRequests, 1)
+}
+
+func TestGetProvenanceBytes_OAuth2SecretRedacted(t *testing.T) {
+	// A misbehaving token endpoint that echoes the request in its error.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	credentials := OAuth2ClientCredentials{
+		TokenURL:     server.URL + "/token",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+	_, err := GetProvenanceBytes(server.URL+"/provenance.json", WithHTTPClient(server.Client()), WithOAuth2ClientCredentials(credentials))
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	if strings.Contains(err.Error(), clientSecret) {
+		t.Errorf("error contains the client secret: %v", err)
+	}
+	if strings.Contains(credentials.String(), clientSecret) {
+		t.Errorf("string representation contains the client secret: %s", credentials.String())
+	}
+}
+
+// headerTransport adds a header to every request sent with the base
+// transport.
+type headerTransport struct {
+	name, value string
+	base        http.RoundTripper
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.name, t.value)
+	return t.base.RoundTrip(req)
+}
+
+func TestLoadProvenance_CustomHTTPClient(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	tokenRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if r.Header.Get("X-Gateway-Key") != "gateway" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": %q, "token_type": "bearer", "expires_in": 3600}`, accessToken)
+	})
+	mux.HandleFunc("/provenance.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Gateway-Key") != "gateway" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("Authorization") != "" && r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(provenanceBytes)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	withTLS := WithHTTPClient(server.Client())
+
+	if _, err := LoadProvenance(server.URL+"/provenance.json", withTLS); err == nil {
+		t.Fatalf("expected failure without the gateway header")
+	}
+
+	withGateway := WithHTTPClient(&http.Client{Transport: headerTransport{name: "X-Gateway-Key", value: "gateway", base: server.Client().Transport}})
+	provenance, err := LoadProvenance(server.URL+"/provenance.json", withGateway)
+	if err != nil {
+		t.Fatalf("Failed to load the provenance: %v", err)
+	}
+	testutil.AssertEq(t, "binary name", provenance.Provenance.BinaryName(), binaryName)
+	// The client only applies to the call it is passed to.
+	if _, err := LoadProvenance(server.URL+"/provenance.json", withTLS); err == nil {
+		t.Fatalf("expected failure without the gateway header")
+	}
+
+	// The custom client is also used with OAuth2 client credentials.
+	withOAuth2 := WithOAuth2ClientCredentials(OAuth2ClientCredentials{
+		TokenURL:     server.URL + "/token",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := LoadProvenance(server.URL+"/provenance.json", withGateway, withOAuth2); err != nil {
+			t.Fatalf("Failed to load the provenance with OAuth2: %v", err)
+		}
+	}
+	// The token is reused across fetches with the same option.
+	testutil.AssertEq(t, "token requests", tokenRequests, 1)
+}
+
+func TestGetProvenanceBytes_OAuth2ScopedToHosts(t *testing.T) {
+	authorizations := map[string]string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": %q, "token_type": "bearer", "expires_in": 3600}`, accessToken)
+	})
+	mux.HandleFunc("/provenance.json", func(w http.ResponseWriter, r *http.Request) {
+		authorizations[r.Host] = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://other.example.com/provenance.json", http.StatusFound)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	insecure := httptest.NewServer(mux)
+	defer insecure.Close()
+
+	// Clients connecting to the test servers for any host name.
+	clientFor := func(addr string, transport *http.Transport) *http.Client {
+		transport = transport.Clone()
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		}
+		return &http.Client{Transport: transport}
+	}
+	withTLS := WithHTTPClient(clientFor(server.Listener.Addr().String(), server.Client().Transport.(*http.Transport)))
+	withInsecure := WithHTTPClient(clientFor(insecure.Listener.Addr().String(), &http.Transport{}))
+	withOAuth2 := WithOAuth2ClientCredentials(OAuth2ClientCredentials{
+		TokenURL:     "https://auth.example.com/token",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Hosts:        []string{"provenances.example.com"},
+	})
+
+	tests := []struct {
+		name  string
+		uri   string
+		host  string
+		https bool
+		want  string
+	}{
+		{name: "configured host", uri: "https://provenances.example.com/provenance.json", host: "provenances.example.com", https: true, want: "Bearer " + accessToken},
+		{name: "other host", uri: "https://other.example.com/provenance.json", host: "other.example.com", https: true},
+		{name: "redirect to other host", uri: "https://provenances.example.com/redirect", host: "other.example.com", https: true},
+		{name: "plain HTTP", uri: "http://provenances.example.com/provenance.json", host: "provenances.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delete(authorizations, tt.host)
+			withClient := withInsecure
+			if tt.https {
+				withClient = withTLS
+			}
+			if _, err := GetProvenanceBytes(tt.uri, withClient, withOAuth2); err != nil {
+				t.Fatalf("Failed to get the provenance: %v", err)
+			}
+			got, found := authorizations[tt.host]
+			testutil.AssertEq(t, "fetched", found, true)
+			testutil.AssertEq(t, "Authorization header", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures how fetches of provenances over HTTP(S) are retried
+// after transient failures, i.e., network errors and responses with a 5xx
+// status. Other failures, e.g., responses with a 4xx status, are not retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values less than 1 are treated as 1, i.e., no retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. The delay doubles with
+	// every further retry, up to MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay before a retry, including jitter. Values
+	// less than 1 are treated as defaultMaxRetryDelay.
+	MaxDelay time.Duration
+	// Jitter is the maximum fraction of the delay that is randomly added to
+	// it, so that concurrent clients do not retry in lockstep.
+	Jitter float64
+}
+
+// defaultMaxRetryDelay is the maximum delay before a retry, unless another
+// one is set in the RetryPolicy.
+const defaultMaxRetryDelay = 30 * time.Second
+
+// DefaultRetryPolicy returns the retry policy used unless another one is set
+// with WithRetryPolicy: 3 attempts, with delays of about 0.5s and 1s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: defaultMaxRetryDelay, Jitter: 0.2}
+}
+
+// WithRetryPolicy makes fetches of provenances over HTTP(S) be retried with
+// the given policy, see LoadOptions.RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.RetryPolicy = &policy
+	}
+}
+
+// retryPolicy returns the retry policy of the options, or DefaultRetryPolicy
+// if not set.
+func (o LoadOptions) retryPolicy() RetryPolicy {
+	if o.RetryPolicy == nil {
+		return DefaultRetryPolicy()
+	}
+	return *o.RetryPolicy
+}
+
+// delay returns the delay before the given retry, starting from 1. The delay
+// is doubled at most until it reaches the maximum delay, so it cannot
+// overflow however many retries there are.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	maxDelay := p.MaxDelay
+	if maxDelay < 1 {
+		maxDelay = defaultMaxRetryDelay
+	}
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay > 0 && delay < maxDelay; i++ {
+		if delay > maxDelay/2 {
+			delay = maxDelay
+		} else {
+			delay *= 2
+		}
+	}
+	if p.Jitter > 0 {
+		//nolint:gosec
+		jitter := rand.Float64() * p.Jitter * float64(delay)
+		if jitter >= float64(maxDelay-delay) {
+			return maxDelay
+		}
+		delay += time.Duration(jitter)
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// retryableError marks an error as caused by a transient failure.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+
+func (e *retryableError) Unwrap() error { return e.err }
+
+// withRetries calls the given function until it succeeds, returns an error
+// that is not a retryableError, or the maximum number of attempts of the
+// given RetryPolicy is reached. If the given context is done while waiting
+// for a retry, returns an error wrapping the error of the context.
+func withRetries(ctx context.Context, uri string, policy RetryPolicy, attempt func() ([]byte, error)) ([]byte, error) {
+	for i := 1; ; i++ {
+		body, err := attempt()
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return body, err
+		}
+		if i >= policy.MaxAttempts {
+			if i == 1 {
+				return nil, retryable.err
+			}
+			return nil, fmt.Errorf("%w (giving up after %d attempts)", retryable.err, i)
+		}
+
+		timer := time.NewTimer(policy.delay(i))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("fetching %s: %w while waiting to retry after: %v", uri, ctx.Err(), retryable.err)
+		case <-timer.C:
+		}
+	}
+}
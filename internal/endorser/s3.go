@@ -0,0 +1,115 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// S3GetObjectAPI is the part of the S3 client API used for fetching
+// provenances from S3. It is implemented by *s3.Client.
+type S3GetObjectAPI interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// ErrS3AccessDenied is returned when S3 denies access to an object.
+var ErrS3AccessDenied = errors.New("access to S3 object denied")
+
+// ErrS3ObjectNotFound is returned when an S3 object does not exist.
+var ErrS3ObjectNotFound = errors.New("S3 object does not exist")
+
+// WithS3Region makes provenances with "s3" URIs be fetched from the given AWS
+// region, see LoadOptions.S3Region.
+func WithS3Region(region string) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.S3Region = region
+	}
+}
+
+// WithS3Client makes provenances with "s3" URIs be fetched with the given
+// client, see LoadOptions.S3Client.
+func WithS3Client(client S3GetObjectAPI) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.S3Client = client
+	}
+}
+
+// getS3Client returns the S3 client of the given options, or creates one
+// using the default AWS configuration and the region of the options.
+func getS3Client(ctx context.Context, opts LoadOptions) (S3GetObjectAPI, error) {
+	if opts.S3Client != nil {
+		return opts.S3Client, nil
+	}
+	var options []func(*config.LoadOptions) error
+	if opts.S3Region != "" {
+		options = append(options, config.WithRegion(opts.S3Region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the AWS configuration: %v", err)
+	}
+	return s3.NewFromConfig(cfg), nil
+}
+
+// getS3Object fetches the object identified by the given URI of the form
+// `s3://<bucket>/<key>`, with the S3 client configured by the given options.
+// Gzip-compressed objects are decompressed.
+func getS3Object(ctx context.Context, uri *url.URL, opts LoadOptions) ([]byte, error) {
+	bucket, key := uri.Host, strings.TrimPrefix(uri.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("invalid S3 URI %q, want s3://<bucket>/<key>", uri)
+	}
+	client, err := getS3Client(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("fetching %s: %w", uri, ErrS3ObjectNotFound)
+		}
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "AccessDenied" {
+			return nil, fmt.Errorf("fetching %s: %w: %s", uri, ErrS3AccessDenied, apiErr.ErrorMessage())
+		}
+		return nil, fmt.Errorf("fetching %s: %v", uri, err)
+	}
+	defer output.Body.Close()
+
+	if output.ContentLength > MaxProvenanceSize {
+		return nil, errTooLarge()
+	}
+	content, err := readAllLimited(output.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Compressed objects, e.g., ".json.gz", are decompressed as over HTTP.
+	if isGzipEncoding(aws.ToString(output.ContentEncoding)) || isGzip(content) {
+		return gunzipLimited(content)
+	}
+	return content, nil
+}
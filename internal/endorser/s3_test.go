@@ -0,0 +1,92 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+// stubS3Client serves objects from a map keyed by `<bucket>/<key>`.
+type stubS3Client struct {
+	objects map[string][]byte
+	denied  map[string]bool
+}
+
+func (c *stubS3Client) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	name := *params.Bucket + "/" + *params.Key
+	if c.denied[name] {
+		return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "Access Denied"}
+	}
+	content, found := c.objects[name]
+	if !found {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{
+		Body:          io.NopCloser(bytes.NewReader(content)),
+		ContentLength: int64(len(content)),
+	}, nil
+}
+
+func TestLoadProvenance_S3(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read provenance: %v", err)
+	}
+	withS3 := WithS3Client(&stubS3Client{
+		objects: map[string][]byte{
+			"releases/provenances/provenance.json":    provenanceBytes,
+			"releases/provenances/provenance.json.gz": gzipBytes(t, provenanceBytes),
+		},
+		denied: map[string]bool{"private/provenance.json": true},
+	})
+
+	provenance, err := LoadProvenance("s3://releases/provenances/provenance.json", withS3)
+	if err != nil {
+		t.Fatalf("Could not load provenance: %v", err)
+	}
+	testutil.AssertEq(t, "binary name", provenance.Provenance.BinaryName(), binaryName)
+	testutil.AssertEq(t, "URI", provenance.SourceMetadata.URI, "s3://releases/provenances/provenance.json")
+
+	compressed, err := GetProvenanceBytes("s3://releases/provenances/provenance.json.gz", withS3)
+	if err != nil {
+		t.Fatalf("Could not get the compressed provenance: %v", err)
+	}
+	testutil.AssertEq(t, "decompressed bytes", string(compressed), string(provenanceBytes))
+
+	_, err = GetProvenanceBytes("s3://releases/missing.json", withS3)
+	if !errors.Is(err, ErrS3ObjectNotFound) {
+		t.Errorf("got %v, want an error wrapping %v", err, ErrS3ObjectNotFound)
+	}
+
+	_, err = GetProvenanceBytes("s3://private/provenance.json", withS3)
+	if !errors.Is(err, ErrS3AccessDenied) {
+		t.Errorf("got %v, want an error wrapping %v", err, ErrS3AccessDenied)
+	}
+
+	if _, err := GetProvenanceBytes("s3://releases", withS3); err == nil {
+		t.Errorf("expected an error for an S3 URI without a key")
+	}
+}
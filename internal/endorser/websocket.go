@@ -0,0 +1,97 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// DefaultWebSocketTimeout is the default timeout for connecting to a WebSocket
+// endpoint and receiving the provenance from it.
+const DefaultWebSocketTimeout = 30 * time.Second
+
+// WithWebSocketTimeout sets the timeout for connecting to a WebSocket endpoint
+// and receiving the provenance from it, see LoadOptions.WebSocketTimeout.
+func WithWebSocketTimeout(timeout time.Duration) func(o *LoadOptions) {
+	return func(o *LoadOptions) {
+		o.WebSocketTimeout = timeout
+	}
+}
+
+// getOverWebSocket connects to the WebSocket endpoint at the given URI,
+// receives a single message containing the provenance, and closes the
+// connection. Fails if the message is not received within the timeout of the
+// given options, or before the given context is done.
+func getOverWebSocket(ctx context.Context, uri *url.URL, opts LoadOptions) ([]byte, error) {
+	timeout := opts.WebSocketTimeout
+	if timeout == 0 {
+		timeout = DefaultWebSocketTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	origin := *uri
+	origin.Scheme = strings.Replace(uri.Scheme, "ws", "http", 1)
+	config, err := websocket.NewConfig(uri.String(), origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not create WebSocket config: %v", err)
+	}
+	config.Dialer = &net.Dialer{Deadline: deadline}
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("fetching %s: %w", uri, ctxErr)
+		}
+		return nil, fmt.Errorf("could not connect to %s: %v", uri, err)
+	}
+	defer conn.Close()
+
+	// Unblock the receive below if the context is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("could not set the read deadline: %v", err)
+	}
+	conn.MaxPayloadBytes = int(MaxProvenanceSize)
+	var message []byte
+	if err := websocket.Message.Receive(conn, &message); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("fetching %s: %w", uri, ctxErr)
+		}
+		if errors.Is(err, websocket.ErrFrameTooLarge) {
+			return nil, errTooLarge()
+		}
+		return nil, fmt.Errorf("could not receive the provenance from %s: %v", uri, err)
+	}
+	return message, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endorser
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+func TestLoadProvenance_WebSocket(t *testing.T) {
+	provenanceBytes, err := os.ReadFile(provenancePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/provenance", websocket.Handler(func(conn *websocket.Conn) {
+		_ = websocket.Message.Send(conn, provenanceBytes)
+	}))
+	mux.Handle("/silent", websocket.Handler(func(conn *websocket.Conn) {
+		// Keep the connection open without sending anything.
+		var message []byte
+		_ = websocket.Message.Receive(conn, &message)
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	provenance, err := LoadProvenance(wsURL + "/provenance")
+	if err != nil {
+		t.Fatalf("Failed to load the provenance: %v", err)
+	}
+	testutil.AssertEq(t, "binary name", provenance.Provenance.BinaryName(), binaryName)
+	testutil.AssertEq(t, "URI", provenance.SourceMetadata.URI, wsURL+"/provenance")
+
+	start := time.Now()
+	if _, err := GetProvenanceBytes(wsURL+"/silent", WithWebSocketTimeout(50*time.Millisecond)); err == nil {
+		t.Errorf("expected failure")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("read deadline not applied, took %v", elapsed)
+	}
+
+	defaultMaxSize := MaxProvenanceSize
+	MaxProvenanceSize = 16
+	defer func() { MaxProvenanceSize = defaultMaxSize }()
+	if _, err := GetProvenanceBytes(wsURL + "/provenance"); !errors.Is(err, ErrProvenanceTooLarge) {
+		t.Errorf("got %v, want an error wrapping %v", err, ErrProvenanceTooLarge)
+	}
+}
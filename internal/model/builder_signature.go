@@ -0,0 +1,123 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+)
+
+// BuilderSignatureField is the name of the predicate field in which builders
+// that sign their own provenances embed their signature. The value of the
+// field is a JSON object of the form `{"keyid": "...", "sig": "<base64>"}`.
+// The signature is computed over the canonical JSON encoding of the predicate
+// without this field, following the JSON Canonicalization Scheme (JCS) of
+// RFC 8785: object keys are sorted by their UTF-16 code units, there is no
+// insignificant whitespace, only quotes, backslashes and control characters
+// are escaped in strings (e.g., "&" and "<" are not), and numbers are
+// encoded in the shortest form of ECMAScript, e.g., 1 rather than 1.0.
+const BuilderSignatureField = "builderSignature"
+
+// BuilderSignature is a signature embedded into a predicate by the builder,
+// independently of any signatures on the DSSE envelope carrying it.
+type BuilderSignature struct {
+	// KeyID is an optional hint identifying the key used for signing.
+	KeyID string
+	// SignedData is the canonical encoding of the predicate that was signed.
+	SignedData []byte
+	// Signature is the raw signature over SignedData.
+	Signature []byte
+}
+
+type embeddedSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Verify checks the signature against the given public key, with the same
+// algorithms as the signatures of envelopes: ECDSA with the hash matching the
+// size of the curve, e.g., SHA2-256 for P-256, Ed25519, or RSA PKCS #1 v1.5
+// or PSS with SHA2-256.
+func (s *BuilderSignature) Verify(publicKey crypto.PublicKey) error {
+	if err := verifySignature(publicKey, s.SignedData, s.Signature); err != nil {
+		return fmt.Errorf("invalid builder signature: %v", err)
+	}
+	return nil
+}
+
+// ParsePublicKey parses a PEM-encoded PKIX public key.
+func ParsePublicKey(pemBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the public key: %v", err)
+	}
+	return publicKey, nil
+}
+
+// extractBuilderSignature returns the signature embedded in the given
+// predicate, or nil if the predicate carries no such signature.
+func extractBuilderSignature(predicate interface{}) (*BuilderSignature, error) {
+	fields, ok := predicate.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	value, found := fields[BuilderSignatureField]
+	if !found {
+		return nil, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the builder signature: %v", err)
+	}
+	var embedded embeddedSignature
+	if err := json.Unmarshal(valueBytes, &embedded); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the builder signature: %v", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(embedded.Sig)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the builder signature: %v", err)
+	}
+
+	signedData, err := builderSignedData(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &BuilderSignature{KeyID: embedded.KeyID, SignedData: signedData, Signature: sig}, nil
+}
+
+// builderSignedData returns the canonical encoding of the given predicate
+// without the builder signature, i.e., the data covered by the signature.
+func builderSignedData(fields map[string]interface{}) ([]byte, error) {
+	unsigned := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if key != BuilderSignatureField {
+			unsigned[key] = value
+		}
+	}
+	signedData, err := canonicalJSON(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the signed predicate: %v", err)
+	}
+	return signedData, nil
+}
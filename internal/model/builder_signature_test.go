@@ -0,0 +1,206 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// signedProvenanceIR loads the SLSA v0.2 test provenance, embeds a builder
+// signature made with `sign` into its predicate, applies `tamper` to the
+// predicate after signing, and maps the result to a ProvenanceIR.
+func signedProvenanceIR(t *testing.T, sign func(data []byte) []byte, tamper func(predicate map[string]interface{})) *ProvenanceIR {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav02ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	predicate := statement["predicate"].(map[string]interface{})
+	signedData, err := builderSignedData(predicate)
+	if err != nil {
+		t.Fatalf("could not compute the signed data: %v", err)
+	}
+	predicate[BuilderSignatureField] = map[string]string{
+		"keyid": "builder-key",
+		"sig":   base64.StdEncoding.EncodeToString(sign(signedData)),
+	}
+	tamper(predicate)
+
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+	provenanceIR, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+	return provenanceIR
+}
+
+func TestBuilderSignature_Ed25519Valid(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	provenanceIR := signedProvenanceIR(t, func(data []byte) []byte { return ed25519.Sign(private, data) }, func(map[string]interface{}) {})
+
+	signature, err := provenanceIR.BuilderSignature()
+	if err != nil {
+		t.Fatalf("no builder signature found: %v", err)
+	}
+	if signature.KeyID != "builder-key" {
+		t.Errorf("got key ID %q, want %q", signature.KeyID, "builder-key")
+	}
+	if err := signature.Verify(public); err != nil {
+		t.Fatalf("could not verify builder signature: %v", err)
+	}
+}
+
+func TestBuilderSignature_ECDSAValid(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	sign := func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		sig, err := ecdsa.SignASN1(rand.Reader, private, digest[:])
+		if err != nil {
+			t.Fatalf("could not sign: %v", err)
+		}
+		return sig
+	}
+	provenanceIR := signedProvenanceIR(t, sign, func(map[string]interface{}) {})
+
+	signature, err := provenanceIR.BuilderSignature()
+	if err != nil {
+		t.Fatalf("no builder signature found: %v", err)
+	}
+	publicKey, err := ParsePublicKey(encodePublicKey(t, &private.PublicKey))
+	if err != nil {
+		t.Fatalf("could not parse public key: %v", err)
+	}
+	if err := signature.Verify(publicKey); err != nil {
+		t.Fatalf("could not verify builder signature: %v", err)
+	}
+}
+
+func TestBuilderSignature_TamperedPredicateInvalid(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	tamper := func(predicate map[string]interface{}) {
+		predicate["metadata"].(map[string]interface{})["buildInvocationID"] = "tampered"
+	}
+	provenanceIR := signedProvenanceIR(t, func(data []byte) []byte { return ed25519.Sign(private, data) }, tamper)
+
+	signature, err := provenanceIR.BuilderSignature()
+	if err != nil {
+		t.Fatalf("no builder signature found: %v", err)
+	}
+	if err := signature.Verify(public); err == nil {
+		t.Fatalf("expected verification of a tampered predicate to fail")
+	}
+}
+
+func TestBuilderSignature_CanonicalSignedData(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	// The predicate as signed by the builder, in its canonical encoding.
+	const signedData = `{"buildType":"make && make install","parameters":{"jobs":8,"ratio":0.5}}`
+	var predicate map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"parameters": {"ratio": 0.50, "jobs": 8.0},
+		"buildType": "make \u0026\u0026 make install"
+	}`), &predicate); err != nil {
+		t.Fatalf("could not unmarshal the predicate: %v", err)
+	}
+	predicate[BuilderSignatureField] = map[string]interface{}{
+		"keyid": "builder-key",
+		"sig":   base64.StdEncoding.EncodeToString(ed25519.Sign(private, []byte(signedData))),
+	}
+
+	signature, err := extractBuilderSignature(predicate)
+	if err != nil {
+		t.Fatalf("could not extract the builder signature: %v", err)
+	}
+	if string(signature.SignedData) != signedData {
+		t.Errorf("got signed data %s, want %s", signature.SignedData, signedData)
+	}
+	if err := signature.Verify(public); err != nil {
+		t.Fatalf("could not verify builder signature: %v", err)
+	}
+}
+
+func TestBuilderSignature_RSAPSSValid(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	sign := func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		sig, err := rsa.SignPSS(rand.Reader, private, crypto.SHA256, digest[:], nil)
+		if err != nil {
+			t.Fatalf("could not sign: %v", err)
+		}
+		return sig
+	}
+	provenanceIR := signedProvenanceIR(t, sign, func(map[string]interface{}) {})
+
+	signature, err := provenanceIR.BuilderSignature()
+	if err != nil {
+		t.Fatalf("no builder signature found: %v", err)
+	}
+	if err := signature.Verify(&private.PublicKey); err != nil {
+		t.Fatalf("could not verify builder signature: %v", err)
+	}
+}
+
+func TestBuilderSignature_AbsentInUnsignedPredicate(t *testing.T) {
+	provenanceIR := loadProvenanceIR(t, slsav02ProvenancePath)
+	if provenanceIR.HasBuilderSignature() {
+		t.Fatalf("unexpected builder signature in an unsigned provenance")
+	}
+}
+
+func encodePublicKey(t *testing.T, publicKey crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("could not marshal public key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "sorted keys without whitespace", input: `{"b": 1, "a": {"d": [], "c": {}}}`, want: `{"a":{"c":{},"d":[]},"b":1}`},
+		{name: "no HTML escaping", input: `{"a": "x & y <z>"}`, want: `{"a":"x & y <z>"}`},
+		{name: "minimal escaping", input: `["\"\\\/\b\f\n\r\t\u0007\u007f é"]`, want: "[\"\\\"\\\\/\\b\\f\\n\\r\\t\\u0007\u007f é\"]"},
+		{name: "numbers", input: `[1.0, -0, 0.1, 1e21, 1e20, 0.000001, 1e-7, -1.5e300, 9007199254740993]`, want: `[1,0,0.1,1e+21,100000000000000000000,0.000001,1e-7,-1.5e+300,9007199254740992]`},
+		// U+1F600 is encoded with surrogates, which sort before U+E000.
+		{name: "keys sorted by UTF-16 code units", input: `{"": 1, "😀": 2, "a": 3}`, want: "{\"a\":3,\"\U0001F600\":2,\"\":1}"},
+		{name: "literals", input: `[true, false, null]`, want: `[true,false,null]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(tt.input), &value); err != nil {
+				t.Fatalf("could not unmarshal the input: %v", err)
+			}
+			got, err := canonicalJSON(value)
+			if err != nil {
+				t.Fatalf("could not canonicalize: %v", err)
+			}
+			testutil.AssertEq(t, "canonical JSON", string(got), tt.want)
+		})
+	}
+}
+
+func TestCanonicalJSON_UseNumber(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{"a": 1.50}`))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatalf("could not decode the input: %v", err)
+	}
+	got, err := canonicalJSON(value)
+	if err != nil {
+		t.Fatalf("could not canonicalize: %v", err)
+	}
+	testutil.AssertEq(t, "canonical JSON", string(got), `{"a":1.5}`)
+}
+
+func TestCanonicalJSON_Invalid(t *testing.T) {
+	tests := map[string]interface{}{
+		"NaN":           math.NaN(),
+		"infinity":      []interface{}{math.Inf(1)},
+		"invalid UTF-8": map[string]interface{}{"a": "\xff"},
+		"unsupported":   map[string]interface{}{"a": 1},
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := canonicalJSON(value); err == nil {
+				t.Errorf("expected failure")
+			}
+		})
+	}
+}
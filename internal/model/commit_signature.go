@@ -0,0 +1,73 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CommitSignatureField is the name of the predicate field in which builders
+// may record the signature of the source commit, as checked out by the
+// builder, in the form of a JSON object such as
+// `{"format": "gpg", "signer": "<key fingerprint>"}`.
+const CommitSignatureField = "commitSignature"
+
+// Supported formats of commit signatures.
+const (
+	CommitSignatureFormatGPG = "gpg"
+	CommitSignatureFormatSSH = "ssh"
+)
+
+// CommitSignature is the signature of the source commit recorded in a
+// provenance.
+type CommitSignature struct {
+	// Format is the format of the signature, either CommitSignatureFormatGPG
+	// or CommitSignatureFormatSSH.
+	Format string `json:"format"`
+	// Signer identifies the key that signed the commit, e.g., the
+	// fingerprint of the GPG key, or the SHA256 fingerprint of the SSH key
+	// in the form "SHA256:<base64>".
+	Signer string `json:"signer"`
+}
+
+// extractCommitSignature returns the commit signature recorded in the given
+// predicate, or nil if the predicate records none.
+func extractCommitSignature(predicate interface{}) (*CommitSignature, error) {
+	fields, ok := predicate.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	value, found := fields[CommitSignatureField]
+	if !found {
+		return nil, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the commit signature: %v", err)
+	}
+	var signature CommitSignature
+	if err := json.Unmarshal(valueBytes, &signature); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the commit signature: %v", err)
+	}
+	if signature.Format != CommitSignatureFormatGPG && signature.Format != CommitSignatureFormatSSH {
+		return nil, fmt.Errorf("unsupported commit signature format %q, want %q or %q", signature.Format, CommitSignatureFormatGPG, CommitSignatureFormatSSH)
+	}
+	if signature.Signer == "" {
+		return nil, fmt.Errorf("the commit signature has no signer")
+	}
+	return &signature, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+// provenanceWithCommitSignature loads the SLSA v0.2 test provenance, sets the
+// given value as its commit signature, and parses the result.
+func provenanceWithCommitSignature(t *testing.T, signature interface{}) *ValidatedProvenance {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav02ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	statement["predicate"].(map[string]interface{})[CommitSignatureField] = signature
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+	return provenance
+}
+
+func TestFromValidatedProvenance_CommitSignature(t *testing.T) {
+	provenance := provenanceWithCommitSignature(t, map[string]string{
+		"format": "gpg",
+		"signer": "3AA5C34371567BD2",
+	})
+	provenanceIR, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+	signature, err := provenanceIR.CommitSignature()
+	if err != nil {
+		t.Fatalf("could not get the commit signature: %v", err)
+	}
+	testutil.AssertEq(t, "commit signature", signature, CommitSignature{Format: CommitSignatureFormatGPG, Signer: "3AA5C34371567BD2"})
+
+	if loadProvenanceIR(t, slsav02ProvenancePath).HasCommitSignature() {
+		t.Errorf("unexpected commit signature in a provenance without one")
+	}
+}
+
+func TestFromValidatedProvenance_InvalidCommitSignature(t *testing.T) {
+	tests := map[string]interface{}{
+		"unsupported format": map[string]string{"format": "x509", "signer": "3AA5C34371567BD2"},
+		"no signer":          map[string]string{"format": "ssh"},
+		"not an object":      "3AA5C34371567BD2",
+	}
+	for name, signature := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := FromValidatedProvenance(provenanceWithCommitSignature(t, signature)); err == nil {
+				t.Errorf("expected failure")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DirHashAlgorithm is the name of the algorithm of directory tree hashes in
+// the subject of in-toto statements. The tree hash is computed as in the "h1"
+// scheme of golang.org/x/mod/sumdb/dirhash (with an empty prefix), but is
+// hex-encoded rather than base64-encoded without the "h1:" prefix.
+const DirHashAlgorithm = "dirHash"
+
+// ComputeDirectoryTreeHash returns the hex-encoded tree hash of the directory
+// in the given path, see DirHashAlgorithm. The tree hash is the SHA2-256
+// digest of a summary with a line `<hex SHA2-256 digest>  <path>\n` for every
+// file in the tree, where paths are relative to the directory, use forward
+// slashes, and are sorted.
+func ComputeDirectoryTreeHash(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("couldn't list the files in %q: %v", dir, err)
+	}
+	sort.Strings(files)
+
+	summary := sha256.New()
+	for _, file := range files {
+		if strings.Contains(file, "\n") {
+			return "", fmt.Errorf("file names with newlines are not supported: %q", file)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", fmt.Errorf("couldn't read file %q: %v", file, err)
+		}
+		fmt.Fprintf(summary, "%x  %s\n", sha256.Sum256(data), file)
+	}
+	return hex.EncodeToString(summary.Sum(nil)), nil
+}
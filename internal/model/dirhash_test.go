@@ -0,0 +1,58 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+func TestComputeDirectoryTreeHash(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0o700); err != nil {
+		t.Fatalf("Could not create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bin", "app"), []byte("app"), 0o600); err != nil {
+		t.Fatalf("Could not write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("readme"), 0o600); err != nil {
+		t.Fatalf("Could not write file: %v", err)
+	}
+
+	got, err := ComputeDirectoryTreeHash(dir)
+	if err != nil {
+		t.Fatalf("Could not compute the tree hash: %v", err)
+	}
+	// Computed with:
+	// printf '%s  README\n%s  bin/app\n' $(printf readme | sha256sum | cut -d' ' -f1) \
+	//   $(printf app | sha256sum | cut -d' ' -f1) | sha256sum
+	wantTreeHash := "b5e51e979273cfb549a9f718e059b6bf06e9f9fc215c31e572e6773b17dfd83e"
+	testutil.AssertEq(t, "tree hash", got, wantTreeHash)
+
+	// Changing the content of a file changes the tree hash.
+	if err := os.WriteFile(filepath.Join(dir, "bin", "app"), []byte("other"), 0o600); err != nil {
+		t.Fatalf("Could not write file: %v", err)
+	}
+	got, err = ComputeDirectoryTreeHash(dir)
+	if err != nil {
+		t.Fatalf("Could not compute the tree hash: %v", err)
+	}
+	if got == wantTreeHash {
+		t.Errorf("expected a different tree hash")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// SignatureVerificationLimits bounds the work done when verifying the
+// signatures of a DSSE envelope, so that a pathological envelope carrying a
+// large number of signatures cannot be used to exhaust resources. The zero
+// value stands for DefaultSignatureVerificationLimits.
+type SignatureVerificationLimits struct {
+	// MaxSignatures is the maximum number of signatures an envelope may carry.
+	// Envelopes with more signatures are rejected without verifying any of
+	// them. Zero or a negative value means no limit.
+	MaxSignatures int
+	// Timeout is the total time budget for verifying all signatures of an
+	// envelope. Zero or a negative value means no limit.
+	Timeout time.Duration
+}
+
+// DefaultSignatureVerificationLimits are the limits VerifyEnvelopeSignatures
+// uses if given the zero SignatureVerificationLimits, i.e., if none are
+// explicitly specified. To verify without any limits, set both limits to
+// negative values instead.
+//
+//nolint:gochecknoglobals
+var DefaultSignatureVerificationLimits = SignatureVerificationLimits{
+	MaxSignatures: 16,
+	Timeout:       10 * time.Second,
+}
+
+// VerifyEnvelopeSignatures verifies the signatures of the given DSSE envelope
+// against the given verifiers, and returns the keys that were accepted.
+// Returns an error if the envelope carries more signatures than allowed by
+// `limits`, or DefaultSignatureVerificationLimits if `limits` is the zero
+// value, if verification exceeds the time budget in `limits`, or if none
+// of the signatures could be verified by any of the verifiers. Signatures are
+// verified over the pre-authentication encoding (PAE) of the payload type and
+// the raw decoded payload bytes, as specified in
+// https://github.com/secure-systems-lab/dsse/blob/master/protocol.md. The
+// payload is not canonicalized, so that differences in whitespace, e.g., in a
+// JSON payload, invalidate the signature.
+func VerifyEnvelopeSignatures(ctx context.Context, envelope *dsse.Envelope, verifiers []dsse.Verifier, limits SignatureVerificationLimits) ([]dsse.AcceptedKey, error) {
+	if envelope == nil {
+		return nil, fmt.Errorf("cannot verify a nil envelope")
+	}
+	if len(envelope.Signatures) == 0 {
+		return nil, dsse.ErrNoSignature
+	}
+	if limits == (SignatureVerificationLimits{}) {
+		limits = DefaultSignatureVerificationLimits
+	}
+	if limits.MaxSignatures > 0 && len(envelope.Signatures) > limits.MaxSignatures {
+		return nil, fmt.Errorf("envelope carries too many signatures: have %d but want at most %d", len(envelope.Signatures), limits.MaxSignatures)
+	}
+
+	if limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
+
+	payload, err := envelope.DecodeB64Payload()
+	if err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+	pae := dsse.PAE(envelope.PayloadType, payload)
+
+	var accepted []dsse.AcceptedKey
+	for i, s := range envelope.Signatures {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("signature verification aborted after %d of %d signatures: %w", i, len(envelope.Signatures), err)
+		}
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			return nil, fmt.Errorf("decode signature #%d: %w", i, err)
+		}
+		for _, v := range verifiers {
+			keyID, err := v.KeyID()
+			if err != nil {
+				keyID = ""
+			}
+			if s.KeyID != "" && keyID != "" && s.KeyID != keyID {
+				continue
+			}
+			if err := v.Verify(ctx, pae, sig); err != nil {
+				continue
+			}
+			accepted = append(accepted, dsse.AcceptedKey{Public: v.Public(), KeyID: keyID, Sig: s})
+			break
+		}
+	}
+
+	if len(accepted) == 0 {
+		return nil, fmt.Errorf("none of the %d signatures could be verified", len(envelope.Signatures))
+	}
+	return accepted, nil
+}
+
+// publicKeyVerifier is a dsse.Verifier for a public key, see
+// NewPublicKeyVerifier.
+type publicKeyVerifier struct {
+	public crypto.PublicKey
+}
+
+// NewPublicKeyVerifier returns a dsse.Verifier for the given ECDSA, Ed25519,
+// or RSA public key. ECDSA signatures are verified with the hash matching the
+// size of the curve, and RSA signatures with SHA2-256. The verifier has no key
+// ID, so it is tried for every signature of an envelope.
+func NewPublicKeyVerifier(public crypto.PublicKey) dsse.Verifier {
+	return &publicKeyVerifier{public: public}
+}
+
+func (v *publicKeyVerifier) Verify(_ context.Context, data, sig []byte) error {
+	return verifySignature(v.public, data, sig)
+}
+
+func (v *publicKeyVerifier) KeyID() (string, error) {
+	return "", nil
+}
+
+func (v *publicKeyVerifier) Public() crypto.PublicKey {
+	return v.public
+}
+
+// SignerIDs returns the distinct identities of the signers of the given
+// accepted keys, in sorted order. The identity of a signer is the SHA2-256
+// fingerprint of its PKIX-encoded public key, formatted as "sha256:<hex>", so
+// that the same key is recognized regardless of the key ID it was used with.
+// If the public key cannot be encoded, the key ID is used instead.
+func SignerIDs(accepted []dsse.AcceptedKey) []string {
+	seen := make(map[string]bool)
+	ids := make([]string, 0, len(accepted))
+	for _, key := range accepted {
+		id := key.KeyID
+		if der, err := x509.MarshalPKIXPublicKey(key.Public); err == nil {
+			sum := sha256.Sum256(der)
+			id = "sha256:" + hex.EncodeToString(sum[:])
+		}
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// ed25519Verifier is a minimal dsse.Verifier used in tests.
+type ed25519Verifier struct {
+	keyID  string
+	public ed25519.PublicKey
+	delay  time.Duration
+}
+
+func (v *ed25519Verifier) Verify(_ context.Context, data, sig []byte) error {
+	time.Sleep(v.delay)
+	if !ed25519.Verify(v.public, data, sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (v *ed25519Verifier) KeyID() (string, error) {
+	return v.keyID, nil
+}
+
+func (v *ed25519Verifier) Public() crypto.PublicKey {
+	return v.public
+}
+
+// newSignedEnvelope returns an envelope with the given payload signed
+// `count` times with a fresh ed25519 key, and a verifier for that key.
+func newSignedEnvelope(t *testing.T, payload string, count int) (*dsse.Envelope, *ed25519Verifier) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err)
+	}
+	envelope := &dsse.Envelope{
+		PayloadType: "application/vnd.in-toto+json",
+		Payload:     base64.StdEncoding.EncodeToString([]byte(payload)),
+	}
+	sig := ed25519.Sign(private, dsse.PAE(envelope.PayloadType, []byte(payload)))
+	for i := 0; i < count; i++ {
+		envelope.Signatures = append(envelope.Signatures, dsse.Signature{
+			KeyID: "test-key",
+			Sig:   base64.StdEncoding.EncodeToString(sig),
+		})
+	}
+	return envelope, &ed25519Verifier{keyID: "test-key", public: public}
+}
+
+func TestVerifyEnvelopeSignatures_Success(t *testing.T) {
+	envelope, verifier := newSignedEnvelope(t, "{}", 1)
+
+	accepted, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, DefaultSignatureVerificationLimits)
+	if err != nil {
+		t.Fatalf("Failed to verify envelope: %v", err)
+	}
+	testutil.AssertEq(t, "accepted keys", len(accepted), 1)
+	testutil.AssertEq(t, "key ID", accepted[0].KeyID, "test-key")
+}
+
+func TestVerifyEnvelopeSignatures_WrongKeyFailure(t *testing.T) {
+	envelope, _ := newSignedEnvelope(t, "{}", 1)
+	_, other := newSignedEnvelope(t, "{}", 1)
+
+	if _, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{other}, DefaultSignatureVerificationLimits); err == nil {
+		t.Fatalf("Expected verification with the wrong key to fail")
+	}
+}
+
+func TestVerifyEnvelopeSignatures_TooManySignaturesFailure(t *testing.T) {
+	envelope, verifier := newSignedEnvelope(t, "{}", 5000)
+
+	_, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, DefaultSignatureVerificationLimits)
+	want := fmt.Sprintf("want at most %d", DefaultSignatureVerificationLimits.MaxSignatures)
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %q, want error message containing %q", err, want)
+	}
+}
+
+func TestVerifyEnvelopeSignatures_ZeroLimitsAreDefaults(t *testing.T) {
+	envelope, verifier := newSignedEnvelope(t, "{}", DefaultSignatureVerificationLimits.MaxSignatures+1)
+
+	_, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, SignatureVerificationLimits{})
+	want := fmt.Sprintf("want at most %d", DefaultSignatureVerificationLimits.MaxSignatures)
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %q, want error message containing %q", err, want)
+	}
+
+	unlimited := SignatureVerificationLimits{MaxSignatures: -1, Timeout: -1}
+	if _, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, unlimited); err != nil {
+		t.Fatalf("Failed to verify without limits: %v", err)
+	}
+}
+
+func TestVerifyEnvelopeSignatures_TimeoutFailure(t *testing.T) {
+	envelope, verifier := newSignedEnvelope(t, "{}", 10)
+	verifier.delay = 20 * time.Millisecond
+	limits := SignatureVerificationLimits{MaxSignatures: 10, Timeout: 50 * time.Millisecond}
+
+	_, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, limits)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want error wrapping %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSignerIDs_SameKeyCountedOnce(t *testing.T) {
+	envelope, verifier := newSignedEnvelope(t, "{}", 1)
+	accepted, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, DefaultSignatureVerificationLimits)
+	if err != nil {
+		t.Fatalf("Failed to verify envelope: %v", err)
+	}
+	// The same key, used under a different key ID.
+	other := accepted[0]
+	other.KeyID = "other-key"
+
+	ids := SignerIDs(append(accepted, other))
+	testutil.AssertEq(t, "number of signers", len(ids), 1)
+}
+
+func TestSignerIDs_DistinctKeys(t *testing.T) {
+	var accepted []dsse.AcceptedKey
+	for i := 0; i < 2; i++ {
+		envelope, verifier := newSignedEnvelope(t, "{}", 1)
+		keys, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, DefaultSignatureVerificationLimits)
+		if err != nil {
+			t.Fatalf("Failed to verify envelope: %v", err)
+		}
+		accepted = append(accepted, keys...)
+	}
+
+	ids := SignerIDs(accepted)
+	testutil.AssertEq(t, "number of signers", len(ids), 2)
+}
+
+func TestPAE_KnownVectors(t *testing.T) {
+	tests := []struct {
+		name        string
+		payloadType string
+		payload     string
+		want        string
+	}{
+		{
+			name:        "DSSE specification",
+			payloadType: "http://example.com/HelloWorld",
+			payload:     "hello world",
+			want:        "DSSEv1 29 http://example.com/HelloWorld 11 hello world",
+		},
+		{
+			name: "empty",
+			want: "DSSEv1 0  0 ",
+		},
+		{
+			name:        "lengths in bytes",
+			payloadType: "application/vnd.in-toto+json",
+			payload:     "{\"name\": \"bücher\"}\n",
+			want:        "DSSEv1 28 application/vnd.in-toto+json 20 {\"name\": \"bücher\"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.AssertEq(t, "PAE", string(dsse.PAE(tt.payloadType, []byte(tt.payload))), tt.want)
+		})
+	}
+}
+
+func TestVerifyEnvelopeSignatures_PAEVectors(t *testing.T) {
+	private := ed25519.NewKeyFromSeed([]byte("transparent-release-test-seed-32"))
+	verifier := &ed25519Verifier{keyID: "test-key", public: private.Public().(ed25519.PublicKey)}
+	payloadType := "application/vnd.in-toto+json"
+	// The payload deliberately has non-canonical whitespace.
+	payload := "{ \"_type\" :\t\"https://in-toto.io/Statement/v0.1\" }\n"
+	// Sign the PAE as spelled out in the DSSE specification, independently of
+	// the implementation under test.
+	pae := fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(private, []byte(pae)))
+
+	newEnvelope := func(payload string) *dsse.Envelope {
+		return &dsse.Envelope{
+			PayloadType: payloadType,
+			Payload:     base64.StdEncoding.EncodeToString([]byte(payload)),
+			Signatures:  []dsse.Signature{{KeyID: "test-key", Sig: sig}},
+		}
+	}
+
+	if _, err := VerifyEnvelopeSignatures(context.Background(), newEnvelope(payload), []dsse.Verifier{verifier}, DefaultSignatureVerificationLimits); err != nil {
+		t.Errorf("Failed to verify the signature over the raw payload: %v", err)
+	}
+
+	// Signing is over the raw bytes, so a payload that only differs in
+	// whitespace does not verify.
+	compact := "{\"_type\":\"https://in-toto.io/Statement/v0.1\"}\n"
+	if _, err := VerifyEnvelopeSignatures(context.Background(), newEnvelope(compact), []dsse.Verifier{verifier}, DefaultSignatureVerificationLimits); err == nil {
+		t.Errorf("expected failure for a payload differing in whitespace")
+	}
+
+	// The payload type is covered by the signature as well.
+	envelope := newEnvelope(payload)
+	envelope.PayloadType = "application/json"
+	if _, err := VerifyEnvelopeSignatures(context.Background(), envelope, []dsse.Verifier{verifier}, DefaultSignatureVerificationLimits); err == nil {
+		t.Errorf("expected failure for a different payload type")
+	}
+}
+
+func TestParseEnvelope_SignatureVerifiers(t *testing.T) {
+	statementBytes, err := os.ReadFile(provenanceExamplePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance file: %v", err)
+	}
+	envelope, verifier := newSignedEnvelope(t, string(statementBytes), 1)
+	envelopeBytes, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Could not marshal the envelope: %v", err)
+	}
+
+	validatedProvenance, err := ParseEnvelope(envelopeBytes, WithSignatureVerifiers(NewPublicKeyVerifier(verifier.public)))
+	if err != nil {
+		t.Fatalf("Failed to parse the envelope: %v", err)
+	}
+	provenance, err := FromValidatedProvenance(validatedProvenance)
+	if err != nil {
+		t.Fatalf("Could not map provenance to ProvenanceIR: %v", err)
+	}
+	signers, err := provenance.Signers()
+	if err != nil {
+		t.Fatalf("Could not get the signers: %v", err)
+	}
+	testutil.AssertEq(t, "signers", len(signers), 1)
+
+	// Without verifiers, the signers are unknown.
+	validatedProvenance, err = ParseEnvelope(envelopeBytes)
+	if err != nil {
+		t.Fatalf("Failed to parse the envelope: %v", err)
+	}
+	provenance, err = FromValidatedProvenance(validatedProvenance)
+	if err != nil {
+		t.Fatalf("Could not map provenance to ProvenanceIR: %v", err)
+	}
+	testutil.AssertEq(t, "has signers", provenance.HasSigners(), false)
+
+	_, other := newSignedEnvelope(t, "{}", 1)
+	if _, err := ParseEnvelope(envelopeBytes, WithSignatureVerifiers(other)); err == nil {
+		t.Errorf("expected failure parsing an envelope signed with an unknown key")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeProvenanceIR merges two provenances of the same binary, e.g., from
+// different builders cross-checking a reproducible build, into a single
+// ProvenanceIR. Returns an error if the binary names, or the binary digests
+// for any algorithm recorded in both, disagree, or if both set the source
+// repo, the commit digest, the builder image digest, or the build command,
+// with different values. The resolved dependencies,
+// binary digests, and signers of the merged provenance are the unions of
+// those of the given provenances, in order, without duplicates. All other
+// fields are taken from a, or from b if a does not set them. Since the values
+// of b are dropped if a sets them, both provenances should be verified before
+// merging.
+func MergeProvenanceIR(a, b ProvenanceIR) (*ProvenanceIR, error) {
+	if !strings.EqualFold(a.binarySHA256Digest, b.binarySHA256Digest) {
+		return nil, fmt.Errorf("the SHA2-256 binary digests disagree: %q and %q", a.binarySHA256Digest, b.binarySHA256Digest)
+	}
+	if a.binaryName != b.binaryName {
+		return nil, fmt.Errorf("the binary names disagree: %q and %q", a.binaryName, b.binaryName)
+	}
+	binaryDigests, err := mergeBinaryDigests(a.binaryDigests, b.binaryDigests)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range []error{
+		checkAgreement("repo URIs", a.repoURI, b.repoURI, func(x, y string) bool { return x == y }),
+		checkAgreement("commit SHA1 digests", a.commitSHA1Digest, b.commitSHA1Digest, strings.EqualFold),
+		checkAgreement("builder image SHA2-256 digests", a.builderImageSHA256Digest, b.builderImageSHA256Digest, strings.EqualFold),
+		checkAgreement("build commands", a.buildCmd, b.buildCmd, equalStrings),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merged := a
+	merged.binaryDigests = binaryDigests
+	merged.resolvedDependencies = mergeDependencies(a.resolvedDependencies, b.resolvedDependencies)
+	merged.signers = mergeSigners(a.signers, b.signers)
+
+	merged.buildCmd = orElse(a.buildCmd, b.buildCmd)
+	merged.builderImageSHA256Digest = orElse(a.builderImageSHA256Digest, b.builderImageSHA256Digest)
+	merged.repoURI = orElse(a.repoURI, b.repoURI)
+	merged.commitSHA1Digest = orElse(a.commitSHA1Digest, b.commitSHA1Digest)
+	merged.trustedBuilder = orElse(a.trustedBuilder, b.trustedBuilder)
+	merged.invocationParameters = orElse(a.invocationParameters, b.invocationParameters)
+	merged.buildStartedOn = orElse(a.buildStartedOn, b.buildStartedOn)
+	merged.buildFinishedOn = orElse(a.buildFinishedOn, b.buildFinishedOn)
+	merged.signedOn = orElse(a.signedOn, b.signedOn)
+	merged.reproducible = orElse(a.reproducible, b.reproducible)
+	merged.materialsComplete = orElse(a.materialsComplete, b.materialsComplete)
+	merged.documentSize = orElse(a.documentSize, b.documentSize)
+	merged.builderSignature = orElse(a.builderSignature, b.builderSignature)
+	merged.signerIdentity = orElse(a.signerIdentity, b.signerIdentity)
+	merged.validity = orElse(a.validity, b.validity)
+	merged.commitSignature = orElse(a.commitSignature, b.commitSignature)
+	merged.ociAnnotations = orElse(a.ociAnnotations, b.ociAnnotations)
+	merged.byproducts = orElse(a.byproducts, b.byproducts)
+	merged.platform = orElse(a.platform, b.platform)
+	merged.invocationID = orElse(a.invocationID, b.invocationID)
+	merged.environmentSHA256Digest = orElse(a.environmentSHA256Digest, b.environmentSHA256Digest)
+	merged.externalParametersSHA256Digest = orElse(a.externalParametersSHA256Digest, b.externalParametersSHA256Digest)
+	return &merged, nil
+}
+
+// orElse returns a if it is set, and b otherwise.
+func orElse[T any](a, b *T) *T {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// checkAgreement returns an error naming the given field if both a and b are
+// set, and their values are not equal.
+func checkAgreement[T any](field string, a, b *T, equal func(x, y T) bool) error {
+	if a != nil && b != nil && !equal(*a, *b) {
+		return fmt.Errorf("the %s disagree: %v and %v", field, *a, *b)
+	}
+	return nil
+}
+
+// equalStrings reports whether the given lists of strings are equal.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeBinaryDigests returns the union of the given binary digests, or an
+// error if they disagree for an algorithm. Returns nil if neither is set.
+func mergeBinaryDigests(a, b *map[string]string) (*map[string]string, error) {
+	if a == nil || b == nil {
+		return orElse(a, b), nil
+	}
+	merged := make(map[string]string, len(*a)+len(*b))
+	for alg, digest := range *a {
+		merged[alg] = digest
+	}
+	for alg, digest := range *b {
+		if existing, found := merged[alg]; found && !strings.EqualFold(existing, digest) {
+			return nil, fmt.Errorf("the %s binary digests disagree: %q and %q", alg, existing, digest)
+		}
+		if _, found := merged[alg]; !found {
+			merged[alg] = digest
+		}
+	}
+	return &merged, nil
+}
+
+// mergeDependencies returns the union of the given dependencies, in order,
+// without duplicates. Returns nil if neither is set.
+func mergeDependencies(a, b *[]Dependency) *[]Dependency {
+	if a == nil || b == nil {
+		return orElse(a, b)
+	}
+	merged := make([]Dependency, 0, len(*a)+len(*b))
+	seen := make(map[string]bool)
+	for _, dependencies := range [][]Dependency{*a, *b} {
+		for _, dependency := range dependencies {
+			key := dependency.URI + " " + dependency.canonicalDigest()
+			if !seen[key] {
+				seen[key] = true
+				merged = append(merged, dependency)
+			}
+		}
+	}
+	return &merged
+}
+
+// mergeSigners returns the union of the given signers, in order, without
+// duplicates. Returns nil if neither is set.
+func mergeSigners(a, b *[]string) *[]string {
+	if a == nil || b == nil {
+		return orElse(a, b)
+	}
+	merged := make([]string, 0, len(*a)+len(*b))
+	seen := make(map[string]bool)
+	for _, signer := range append(append([]string{}, *a...), *b...) {
+		if !seen[signer] {
+			seen[signer] = true
+			merged = append(merged, signer)
+		}
+	}
+	return &merged
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+const (
+	mergeDigest     = "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"
+	mergeBinaryName = "oak_functions_freestanding_bin"
+)
+
+func TestMergeProvenanceIR(t *testing.T) {
+	source := Dependency{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": "0f2189703c57845e09d8ab89164a4041c0af0a62"}}
+	builderA := Dependency{URI: "docker://builder-a", Digest: map[string]string{"sha256": "aaaa"}}
+	builderB := Dependency{URI: "docker://builder-b", Digest: map[string]string{"sha256": "bbbb"}}
+	a := NewProvenanceIR(mergeDigest, "build type a", mergeBinaryName,
+		WithTrustedBuilder("builder a"),
+		WithRepoURI(source.URI),
+		WithCommitSHA1Digest(source.Digest["sha1"]),
+		WithBinaryDigests(map[string]string{"sha2-256": mergeDigest}),
+		WithResolvedDependencies([]Dependency{source, builderA}),
+		WithSigners([]string{"signer a"}))
+	b := NewProvenanceIR(strings.ToUpper(mergeDigest), "build type b", mergeBinaryName,
+		WithTrustedBuilder("builder b"),
+		WithInvocationID("invocation b"),
+		WithRepoURI(source.URI),
+		WithCommitSHA1Digest(strings.ToUpper(source.Digest["sha1"])),
+		WithBuildCmd([]string{"make", "release"}),
+		WithBinaryDigests(map[string]string{"sha2-256": mergeDigest, "sha2-512": "5555"}),
+		WithResolvedDependencies([]Dependency{source, builderB}),
+		WithSigners([]string{"signer b", "signer a"}))
+
+	merged, err := MergeProvenanceIR(*a, *b)
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	testutil.AssertEq(t, "binary digest", merged.BinarySHA256Digest(), mergeDigest)
+	testutil.AssertEq(t, "build type", merged.BuildType(), "build type a")
+	builder, err := merged.TrustedBuilder()
+	if err != nil {
+		t.Fatalf("no builder: %v", err)
+	}
+	testutil.AssertEq(t, "builder", builder, "builder a")
+	invocationID, err := merged.InvocationID()
+	if err != nil {
+		t.Fatalf("no invocation ID: %v", err)
+	}
+	testutil.AssertEq(t, "invocation ID", invocationID, "invocation b")
+	testutil.AssertEq(t, "commit digest", merged.CommitSHA1Digest(), source.Digest["sha1"])
+	buildCmd, err := merged.BuildCmd()
+	if err != nil {
+		t.Fatalf("no build command: %v", err)
+	}
+	if diff := cmp.Diff(buildCmd, []string{"make", "release"}); diff != "" {
+		t.Errorf("unexpected build command: %s", diff)
+	}
+
+	dependencies, err := merged.ResolvedDependencies()
+	if err != nil {
+		t.Fatalf("no dependencies: %v", err)
+	}
+	if diff := cmp.Diff(dependencies, []Dependency{source, builderA, builderB}); diff != "" {
+		t.Errorf("unexpected dependencies: %s", diff)
+	}
+	digests, err := merged.BinaryDigests()
+	if err != nil {
+		t.Fatalf("no binary digests: %v", err)
+	}
+	if diff := cmp.Diff(digests, map[string]string{"sha2-256": mergeDigest, "sha2-512": "5555"}); diff != "" {
+		t.Errorf("unexpected binary digests: %s", diff)
+	}
+	signers, err := merged.Signers()
+	if err != nil {
+		t.Fatalf("no signers: %v", err)
+	}
+	if diff := cmp.Diff(signers, []string{"signer a", "signer b"}); diff != "" {
+		t.Errorf("unexpected signers: %s", diff)
+	}
+
+	// The inputs are not modified.
+	aDependencies, _ := a.ResolvedDependencies()
+	testutil.AssertEq(t, "dependencies of a", len(aDependencies), 2)
+	testutil.AssertEq(t, "invocation ID of a", a.HasInvocationID(), false)
+}
+
+func TestMergeProvenanceIR_Disagreements(t *testing.T) {
+	base := NewProvenanceIR(mergeDigest, "build type", mergeBinaryName,
+		WithBinaryDigests(map[string]string{"sha2-512": "5555"}),
+		WithRepoURI("https://github.com/project-oak/oak"),
+		WithCommitSHA1Digest("1111"),
+		WithBuilderImageSHA256Digest("3333"),
+		WithBuildCmd([]string{"make", "release"}))
+	tests := []struct {
+		name  string
+		other *ProvenanceIR
+		want  string
+	}{
+		{
+			name:  "SHA2-256 digest",
+			other: NewProvenanceIR(strings.Repeat("0", 64), "build type", mergeBinaryName),
+			want:  "SHA2-256 binary digests disagree",
+		},
+		{
+			name:  "binary name",
+			other: NewProvenanceIR(mergeDigest, "build type", "other_binary"),
+			want:  "binary names disagree",
+		},
+		{
+			name: "other digest",
+			other: NewProvenanceIR(mergeDigest, "build type", mergeBinaryName,
+				WithBinaryDigests(map[string]string{"sha2-512": "6666"})),
+			want: "sha2-512 binary digests disagree",
+		},
+		{
+			name:  "repo URI",
+			other: NewProvenanceIR(mergeDigest, "build type", mergeBinaryName, WithRepoURI("https://github.com/other/repo")),
+			want:  "repo URIs disagree",
+		},
+		{
+			name:  "commit digest",
+			other: NewProvenanceIR(mergeDigest, "build type", mergeBinaryName, WithCommitSHA1Digest("2222")),
+			want:  "commit SHA1 digests disagree",
+		},
+		{
+			name:  "builder image digest",
+			other: NewProvenanceIR(mergeDigest, "build type", mergeBinaryName, WithBuilderImageSHA256Digest("4444")),
+			want:  "builder image SHA2-256 digests disagree",
+		},
+		{
+			name:  "build command",
+			other: NewProvenanceIR(mergeDigest, "build type", mergeBinaryName, WithBuildCmd([]string{"make", "debug"})),
+			want:  "build commands disagree",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MergeProvenanceIR(*base, *tt.other)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
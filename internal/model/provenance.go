@@ -20,8 +20,13 @@ package model
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	slsav02 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
 	slsav1 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v1"
@@ -35,16 +40,37 @@ import (
 //
 // To add a new field X to `ProvenanceIR`
 // (i) implement GetX, HasX, WithX, and
-// (ii) check whether `WithX` needs to be added to existing mappings to `ProvenanceIR` from validated provenances.
+// (ii) check whether `WithX` needs to be added to existing mappings to `ProvenanceIR` from validated provenances, and
+// (iii) merge X in MergeProvenanceIR.
 type ProvenanceIR struct {
-	binarySHA256Digest       string
-	buildType                string
-	binaryName               string
-	buildCmd                 *[]string
-	builderImageSHA256Digest *string
-	repoURI                  *string
-	commitSHA1Digest         *string
-	trustedBuilder           *string
+	binarySHA256Digest             string
+	buildType                      string
+	binaryName                     string
+	buildCmd                       *[]string
+	builderImageSHA256Digest       *string
+	repoURI                        *string
+	commitSHA1Digest               *string
+	trustedBuilder                 *string
+	invocationParameters           *map[string]string
+	buildStartedOn                 *time.Time
+	buildFinishedOn                *time.Time
+	signedOn                       *time.Time
+	reproducible                   *bool
+	materialsComplete              *bool
+	documentSize                   *int
+	builderSignature               *BuilderSignature
+	signers                        *[]string
+	signerIdentity                 *SignerIdentity
+	validity                       *Validity
+	commitSignature                *CommitSignature
+	ociAnnotations                 *map[string]string
+	binaryDigests                  *map[string]string
+	resolvedDependencies           *[]Dependency
+	byproducts                     *[]Byproduct
+	platform                       *Platform
+	invocationID                   *string
+	environmentSHA256Digest        *string
+	externalParametersSHA256Digest *string
 }
 
 // NewProvenanceIR creates a new proveance with given optional fields.
@@ -108,6 +134,189 @@ func (p *ProvenanceIR) TrustedBuilder() (string, error) {
 	return *p.trustedBuilder, nil
 }
 
+// InvocationParameters returns the parameters of the build invocation, or an
+// error if the invocation parameters have not been set.
+func (p *ProvenanceIR) InvocationParameters() (map[string]string, error) {
+	if !p.HasInvocationParameters() {
+		return nil, fmt.Errorf("provenance does not have invocation parameters")
+	}
+	return *p.invocationParameters, nil
+}
+
+// BuildStartedOn returns the time the build started, or an error if it has not
+// been set.
+func (p *ProvenanceIR) BuildStartedOn() (time.Time, error) {
+	if !p.HasBuildStartedOn() {
+		return time.Time{}, fmt.Errorf("provenance does not have a build start time")
+	}
+	return *p.buildStartedOn, nil
+}
+
+// BuildFinishedOn returns the time the build finished, or an error if it has
+// not been set.
+func (p *ProvenanceIR) BuildFinishedOn() (time.Time, error) {
+	if !p.HasBuildFinishedOn() {
+		return time.Time{}, fmt.Errorf("provenance does not have a build finish time")
+	}
+	return *p.buildFinishedOn, nil
+}
+
+// SignedOn returns the time the provenance was signed, or an error if it has
+// not been set.
+func (p *ProvenanceIR) SignedOn() (time.Time, error) {
+	if !p.HasSignedOn() {
+		return time.Time{}, fmt.Errorf("provenance does not have a signing time")
+	}
+	return *p.signedOn, nil
+}
+
+// Reproducible returns whether the builder claims the build is reproducible,
+// or an error if the provenance makes no claim about reproducibility.
+func (p *ProvenanceIR) Reproducible() (bool, error) {
+	if !p.HasReproducible() {
+		return false, fmt.Errorf("provenance does not have a reproducibility claim")
+	}
+	return *p.reproducible, nil
+}
+
+// MaterialsComplete returns whether the builder claims that the materials of
+// the build are complete, or an error if the provenance makes no such claim.
+func (p *ProvenanceIR) MaterialsComplete() (bool, error) {
+	if !p.HasMaterialsComplete() {
+		return false, fmt.Errorf("provenance does not have a completeness claim for materials")
+	}
+	return *p.materialsComplete, nil
+}
+
+// DocumentSize returns the size in bytes of the raw document the provenance
+// was parsed from, or an error if it has not been set.
+func (p *ProvenanceIR) DocumentSize() (int, error) {
+	if !p.HasDocumentSize() {
+		return 0, fmt.Errorf("provenance does not have a document size")
+	}
+	return *p.documentSize, nil
+}
+
+// BuilderSignature returns the signature the builder embedded in the
+// predicate, or an error if it has not been set.
+func (p *ProvenanceIR) BuilderSignature() (BuilderSignature, error) {
+	if !p.HasBuilderSignature() {
+		return BuilderSignature{}, fmt.Errorf("provenance does not have a builder signature")
+	}
+	return *p.builderSignature, nil
+}
+
+// Signers returns the identities of the verified signers of the provenance,
+// or an error if they have not been set.
+func (p *ProvenanceIR) Signers() ([]string, error) {
+	if !p.HasSigners() {
+		return nil, fmt.Errorf("provenance does not have signers")
+	}
+	return *p.signers, nil
+}
+
+// SignerIdentity returns the identity bound to the keyless signing
+// certificate of the provenance, or an error if it has not been set.
+func (p *ProvenanceIR) SignerIdentity() (SignerIdentity, error) {
+	if !p.HasSignerIdentity() {
+		return SignerIdentity{}, fmt.Errorf("provenance does not have a signer identity")
+	}
+	return *p.signerIdentity, nil
+}
+
+// Validity returns the validity window recorded in the provenance, or an
+// error if it has not been set.
+func (p *ProvenanceIR) Validity() (Validity, error) {
+	if !p.HasValidity() {
+		return Validity{}, fmt.Errorf("provenance does not have a validity")
+	}
+	return *p.validity, nil
+}
+
+// CommitSignature returns the signature of the source commit recorded in the
+// provenance, or an error if it has not been set.
+func (p *ProvenanceIR) CommitSignature() (CommitSignature, error) {
+	if !p.HasCommitSignature() {
+		return CommitSignature{}, fmt.Errorf("provenance does not have a commit signature")
+	}
+	return *p.commitSignature, nil
+}
+
+// OCIAnnotations returns the annotations recorded for OCI resources in the
+// provenance, or an error if they have not been set.
+func (p *ProvenanceIR) OCIAnnotations() (map[string]string, error) {
+	if !p.HasOCIAnnotations() {
+		return nil, fmt.Errorf("provenance does not have OCI annotations")
+	}
+	return *p.ociAnnotations, nil
+}
+
+// BinaryDigests returns all digests of the binary listed in the subject of
+// the provenance, keyed by algorithm, or an error if they have not been set.
+func (p *ProvenanceIR) BinaryDigests() (map[string]string, error) {
+	if !p.HasBinaryDigests() {
+		return nil, fmt.Errorf("provenance does not have binary digests")
+	}
+	return *p.binaryDigests, nil
+}
+
+// ResolvedDependencies returns the resolved dependencies of the build, or the
+// materials of SLSA v0.2 provenances, in the order listed in the provenance,
+// or an error if they have not been set.
+func (p *ProvenanceIR) ResolvedDependencies() ([]Dependency, error) {
+	if !p.HasResolvedDependencies() {
+		return nil, fmt.Errorf("provenance does not have resolved dependencies")
+	}
+	return *p.resolvedDependencies, nil
+}
+
+// Byproducts returns the byproducts of the build, e.g., logs and test
+// reports, in the order listed in the provenance, or an error if they have not
+// been set.
+func (p *ProvenanceIR) Byproducts() ([]Byproduct, error) {
+	if !p.HasByproducts() {
+		return nil, fmt.Errorf("provenance does not have byproducts")
+	}
+	return *p.byproducts, nil
+}
+
+// Platform returns the target platform of the build, or an error if it has
+// not been set.
+func (p *ProvenanceIR) Platform() (Platform, error) {
+	if !p.HasPlatform() {
+		return Platform{}, fmt.Errorf("provenance does not have a platform")
+	}
+	return *p.platform, nil
+}
+
+// InvocationID returns the ID of the build invocation, or an error if it has
+// not been set.
+func (p *ProvenanceIR) InvocationID() (string, error) {
+	if !p.HasInvocationID() {
+		return "", fmt.Errorf("provenance does not have an invocation ID")
+	}
+	return *p.invocationID, nil
+}
+
+// EnvironmentSHA256Digest returns the SHA2-256 digest of the build
+// environment, e.g., the base image, or an error if it has not been set.
+func (p *ProvenanceIR) EnvironmentSHA256Digest() (string, error) {
+	if !p.HasEnvironmentSHA256Digest() {
+		return "", fmt.Errorf("provenance does not have an environment digest")
+	}
+	return *p.environmentSHA256Digest, nil
+}
+
+// ExternalParametersSHA256Digest returns the SHA2-256 digest of the external
+// parameters of the build, as recorded by the builder, or an error if it has
+// not been set.
+func (p *ProvenanceIR) ExternalParametersSHA256Digest() (string, error) {
+	if !p.HasExternalParametersSHA256Digest() {
+		return "", fmt.Errorf("provenance does not have an external parameters digest")
+	}
+	return *p.externalParametersSHA256Digest, nil
+}
+
 // WithBuildCmd sets the build cmd when creating a new ProvenanceIR.
 func WithBuildCmd(buildCmd []string) func(p *ProvenanceIR) {
 	return func(p *ProvenanceIR) {
@@ -168,11 +377,359 @@ func (p *ProvenanceIR) HasTrustedBuilder() bool {
 	return p.trustedBuilder != nil
 }
 
+// WithInvocationParameters sets the parameters of the build invocation when creating a new ProvenanceIR.
+func WithInvocationParameters(invocationParameters map[string]string) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.invocationParameters = &invocationParameters
+	}
+}
+
+// HasInvocationParameters returns true if the invocation parameters have been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasInvocationParameters() bool {
+	return p.invocationParameters != nil
+}
+
+// WithBuildStartedOn sets the time the build started when creating a new ProvenanceIR.
+func WithBuildStartedOn(buildStartedOn time.Time) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.buildStartedOn = &buildStartedOn
+	}
+}
+
+// HasBuildStartedOn returns true if the build start time has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasBuildStartedOn() bool {
+	return p.buildStartedOn != nil
+}
+
+// WithBuildFinishedOn sets the time the build finished when creating a new ProvenanceIR.
+func WithBuildFinishedOn(buildFinishedOn time.Time) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.buildFinishedOn = &buildFinishedOn
+	}
+}
+
+// HasBuildFinishedOn returns true if the build finish time has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasBuildFinishedOn() bool {
+	return p.buildFinishedOn != nil
+}
+
+// WithSignedOn sets the time the provenance was signed when creating a new ProvenanceIR.
+func WithSignedOn(signedOn time.Time) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.signedOn = &signedOn
+	}
+}
+
+// HasSignedOn returns true if the signing time has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasSignedOn() bool {
+	return p.signedOn != nil
+}
+
+// WithReproducible sets the reproducibility claim when creating a new ProvenanceIR.
+func WithReproducible(reproducible bool) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.reproducible = &reproducible
+	}
+}
+
+// HasReproducible returns true if the reproducibility claim has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasReproducible() bool {
+	return p.reproducible != nil
+}
+
+// WithMaterialsComplete sets the completeness claim for materials when creating a new ProvenanceIR.
+func WithMaterialsComplete(complete bool) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.materialsComplete = &complete
+	}
+}
+
+// HasMaterialsComplete returns true if the completeness claim for materials has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasMaterialsComplete() bool {
+	return p.materialsComplete != nil
+}
+
+// WithDocumentSize sets the size of the raw provenance document when creating a new ProvenanceIR.
+func WithDocumentSize(documentSize int) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.documentSize = &documentSize
+	}
+}
+
+// HasDocumentSize returns true if the document size has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasDocumentSize() bool {
+	return p.documentSize != nil
+}
+
+// WithBuilderSignature sets the signature embedded by the builder when creating a new ProvenanceIR.
+func WithBuilderSignature(builderSignature BuilderSignature) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.builderSignature = &builderSignature
+	}
+}
+
+// HasBuilderSignature returns true if the builder signature has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasBuilderSignature() bool {
+	return p.builderSignature != nil
+}
+
+// WithSigners sets the identities of the verified signers when creating a
+// new ProvenanceIR, e.g., as returned by SignerIDs after verifying the DSSE
+// envelope of the provenance.
+func WithSigners(signers []string) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.signers = &signers
+	}
+}
+
+// HasSigners returns true if the signers have been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasSigners() bool {
+	return p.signers != nil
+}
+
+// WithSignerIdentity sets the identity bound to the keyless signing
+// certificate when creating a new ProvenanceIR.
+func WithSignerIdentity(identity SignerIdentity) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.signerIdentity = &identity
+	}
+}
+
+// HasSignerIdentity returns true if the signer identity has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasSignerIdentity() bool {
+	return p.signerIdentity != nil
+}
+
+// WithValidity sets the validity window recorded in the provenance when
+// creating a new ProvenanceIR.
+func WithValidity(validity Validity) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.validity = &validity
+	}
+}
+
+// HasValidity returns true if the validity has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasValidity() bool {
+	return p.validity != nil
+}
+
+// WithCommitSignature sets the signature of the source commit when creating
+// a new ProvenanceIR.
+func WithCommitSignature(signature CommitSignature) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.commitSignature = &signature
+	}
+}
+
+// HasCommitSignature returns true if the commit signature has been set in the
+// ProvenanceIR.
+func (p *ProvenanceIR) HasCommitSignature() bool {
+	return p.commitSignature != nil
+}
+
+// WithOCIAnnotations sets the annotations of OCI resources when creating a new ProvenanceIR.
+func WithOCIAnnotations(ociAnnotations map[string]string) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.ociAnnotations = &ociAnnotations
+	}
+}
+
+// HasOCIAnnotations returns true if the OCI annotations have been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasOCIAnnotations() bool {
+	return p.ociAnnotations != nil
+}
+
+// WithBinaryDigests sets all digests of the binary when creating a new ProvenanceIR.
+func WithBinaryDigests(binaryDigests map[string]string) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.binaryDigests = &binaryDigests
+	}
+}
+
+// HasBinaryDigests returns true if all digests of the binary have been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasBinaryDigests() bool {
+	return p.binaryDigests != nil
+}
+
+// WithResolvedDependencies sets the resolved dependencies when creating a new ProvenanceIR.
+func WithResolvedDependencies(resolvedDependencies []Dependency) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.resolvedDependencies = &resolvedDependencies
+	}
+}
+
+// HasResolvedDependencies returns true if the resolved dependencies have been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasResolvedDependencies() bool {
+	return p.resolvedDependencies != nil
+}
+
+// WithByproducts sets the byproducts of the build when creating a new ProvenanceIR.
+func WithByproducts(byproducts []Byproduct) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.byproducts = &byproducts
+	}
+}
+
+// HasByproducts returns true if the byproducts of the build have been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasByproducts() bool {
+	return p.byproducts != nil
+}
+
+// WithPlatform sets the target platform when creating a new ProvenanceIR.
+func WithPlatform(platform Platform) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.platform = &platform
+	}
+}
+
+// HasPlatform returns true if the target platform has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasPlatform() bool {
+	return p.platform != nil
+}
+
+// WithInvocationID sets the ID of the build invocation when creating a new ProvenanceIR.
+func WithInvocationID(invocationID string) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.invocationID = &invocationID
+	}
+}
+
+// HasInvocationID returns true if the ID of the build invocation has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasInvocationID() bool {
+	return p.invocationID != nil
+}
+
+// WithEnvironmentSHA256Digest sets the digest of the build environment when creating a new ProvenanceIR.
+func WithEnvironmentSHA256Digest(environmentSHA256Digest string) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.environmentSHA256Digest = &environmentSHA256Digest
+	}
+}
+
+// HasEnvironmentSHA256Digest returns true if the digest of the build environment has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasEnvironmentSHA256Digest() bool {
+	return p.environmentSHA256Digest != nil
+}
+
+// WithExternalParametersSHA256Digest sets the digest of the external parameters when creating a new ProvenanceIR.
+func WithExternalParametersSHA256Digest(externalParametersSHA256Digest string) func(p *ProvenanceIR) {
+	return func(p *ProvenanceIR) {
+		p.externalParametersSHA256Digest = &externalParametersSHA256Digest
+	}
+}
+
+// HasExternalParametersSHA256Digest returns true if the digest of the external parameters has been set in the ProvenanceIR.
+func (p *ProvenanceIR) HasExternalParametersSHA256Digest() bool {
+	return p.externalParametersSHA256Digest != nil
+}
+
 // FromValidatedProvenance maps a validated provenance to ProvenanceIR by checking the provenance's
-// predicate and build type.
+// predicate and build type. Mappers registered with RegisterPredicateType take
+// precedence over the built-in mappings.
 //
 // To add a new mapping from a provenance P write `fromP`, which sets every required field `X` from `ProvenanceIR` using `WithX`.
 func FromValidatedProvenance(prov *ValidatedProvenance) (*ProvenanceIR, error) {
+	provenanceIR, err := fromValidatedProvenance(prov)
+	if err != nil {
+		return nil, err
+	}
+	// ValidatedProvenance guarantees exactly one subject.
+	WithBinaryDigests(prov.GetProvenance().Subject[0].Digest)(provenanceIR)
+	// The signing time comes from the envelope or bundle, not the predicate.
+	if prov.signedOn != nil {
+		WithSignedOn(*prov.signedOn)(provenanceIR)
+	}
+	if prov.documentSize != nil {
+		WithDocumentSize(*prov.documentSize)(provenanceIR)
+	}
+	if prov.signerIdentity != nil {
+		WithSignerIdentity(*prov.signerIdentity)(provenanceIR)
+	}
+	if prov.signers != nil {
+		WithSigners(prov.signers)(provenanceIR)
+	}
+	builderSignature, err := extractBuilderSignature(prov.GetProvenance().Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract the builder signature: %v", err)
+	}
+	if builderSignature != nil {
+		WithBuilderSignature(*builderSignature)(provenanceIR)
+	}
+	validity, err := extractValidity(prov.GetProvenance().Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract the validity: %v", err)
+	}
+	if validity != nil {
+		WithValidity(*validity)(provenanceIR)
+	}
+	commitSignature, err := extractCommitSignature(prov.GetProvenance().Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract the commit signature: %v", err)
+	}
+	if commitSignature != nil {
+		WithCommitSignature(*commitSignature)(provenanceIR)
+	}
+	return provenanceIR, nil
+}
+
+// PredicateMapper maps a validated provenance with a particular build type to
+// ProvenanceIR, see RegisterPredicateType.
+type PredicateMapper func(prov *ValidatedProvenance) (*ProvenanceIR, error)
+
+//nolint:gochecknoglobals
+var (
+	predicateMappersMu sync.Mutex
+	predicateMappers   = map[string]PredicateMapper{}
+)
+
+// RegisterPredicateType registers a PredicateMapper for provenances with the
+// given build type, e.g., of a custom build system, to be used by
+// FromValidatedProvenance instead of the built-in mappings. The build type is
+// taken from `buildType` in SLSA v0.2 predicates, and from
+// `buildDefinition.buildType` in SLSA v1 predicates. Registering a mapper for
+// a build type that already has one replaces the previous mapper.
+func RegisterPredicateType(buildType string, mapper PredicateMapper) {
+	predicateMappersMu.Lock()
+	defer predicateMappersMu.Unlock()
+	predicateMappers[buildType] = mapper
+}
+
+// UnregisterPredicateType removes the PredicateMapper registered for the given
+// build type, if any.
+func UnregisterPredicateType(buildType string) {
+	predicateMappersMu.Lock()
+	defer predicateMappersMu.Unlock()
+	delete(predicateMappers, buildType)
+}
+
+// registeredPredicateMapper returns the PredicateMapper registered for the
+// build type of the given provenance, if any.
+func registeredPredicateMapper(prov *ValidatedProvenance) (PredicateMapper, bool) {
+	fields, ok := prov.GetProvenance().Predicate.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	buildType, ok := fields["buildType"].(string)
+	if !ok {
+		if buildDefinition, found := fields["buildDefinition"].(map[string]interface{}); found {
+			buildType, ok = buildDefinition["buildType"].(string)
+		}
+	}
+	if !ok {
+		return nil, false
+	}
+
+	predicateMappersMu.Lock()
+	defer predicateMappersMu.Unlock()
+	mapper, found := predicateMappers[buildType]
+	return mapper, found
+}
+
+func fromValidatedProvenance(prov *ValidatedProvenance) (*ProvenanceIR, error) {
+	if mapper, found := registeredPredicateMapper(prov); found {
+		return mapper(prov)
+	}
+
 	predType := prov.PredicateType()
 	switch predType {
 	case intoto.SLSAV02PredicateType:
@@ -198,7 +755,7 @@ func FromValidatedProvenance(prov *ValidatedProvenance) (*ProvenanceIR, error) {
 // be mapped to a field in `ProvenanceIR`, `fromSLSAv02` sets a non-nil value
 // `v` for `X` by using `WithX(v)`.
 func fromSLSAv02(provenance *ValidatedProvenance) (*ProvenanceIR, error) {
-	// A ValidatedProvenance contains a SHA256 hash of a single subject.
+	// Empty if the single subject of the provenance has no SHA2-256 digest.
 	binarySHA256Digest := provenance.GetBinarySHA256Digest()
 	buildType := slsav02.GenericSLSABuildType
 
@@ -214,20 +771,99 @@ func fromSLSAv02(provenance *ValidatedProvenance) (*ProvenanceIR, error) {
 
 	builder := predicate.Builder.ID
 
-	provenanceIR := NewProvenanceIR(binarySHA256Digest, buildType, binaryName,
+	options := []func(p *ProvenanceIR){
 		WithRepoURI(*repoURI),
 		WithCommitSHA1Digest(*commitHash),
 		WithTrustedBuilder(builder),
-	)
+	}
+
+	if predicate.Invocation.Parameters != nil {
+		params, err := stringifyParameters(predicate.Invocation.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse invocation parameters: %v", err)
+		}
+		options = append(options, WithInvocationParameters(params))
+	}
+
+	if len(predicate.Materials) > 0 {
+		// Materials were renamed to resolved dependencies in SLSA v1.
+		dependencies := make([]Dependency, 0, len(predicate.Materials))
+		for _, m := range predicate.Materials {
+			dependencies = append(dependencies, Dependency{URI: m.URI, Digest: m.Digest})
+		}
+		options = append(options, WithResolvedDependencies(dependencies))
+	}
+
+	if platform := platformFromParameters(predicate.Invocation.Environment, predicate.Invocation.Parameters); platform != nil {
+		options = append(options, WithPlatform(*platform))
+	}
+
+	if digest := environmentDigestFromParameters(predicate.Invocation.Environment); digest != "" {
+		options = append(options, WithEnvironmentSHA256Digest(digest))
+	}
+
+	if predicate.Metadata != nil {
+		if predicate.Metadata.BuildStartedOn != nil {
+			options = append(options, WithBuildStartedOn(*predicate.Metadata.BuildStartedOn))
+		}
+		if predicate.Metadata.BuildFinishedOn != nil {
+			options = append(options, WithBuildFinishedOn(*predicate.Metadata.BuildFinishedOn))
+		}
+		options = append(options, WithReproducible(predicate.Metadata.Reproducible))
+		options = append(options, WithMaterialsComplete(predicate.Metadata.Completeness.Materials))
+		if predicate.Metadata.BuildInvocationID != "" {
+			options = append(options, WithInvocationID(predicate.Metadata.BuildInvocationID))
+		}
+	}
+
+	provenanceIR := NewProvenanceIR(binarySHA256Digest, buildType, binaryName, options...)
 	return provenanceIR, nil
 }
 
+// stringifyParameters converts the given JSON object into a map from keys to
+// string values. String values are kept as they are, while any other value is
+// replaced by its JSON encoding.
+func stringifyParameters(parameters interface{}) (map[string]string, error) {
+	object, ok := parameters.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("want a JSON object, got %T", parameters)
+	}
+	params := make(map[string]string, len(object))
+	for key, value := range object {
+		if str, ok := value.(string); ok {
+			params[key] = str
+			continue
+		}
+		bytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal the value of %q: %v", key, err)
+		}
+		params[key] = string(bytes)
+	}
+	return params, nil
+}
+
 // fromSLSAv1 maps data from a validated SLSA v1 provenance to ProvenanceIR.
 // Invariant: for every data `X` in a validated SLSA v1 provenance that can be
 // mapped to a field in `ProvenanceIR`, `fromSLSAv1` sets a non-nil value `v`
-// for `X` by using `WithX(v)`.
+// for `X` by using `WithX(v)`. Provenances with the container-based build type
+// are mapped by fromContainerBasedSLSAv1, and all others by
+// fromGenericSLSAv1.
 func fromSLSAv1(provenance *ValidatedProvenance) (*ProvenanceIR, error) {
-	// A ValidatedProvenance contains a SHA256 hash of a single subject.
+	predicate, err := slsav1.ParseSLSAv1Provenance(provenance.GetProvenance().Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SLSA v1 provenance predicate: %v", err)
+	}
+	if predicate.BuildDefinition.BuildType == slsav1.DockerBasedBuildType {
+		return fromContainerBasedSLSAv1(provenance)
+	}
+	return fromGenericSLSAv1(provenance, predicate)
+}
+
+// fromContainerBasedSLSAv1 maps data from a validated SLSA v1 provenance with
+// the container-based build type to ProvenanceIR.
+func fromContainerBasedSLSAv1(provenance *ValidatedProvenance) (*ProvenanceIR, error) {
+	// Empty if the single subject of the provenance has no SHA2-256 digest.
 	binarySHA256Digest := provenance.GetBinarySHA256Digest()
 	buildType := slsav1.DockerBasedBuildType
 	binaryName := provenance.GetBinaryName()
@@ -238,6 +874,9 @@ func fromSLSAv1(provenance *ValidatedProvenance) (*ProvenanceIR, error) {
 	}
 
 	repoURI, commitDigest := predicate.RepoURIAndDigest()
+	if repoURI == nil {
+		repoURI, commitDigest = predicate.SourceFromResolvedDependencies()
+	}
 	builder := predicate.BuilderID()
 	buildCmd := predicate.BuildCmd()
 	builderImageDigest, err := predicate.BuilderImageDigest()
@@ -245,17 +884,325 @@ func fromSLSAv1(provenance *ValidatedProvenance) (*ProvenanceIR, error) {
 		return nil, fmt.Errorf("getting builder image digest from SLSA v1 provenance: %v", err)
 	}
 
-	provenanceIR := NewProvenanceIR(binarySHA256Digest, buildType, binaryName,
-		WithRepoURI(*repoURI),
-		WithCommitSHA1Digest(*commitDigest),
+	options := []func(p *ProvenanceIR){
 		WithTrustedBuilder(builder),
 		WithBuildCmd(buildCmd),
 		WithBuilderImageSHA256Digest(builderImageDigest),
-	)
+	}
+	if repoURI != nil {
+		options = append(options, WithRepoURI(*repoURI), WithCommitSHA1Digest(*commitDigest))
+	}
+
+	commonOptions, err := slsav1Options(predicate)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, commonOptions...)
+	provenanceIR := NewProvenanceIR(binarySHA256Digest, buildType, binaryName, options...)
 
 	return provenanceIR, nil
 }
 
+// fromGenericSLSAv1 maps data from a validated SLSA v1 provenance with any
+// build type other than the container-based one to ProvenanceIR, given its
+// parsed predicate. The external parameters are specific to the build type,
+// so only the build type, the builder, and the data in the common structure
+// of SLSA v1 are mapped. The source repo is taken from the resolved
+// dependencies.
+func fromGenericSLSAv1(provenance *ValidatedProvenance, predicate *slsav1.ProvenancePredicate) (*ProvenanceIR, error) {
+	buildType := predicate.BuildDefinition.BuildType
+	if buildType == "" {
+		return nil, fmt.Errorf("the SLSA v1 provenance has no buildType")
+	}
+	builder := predicate.BuilderID()
+	if builder == "" {
+		return nil, fmt.Errorf("the SLSA v1 provenance has no builder ID")
+	}
+
+	options := []func(p *ProvenanceIR){
+		WithTrustedBuilder(builder),
+	}
+	if repoURI, commitDigest := predicate.SourceFromResolvedDependencies(); repoURI != nil {
+		options = append(options, WithRepoURI(*repoURI), WithCommitSHA1Digest(*commitDigest))
+	}
+	commonOptions, err := slsav1Options(predicate)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, commonOptions...)
+
+	return NewProvenanceIR(provenance.GetBinarySHA256Digest(), buildType, provenance.GetBinaryName(), options...), nil
+}
+
+// slsav1Options returns the options setting the fields of ProvenanceIR that
+// are mapped from the common structure of SLSA v1 predicates of all build
+// types.
+func slsav1Options(predicate *slsav1.ProvenancePredicate) ([]func(p *ProvenanceIR), error) {
+	var options []func(p *ProvenanceIR)
+	if len(predicate.BuildDefinition.ResolvedDependencies) > 0 {
+		dependencies := make([]Dependency, 0, len(predicate.BuildDefinition.ResolvedDependencies))
+		for _, r := range predicate.BuildDefinition.ResolvedDependencies {
+			dependencies = append(dependencies, Dependency{URI: r.URI, Digest: r.Digest})
+		}
+		options = append(options, WithResolvedDependencies(dependencies))
+	}
+
+	if len(predicate.RunDetails.Byproducts) > 0 {
+		byproducts := make([]Byproduct, 0, len(predicate.RunDetails.Byproducts))
+		for _, r := range predicate.RunDetails.Byproducts {
+			byproducts = append(byproducts, Byproduct{Name: r.Name, URI: r.URI, Digest: r.Digest})
+		}
+		options = append(options, WithByproducts(byproducts))
+	}
+
+	if platform := platformFromParameters(predicate.BuildDefinition.InternalParameters); platform != nil {
+		options = append(options, WithPlatform(*platform))
+	}
+
+	if digest := environmentDigestFromParameters(predicate.BuildDefinition.InternalParameters); digest != "" {
+		options = append(options, WithEnvironmentSHA256Digest(digest))
+	}
+
+	if digest := externalParametersDigestFromParameters(predicate.BuildDefinition.InternalParameters); digest != "" {
+		options = append(options, WithExternalParametersSHA256Digest(digest))
+	}
+
+	metadata := predicate.RunDetails.BuildMetadata
+	if metadata.InvocationID != "" {
+		options = append(options, WithInvocationID(metadata.InvocationID))
+	}
+	if metadata.StartedOn != nil {
+		options = append(options, WithBuildStartedOn(*metadata.StartedOn))
+	}
+	if metadata.FinishedOn != nil {
+		options = append(options, WithBuildFinishedOn(*metadata.FinishedOn))
+	}
+
+	if annotations := predicate.OCIAnnotations(); len(annotations) > 0 {
+		ociAnnotations, err := stringifyParameters(annotations)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse OCI annotations: %v", err)
+		}
+		options = append(options, WithOCIAnnotations(ociAnnotations))
+	}
+	return options, nil
+}
+
+// Platform is the target platform of a build.
+type Platform struct {
+	// OS is the operating system, e.g., "linux". Empty if unknown.
+	OS string
+	// Architecture is the CPU architecture, normalized with
+	// NormalizeArchitecture, e.g., "amd64". Empty if unknown.
+	Architecture string
+}
+
+// String returns the platform in the form `<os>/<architecture>`.
+func (p Platform) String() string {
+	return p.OS + "/" + p.Architecture
+}
+
+// architectureAliases maps alternative names of CPU architectures, as used by
+// CI systems and toolchains, to the names used by Go and OCI.
+//
+//nolint:gochecknoglobals
+var architectureAliases = map[string]string{
+	"x64":     "amd64",
+	"x86_64":  "amd64",
+	"x86-64":  "amd64",
+	"aarch64": "arm64",
+	"x86":     "386",
+	"i386":    "386",
+	"i686":    "386",
+}
+
+// NormalizeArchitecture returns the lowercase name of the given CPU
+// architecture, with common aliases mapped to the names used by Go and OCI,
+// e.g., "X64" and "x86_64" to "amd64".
+func NormalizeArchitecture(architecture string) string {
+	architecture = strings.ToLower(architecture)
+	if alias, found := architectureAliases[architecture]; found {
+		return alias
+	}
+	return architecture
+}
+
+// platformFromParameters extracts the target platform from the first of the
+// given JSON objects that records it, either as a "platform" entry of the form
+// `<os>/<architecture>[/<variant>]`, or as separate "os" and "arch" entries.
+// Returns nil if none of the objects records a platform.
+func platformFromParameters(parameters ...interface{}) *Platform {
+	for _, p := range parameters {
+		object, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if platform, ok := object["platform"].(string); ok && platform != "" {
+			parts := strings.SplitN(platform, "/", 3)
+			result := Platform{OS: strings.ToLower(parts[0])}
+			if len(parts) > 1 {
+				result.Architecture = NormalizeArchitecture(parts[1])
+			}
+			return &result
+		}
+		osName, _ := object["os"].(string)
+		arch, _ := object["arch"].(string)
+		if osName != "" || arch != "" {
+			return &Platform{OS: strings.ToLower(osName), Architecture: NormalizeArchitecture(arch)}
+		}
+	}
+	return nil
+}
+
+// environmentDigestFromParameters extracts the SHA2-256 digest of the build
+// environment from the first of the given JSON objects that records it, as a
+// "baseImage" (or "base_image") entry. The entry is either an image reference
+// or digest string such as `<name>@sha256:<hex>` or `sha256:<hex>`, or an
+// object with a "digest" map from algorithms to digests, as in in-toto
+// resource descriptors. Returns an empty string if none of the objects records
+// a SHA2-256 digest.
+func environmentDigestFromParameters(parameters ...interface{}) string {
+	for _, p := range parameters {
+		object, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		baseImage, found := object["baseImage"]
+		if !found {
+			baseImage = object["base_image"]
+		}
+		switch image := baseImage.(type) {
+		case string:
+			if i := strings.LastIndex(image, "sha256:"); i >= 0 && image[i+len("sha256:"):] != "" {
+				return strings.ToLower(image[i+len("sha256:"):])
+			}
+		case map[string]interface{}:
+			digests, _ := image["digest"].(map[string]interface{})
+			for _, alg := range []string{"sha256", "sha2-256"} {
+				if digest, ok := digests[alg].(string); ok && digest != "" {
+					return strings.ToLower(digest)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// ExternalParametersDigestField is the name of the entry of the internal
+// parameters of SLSA v1 provenances in which builders may record the digest
+// of the external parameters, e.g., to pin large build configurations by
+// hash. The entry is either a digest string of the form `sha256:<hex>`, or a
+// map from algorithms to digests, as the "digest" of in-toto resource
+// descriptors.
+const ExternalParametersDigestField = "externalParametersDigest"
+
+// externalParametersDigestFromParameters extracts the SHA2-256 digest of the
+// external parameters recorded in the ExternalParametersDigestField entry of
+// the given internal parameters. Returns an empty string if the parameters do
+// not record a SHA2-256 digest.
+func externalParametersDigestFromParameters(parameters interface{}) string {
+	object, ok := parameters.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	switch digest := object[ExternalParametersDigestField].(type) {
+	case string:
+		if hexDigest := strings.TrimPrefix(digest, "sha256:"); hexDigest != digest && hexDigest != "" {
+			return strings.ToLower(hexDigest)
+		}
+	case map[string]interface{}:
+		for _, alg := range []string{"sha256", "sha2-256"} {
+			if hexDigest, ok := digest[alg].(string); ok && hexDigest != "" {
+				return strings.ToLower(hexDigest)
+			}
+		}
+	}
+	return ""
+}
+
+// Dependency is a resolved dependency of a build, identified by its URI and
+// digests.
+type Dependency struct {
+	URI    string
+	Digest map[string]string
+}
+
+// Byproduct is an artifact produced by a build in addition to the subject,
+// e.g., a log or a test report, identified by its name, URI, and digests, any
+// of which may be empty.
+type Byproduct struct {
+	Name   string
+	URI    string
+	Digest map[string]string
+}
+
+// canonicalDigest returns the digests of the dependency as a string of
+// `<algorithm>:<digest>` pairs sorted by algorithm.
+func (d Dependency) canonicalDigest() string {
+	pairs := make([]string, 0, len(d.Digest))
+	for alg, digest := range d.Digest {
+		pairs = append(pairs, alg+":"+digest)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// CanonicalDependencies returns a copy of the given dependencies in canonical
+// order, i.e., sorted by URI, and dependencies with the same URI sorted by
+// their digests. Two lists of dependencies are equivalent if and only if their
+// canonical orders are equal.
+func CanonicalDependencies(dependencies []Dependency) []Dependency {
+	canonical := make([]Dependency, len(dependencies))
+	copy(canonical, dependencies)
+	sort.SliceStable(canonical, func(i, j int) bool {
+		if canonical[i].URI != canonical[j].URI {
+			return canonical[i].URI < canonical[j].URI
+		}
+		return canonical[i].canonicalDigest() < canonical[j].canonicalDigest()
+	})
+	return canonical
+}
+
+// IsCanonicalDependencies returns true if the given dependencies are in the
+// canonical order produced by CanonicalDependencies.
+func IsCanonicalDependencies(dependencies []Dependency) bool {
+	for i := 1; i < len(dependencies); i++ {
+		prev, cur := dependencies[i-1], dependencies[i]
+		if prev.URI > cur.URI || (prev.URI == cur.URI && prev.canonicalDigest() > cur.canonicalDigest()) {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildCommandString returns a shell-like representation of the build command
+// in the given provenance, suitable for displaying to humans. Arguments that
+// contain whitespace or shell metacharacters are single-quoted. Returns false
+// if the provenance does not have a build command, or the build command is
+// empty.
+func BuildCommandString(p ProvenanceIR) (string, bool) {
+	buildCmd, err := p.BuildCmd()
+	if err != nil || len(buildCmd) == 0 {
+		return "", false
+	}
+	quoted := make([]string, 0, len(buildCmd))
+	for _, arg := range buildCmd {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return strings.Join(quoted, " "), true
+}
+
+// shellQuote quotes the given argument for a POSIX shell, if needed.
+func shellQuote(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(arg, " \t\n'\"\\$`!*?&;|<>()[]{}#~") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
 // ComputeSHA256Digest returns the SHA256 digest of the file in the given path, or an error if the
 // file cannot be read.
 func ComputeSHA256Digest(path string) (string, error) {
@@ -15,11 +15,15 @@
 package model
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/project-oak/transparent-release/internal/testutil"
 	slsav02 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
 	slsav1 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v1"
 )
@@ -28,7 +32,10 @@ const (
 	testdataPath          = "../../testdata/"
 	slsav02ProvenancePath = "slsa_v02_provenance.json"
 	slsav1ProvenancePath  = "slsa_v1_provenance.json"
-	wantTOMLDigest        = "322527c0260e25f0e9a2595bd0d71a52294fe2397a7af76165190fd98de8920d"
+	// A SLSA v1 provenance with the GitHub Actions workflow build type, and
+	// two subjects.
+	slsav1GenericProvenancePath = "slsa_v1_generic_provenance.json"
+	wantTOMLDigest              = "322527c0260e25f0e9a2595bd0d71a52294fe2397a7af76165190fd98de8920d"
 )
 
 func TestComputeBinarySHA256Digest(t *testing.T) {
@@ -58,6 +65,16 @@ func TestFromProvenance_Slsav02(t *testing.T) {
 		WithRepoURI("git+https://github.com/project-oak/oak@refs/heads/main"),
 		WithCommitSHA1Digest("1b128fb2556e4bdcc4f92552654bfbca9d2fb8c6"),
 		WithTrustedBuilder("https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"),
+		WithInvocationParameters(map[string]string{}),
+		WithReproducible(false),
+		WithMaterialsComplete(false),
+		WithInvocationID("3230206088-1"),
+		WithDocumentSize(len(statementBytes)),
+		WithBinaryDigests(map[string]string{"sha256": "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"}),
+		WithResolvedDependencies([]Dependency{{
+			URI:    "git+https://github.com/project-oak/oak@refs/heads/main",
+			Digest: map[string]string{"sha1": "1b128fb2556e4bdcc4f92552654bfbca9d2fb8c6"},
+		}}),
 	)
 
 	got, err := FromValidatedProvenance(provenance)
@@ -94,6 +111,13 @@ func TestFromProvenance_Slsav1(t *testing.T) {
 		WithRepoURI("git+https://github.com/project-oak/oak"),
 		WithCommitSHA1Digest("6bac02b6b0442ed944f57b7cba9a5f1119863ca4"),
 		WithTrustedBuilder("https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_docker-based_slsa3.yml@refs/tags/v1.6.0-rc.0"),
+		WithDocumentSize(len(statementBytes)),
+		WithBinaryDigests(map[string]string{"sha256": "813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"}),
+		WithResolvedDependencies([]Dependency{{
+			URI:    "git+https://github.com/slsa-framework/slsa-github-generator@refs/tags/v1.6.0-rc.0",
+			Digest: map[string]string{"sha256": "b96aafbb02449d5ff041856cb0cd251ae3a895a51f10a451f5b655e0f27fc33f"},
+		}}),
+		WithInvocationID("https://github.com/project-oak/oak/actions/runs/4755980100/attempts/1"),
 	)
 
 	got, err := FromValidatedProvenance(provenance)
@@ -105,3 +129,468 @@ func TestFromProvenance_Slsav1(t *testing.T) {
 		t.Errorf("unexpected provenanceIR: %s", diff)
 	}
 }
+
+func TestFromProvenance_Slsav1GenericBuildType(t *testing.T) {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav1GenericProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	if _, err := ParseStatementData(statementBytes); err == nil {
+		t.Fatalf("expected failure parsing a provenance with two subjects")
+	}
+	provenances, err := ParseStatementDataPerSubject(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance file: %v", err)
+	}
+	testutil.AssertEq(t, "number of provenances", len(provenances), 2)
+
+	subjects := []struct{ name, digest string }{
+		{"oak_restricted_kernel", "813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"},
+		{"oak_stage0.bin", "322527c0260e25f0e9a2595bd0d71a52294fe2397a7af76165190fd98de8920d"},
+	}
+	for i, subject := range subjects {
+		want := NewProvenanceIR(subject.digest,
+			"https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1", subject.name,
+			WithRepoURI("git+https://github.com/project-oak/oak@refs/heads/main"),
+			WithCommitSHA1Digest("6bac02b6b0442ed944f57b7cba9a5f1119863ca4"),
+			WithTrustedBuilder("https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.9.0"),
+			WithDocumentSize(len(statementBytes)),
+			WithBinaryDigests(map[string]string{"sha256": subject.digest}),
+			WithResolvedDependencies([]Dependency{{
+				URI:    "git+https://github.com/project-oak/oak@refs/heads/main",
+				Digest: map[string]string{"gitCommit": "6bac02b6b0442ed944f57b7cba9a5f1119863ca4"},
+			}}),
+			WithInvocationID("https://github.com/project-oak/oak/actions/runs/6002215393/attempts/1"),
+			WithBuildStartedOn(time.Date(2023, 8, 28, 10, 0, 0, 0, time.UTC)),
+		)
+
+		got, err := FromValidatedProvenance(provenances[i])
+		if err != nil {
+			t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+		}
+		if diff := cmp.Diff(got, want, cmp.AllowUnexported(ProvenanceIR{})); diff != "" {
+			t.Errorf("unexpected provenanceIR for subject %d: %s", i, diff)
+		}
+	}
+}
+
+func TestFromProvenance_Slsav1GenericBuildTypeWithoutSource(t *testing.T) {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav1GenericProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	statement["subject"] = statement["subject"].([]interface{})[:1]
+	buildDefinition := statement["predicate"].(map[string]interface{})["buildDefinition"].(map[string]interface{})
+	buildDefinition["resolvedDependencies"] = []interface{}{
+		map[string]interface{}{
+			"uri":    "https://example.com/toolchain.tar.gz",
+			"digest": map[string]interface{}{"sha256": "b96aafbb02449d5ff041856cb0cd251ae3a895a51f10a451f5b655e0f27fc33f"},
+		},
+	}
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	validatedProvenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+
+	provenance, err := FromValidatedProvenance(validatedProvenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+	testutil.AssertEq(t, "has repo URI", provenance.HasRepoURI(), false)
+	testutil.AssertEq(t, "build type", provenance.BuildType(), "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1")
+}
+
+func TestBuildCommandString_Slsav02(t *testing.T) {
+	provenance := loadProvenanceIR(t, slsav02ProvenancePath)
+
+	// SLSA v0.2 provenances with the generic build type carry no build command.
+	if got, ok := BuildCommandString(*provenance); ok {
+		t.Errorf("unexpected build command string: %q", got)
+	}
+}
+
+func TestBuildCommandString_Slsav1(t *testing.T) {
+	provenance := loadProvenanceIR(t, slsav1ProvenancePath)
+
+	got, ok := BuildCommandString(*provenance)
+	if !ok {
+		t.Fatalf("no build command string")
+	}
+	want := "env --chdir=oak_functions_enclave_app cargo build --release"
+	if got != want {
+		t.Errorf("unexpected build command string: got %q, want %q", got, want)
+	}
+}
+
+func TestBuildCommandString_Quoting(t *testing.T) {
+	provenance := NewProvenanceIR("", "", "", WithBuildCmd([]string{"sh", "-c", "echo 'hi' && make", ""}))
+
+	got, _ := BuildCommandString(*provenance)
+	want := `sh -c 'echo '\''hi'\'' && make' ''`
+	if got != want {
+		t.Errorf("unexpected build command string: got %q, want %q", got, want)
+	}
+}
+
+// loadProvenanceIR reads the provenance with the given name from the testdata
+// directory and maps it to ProvenanceIR.
+func loadProvenanceIR(t *testing.T, name string) *ProvenanceIR {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, name))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	validatedProvenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance file: %v", err)
+	}
+	provenance, err := FromValidatedProvenance(validatedProvenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+	return provenance
+}
+
+func TestFromProvenance_Slsav1OCIAnnotations(t *testing.T) {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav1ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	buildDefinition := statement["predicate"].(map[string]interface{})["buildDefinition"].(map[string]interface{})
+	builderImage := buildDefinition["externalParameters"].(map[string]interface{})["builderImage"].(map[string]interface{})
+	builderImage["annotations"] = map[string]interface{}{
+		"org.opencontainers.image.source": "https://github.com/project-oak/oak",
+	}
+	buildDefinition["resolvedDependencies"] = []interface{}{
+		map[string]interface{}{
+			"uri":         "oci://example.com/base",
+			"mediaType":   "application/vnd.oci.image.manifest.v1+json",
+			"annotations": map[string]interface{}{"org.opencontainers.image.version": "1.2.3"},
+		},
+		map[string]interface{}{
+			"uri":         "git+https://github.com/project-oak/oak",
+			"annotations": map[string]interface{}{"ignored": "not an OCI resource"},
+		},
+	}
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+	provenanceIR, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+
+	got, err := provenanceIR.OCIAnnotations()
+	if err != nil {
+		t.Fatalf("no OCI annotations found: %v", err)
+	}
+	want := map[string]string{
+		"org.opencontainers.image.source":  "https://github.com/project-oak/oak",
+		"org.opencontainers.image.version": "1.2.3",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected OCI annotations: %s", diff)
+	}
+}
+
+func TestFromProvenance_Slsav1GenericBuildTypeOCIAnnotations(t *testing.T) {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav1GenericProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	statement["subject"] = statement["subject"].([]interface{})[:1]
+	predicate := statement["predicate"].(map[string]interface{})
+	buildDefinition := predicate["buildDefinition"].(map[string]interface{})
+	buildDefinition["resolvedDependencies"] = []interface{}{
+		map[string]interface{}{
+			"uri":         "oci://example.com/base",
+			"mediaType":   "application/vnd.oci.image.manifest.v1+json",
+			"annotations": map[string]interface{}{"org.opencontainers.image.version": "1.2.3"},
+		},
+		map[string]interface{}{
+			"uri":         "git+https://github.com/project-oak/oak",
+			"annotations": map[string]interface{}{"ignored": "not an OCI resource"},
+		},
+	}
+	runDetails := predicate["runDetails"].(map[string]interface{})
+	runDetails["byproducts"] = []interface{}{
+		map[string]interface{}{
+			"uri":         "oci://example.com/image",
+			"mediaType":   "application/vnd.oci.image.index.v1+json",
+			"annotations": map[string]interface{}{"org.opencontainers.image.source": "https://github.com/project-oak/oak"},
+		},
+	}
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+	provenanceIR, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+
+	got, err := provenanceIR.OCIAnnotations()
+	if err != nil {
+		t.Fatalf("no OCI annotations found: %v", err)
+	}
+	want := map[string]string{
+		"org.opencontainers.image.source":  "https://github.com/project-oak/oak",
+		"org.opencontainers.image.version": "1.2.3",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected OCI annotations: %s", diff)
+	}
+}
+
+func TestFromProvenance_Slsav1Byproducts(t *testing.T) {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav1ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	runDetails := statement["predicate"].(map[string]interface{})["runDetails"].(map[string]interface{})
+	runDetails["byproducts"] = []interface{}{
+		map[string]interface{}{"name": "build.log", "digest": map[string]interface{}{"sha256": "1234"}},
+		map[string]interface{}{"uri": "https://example.com/test-report.xml"},
+	}
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+	provenanceIR, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+
+	got, err := provenanceIR.Byproducts()
+	if err != nil {
+		t.Fatalf("no byproducts found: %v", err)
+	}
+	want := []Byproduct{
+		{Name: "build.log", Digest: map[string]string{"sha256": "1234"}},
+		{URI: "https://example.com/test-report.xml"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("unexpected byproducts: %s", diff)
+	}
+}
+
+func TestCanonicalDependencies(t *testing.T) {
+	dependencies := []Dependency{
+		{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": "bb"}},
+		{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": "aa"}},
+		{URI: "docker://builder", Digest: map[string]string{"sha256": "cc"}},
+	}
+	if IsCanonicalDependencies(dependencies) {
+		t.Errorf("expected the dependencies to be non-canonical")
+	}
+
+	canonical := CanonicalDependencies(dependencies)
+	want := []Dependency{dependencies[2], dependencies[1], dependencies[0]}
+	if diff := cmp.Diff(canonical, want); diff != "" {
+		t.Errorf("unexpected canonical dependencies: %s", diff)
+	}
+	if !IsCanonicalDependencies(canonical) {
+		t.Errorf("expected the dependencies to be canonical")
+	}
+	// The input is not modified.
+	if dependencies[0].Digest["sha1"] != "bb" {
+		t.Errorf("expected the input to be unmodified")
+	}
+}
+
+func TestPlatformFromParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []interface{}
+		want       *Platform
+	}{
+		{
+			name:       "OCI platform",
+			parameters: []interface{}{map[string]interface{}{"platform": "linux/arm64/v8"}},
+			want:       &Platform{OS: "linux", Architecture: "arm64"},
+		},
+		{
+			name:       "separate entries with aliases",
+			parameters: []interface{}{map[string]interface{}{"os": "Linux", "arch": "X64"}},
+			want:       &Platform{OS: "linux", Architecture: "amd64"},
+		},
+		{
+			name:       "first object recording a platform",
+			parameters: []interface{}{nil, map[string]interface{}{}, map[string]interface{}{"arch": "aarch64"}},
+			want:       &Platform{Architecture: "arm64"},
+		},
+		{
+			name:       "no platform",
+			parameters: []interface{}{map[string]interface{}{"other": "value"}},
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := platformFromParameters(tt.parameters...)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("unexpected platform: %s", diff)
+			}
+		})
+	}
+}
+
+func TestEnvironmentDigestFromParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []interface{}
+		want       string
+	}{
+		{
+			name:       "image reference",
+			parameters: []interface{}{map[string]interface{}{"baseImage": "docker.io/library/debian@sha256:ABC123"}},
+			want:       "abc123",
+		},
+		{
+			name: "resource descriptor",
+			parameters: []interface{}{nil, map[string]interface{}{
+				"base_image": map[string]interface{}{"digest": map[string]interface{}{"sha256": "abc123"}},
+			}},
+			want: "abc123",
+		},
+		{
+			name:       "no environment digest",
+			parameters: []interface{}{map[string]interface{}{"baseImage": "debian:latest"}},
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := environmentDigestFromParameters(tt.parameters...); got != tt.want {
+				t.Errorf("unexpected environment digest: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalParametersDigestFromParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters interface{}
+		want       string
+	}{
+		{
+			name:       "digest string",
+			parameters: map[string]interface{}{ExternalParametersDigestField: "sha256:ABC123"},
+			want:       "abc123",
+		},
+		{
+			name:       "digest map",
+			parameters: map[string]interface{}{ExternalParametersDigestField: map[string]interface{}{"sha256": "abc123"}},
+			want:       "abc123",
+		},
+		{
+			name:       "unsupported algorithm",
+			parameters: map[string]interface{}{ExternalParametersDigestField: "sha512:abc123"},
+			want:       "",
+		},
+		{
+			name:       "no external parameters digest",
+			parameters: map[string]interface{}{"other": "sha256:abc123"},
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := externalParametersDigestFromParameters(tt.parameters); got != tt.want {
+				t.Errorf("unexpected external parameters digest: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterPredicateType(t *testing.T) {
+	const customBuildType = "https://example.com/custom-build@v1"
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav02ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	statement["predicate"].(map[string]interface{})["buildType"] = customBuildType
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+
+	if _, err := FromValidatedProvenance(provenance); err == nil {
+		t.Fatalf("expected failure for an unregistered build type")
+	}
+
+	RegisterPredicateType(customBuildType, func(prov *ValidatedProvenance) (*ProvenanceIR, error) {
+		return NewProvenanceIR(prov.GetBinarySHA256Digest(), customBuildType, prov.GetBinaryName(), WithBuildCmd([]string{"custom"})), nil
+	})
+	defer UnregisterPredicateType(customBuildType)
+	got, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+	testutil.AssertEq(t, "build type", got.BuildType(), customBuildType)
+	buildCmd, err := got.BuildCmd()
+	if err != nil {
+		t.Fatalf("could not get the build command: %v", err)
+	}
+	testutil.AssertEq(t, "build command", buildCmd[0], "custom")
+	// Fields from the envelope and subject are still set.
+	testutil.AssertEq(t, "has binary digests", got.HasBinaryDigests(), true)
+
+	// Registered mappers take precedence over the built-in ones.
+	RegisterPredicateType(slsav1.DockerBasedBuildType, func(prov *ValidatedProvenance) (*ProvenanceIR, error) {
+		return nil, fmt.Errorf("unsupported by this deployment")
+	})
+	defer UnregisterPredicateType(slsav1.DockerBasedBuildType)
+	v1Bytes, err := os.ReadFile(filepath.Join(testdataPath, slsav1ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	v1Provenance, err := ParseStatementData(v1Bytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+	if _, err := FromValidatedProvenance(v1Provenance); err == nil {
+		t.Errorf("expected the registered mapper to be used")
+	}
+}
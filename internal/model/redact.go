@@ -0,0 +1,109 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "strings"
+
+// Redacted replaces the values of sensitive fields in the result of
+// RedactForLogging.
+const Redacted = "[REDACTED]"
+
+// RedactionOptions configures which fields RedactForLogging masks.
+type RedactionOptions struct {
+	// InternalURIPrefixes lists prefixes of internal URIs. The repository URI,
+	// the trusted builder, the invocation ID, and the URIs of resolved
+	// dependencies are masked if they start with any of them.
+	InternalURIPrefixes []string
+	// PublicParameters lists the names of invocation parameters whose values
+	// are not sensitive. The values of all other parameters are masked.
+	PublicParameters []string
+}
+
+// WithInternalURIPrefixes makes RedactForLogging mask URIs starting with any
+// of the given prefixes.
+func WithInternalURIPrefixes(prefixes ...string) func(o *RedactionOptions) {
+	return func(o *RedactionOptions) {
+		o.InternalURIPrefixes = append(o.InternalURIPrefixes, prefixes...)
+	}
+}
+
+// WithPublicParameters makes RedactForLogging keep the values of the
+// invocation parameters with the given names.
+func WithPublicParameters(names ...string) func(o *RedactionOptions) {
+	return func(o *RedactionOptions) {
+		o.PublicParameters = append(o.PublicParameters, names...)
+	}
+}
+
+// RedactForLogging returns a copy of the given provenance that is safe to log,
+// with the values of sensitive fields replaced by Redacted. By default, the
+// values of all invocation parameters are masked, while their names are kept;
+// see RedactionOptions for what else can be configured. Fields that are not
+// set remain unset, so that the structure of the provenance is preserved. The
+// given provenance is not modified.
+func RedactForLogging(p ProvenanceIR, options ...func(o *RedactionOptions)) ProvenanceIR {
+	opts := RedactionOptions{}
+	for _, addOption := range options {
+		addOption(&opts)
+	}
+	public := make(map[string]bool, len(opts.PublicParameters))
+	for _, name := range opts.PublicParameters {
+		public[name] = true
+	}
+	redactURI := func(uri string) string {
+		for _, prefix := range opts.InternalURIPrefixes {
+			if prefix != "" && strings.HasPrefix(uri, prefix) {
+				return Redacted
+			}
+		}
+		return uri
+	}
+
+	redacted := p
+	if p.invocationParameters != nil {
+		WithInvocationParameters(redactParameters(*p.invocationParameters, public))(&redacted)
+	}
+	if p.repoURI != nil {
+		WithRepoURI(redactURI(*p.repoURI))(&redacted)
+	}
+	if p.trustedBuilder != nil {
+		WithTrustedBuilder(redactURI(*p.trustedBuilder))(&redacted)
+	}
+	if p.invocationID != nil {
+		WithInvocationID(redactURI(*p.invocationID))(&redacted)
+	}
+	if p.resolvedDependencies != nil {
+		dependencies := make([]Dependency, 0, len(*p.resolvedDependencies))
+		for _, dependency := range *p.resolvedDependencies {
+			dependencies = append(dependencies, Dependency{URI: redactURI(dependency.URI), Digest: dependency.Digest})
+		}
+		WithResolvedDependencies(dependencies)(&redacted)
+	}
+	return redacted
+}
+
+// redactParameters returns a copy of the given parameters with the values of
+// all parameters that are not public replaced by Redacted.
+func redactParameters(parameters map[string]string, public map[string]bool) map[string]string {
+	redacted := make(map[string]string, len(parameters))
+	for name, value := range parameters {
+		if public[name] {
+			redacted[name] = value
+		} else {
+			redacted[name] = Redacted
+		}
+	}
+	return redacted
+}
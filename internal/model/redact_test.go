@@ -0,0 +1,77 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRedactForLogging(t *testing.T) {
+	provenance := NewProvenanceIR("d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc", "build_type", "binary",
+		WithRepoURI("git+https://git.internal.example.com/project"),
+		WithCommitSHA1Digest("1b128fb2556e4bdcc4f92552654bfbca9d2fb8c6"),
+		WithTrustedBuilder("https://github.com/slsa-framework/slsa-github-generator"),
+		WithInvocationParameters(map[string]string{"token": "secret", "target": "release"}),
+		WithResolvedDependencies([]Dependency{
+			{URI: "https://git.internal.example.com/dependency", Digest: map[string]string{"sha1": "aa"}},
+			{URI: "docker://builder", Digest: map[string]string{"sha256": "bb"}},
+		}),
+	)
+
+	got := RedactForLogging(*provenance,
+		WithInternalURIPrefixes("https://git.internal.example.com/", "git+https://git.internal.example.com/"),
+		WithPublicParameters("target"))
+
+	want := NewProvenanceIR("d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc", "build_type", "binary",
+		WithRepoURI(Redacted),
+		WithCommitSHA1Digest("1b128fb2556e4bdcc4f92552654bfbca9d2fb8c6"),
+		WithTrustedBuilder("https://github.com/slsa-framework/slsa-github-generator"),
+		WithInvocationParameters(map[string]string{"token": Redacted, "target": "release"}),
+		WithResolvedDependencies([]Dependency{
+			{URI: Redacted, Digest: map[string]string{"sha1": "aa"}},
+			{URI: "docker://builder", Digest: map[string]string{"sha256": "bb"}},
+		}),
+	)
+	if diff := cmp.Diff(got, *want, cmp.AllowUnexported(ProvenanceIR{})); diff != "" {
+		t.Errorf("unexpected redacted provenance: %s", diff)
+	}
+
+	// The original provenance is not modified.
+	params, _ := provenance.InvocationParameters()
+	if params["token"] != "secret" || provenance.RepoURI() != "git+https://git.internal.example.com/project" {
+		t.Errorf("expected the original provenance to be unmodified")
+	}
+}
+
+func TestRedactForLogging_Defaults(t *testing.T) {
+	provenance := NewProvenanceIR("digest", "build_type", "binary",
+		WithRepoURI("git+https://git.internal.example.com/project"),
+		WithInvocationParameters(map[string]string{"target": "release"}))
+
+	got := RedactForLogging(*provenance)
+
+	params, _ := got.InvocationParameters()
+	if params["target"] != Redacted {
+		t.Errorf("got parameter value %q, want %q", params["target"], Redacted)
+	}
+	if got.RepoURI() != provenance.RepoURI() {
+		t.Errorf("got repository URI %q, want %q", got.RepoURI(), provenance.RepoURI())
+	}
+	if got.HasTrustedBuilder() {
+		t.Errorf("expected the trusted builder to remain unset")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/project-oak/transparent-release/pkg/cyclonedx"
+)
+
+const (
+	// SPDXFormat identifies SBOMs in the SPDX JSON format.
+	SPDXFormat = "SPDX"
+	// CycloneDXFormat identifies SBOMs in the CycloneDX JSON format.
+	CycloneDXFormat = cyclonedx.BOMFormat
+)
+
+// SBOM is a format-agnostic partial representation of a software bill of
+// materials, containing only the information relevant to transparent release.
+type SBOM struct {
+	// Format is the format the SBOM was parsed from, either SPDXFormat or
+	// CycloneDXFormat.
+	Format string
+	// ProvenanceURIs lists the URIs of provenances referenced in the SBOM,
+	// without duplicates, in the order they appear in.
+	ProvenanceURIs []string
+	// Components lists the components of the SBOM that have digests, i.e.,
+	// SPDX packages with checksums, or CycloneDX components with hashes, in
+	// the order they appear in.
+	Components []SBOMComponent
+}
+
+// SBOMComponent is a component listed in an SBOM.
+type SBOMComponent struct {
+	Name string
+	// Digest maps in-toto names of digest algorithms, e.g., "sha256", to
+	// lowercase hex-encoded digests.
+	Digest map[string]string
+}
+
+// FindComponent returns the first component with digests matching the given
+// digests, i.e., with at least one algorithm in common, and equal digests for
+// all algorithms in common. Algorithm names are normalized as in SBOMs, e.g.,
+// "SHA-256" and "sha256" are the same algorithm.
+func (s *SBOM) FindComponent(digest map[string]string) (SBOMComponent, bool) {
+	normalized := normalizeSBOMDigests(digest)
+	for _, component := range s.Components {
+		common := 0
+		matches := true
+		for alg, value := range normalized {
+			if componentValue, found := component.Digest[alg]; found {
+				common++
+				matches = matches && componentValue == value
+			}
+		}
+		if common > 0 && matches {
+			return component, true
+		}
+	}
+	return SBOMComponent{}, false
+}
+
+// spdxDocument is a partial representation of an SPDX 2.x JSON document.
+// See https://spdx.github.io/spdx-spec/v2.3/.
+type spdxDocument struct {
+	SPDXVersion string        `json:"spdxVersion"`
+	Packages    []spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name         string            `json:"name"`
+	Checksums    []spdxChecksum    `json:"checksums"`
+	ExternalRefs []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// spdxProvenanceReferenceTypes are the SPDX external reference types that
+// denote a provenance. SPDX 2.3 has no dedicated type, so these are used with
+// the OTHER reference category.
+//
+//nolint:gochecknoglobals
+var spdxProvenanceReferenceTypes = map[string]bool{
+	"provenance":      true,
+	"slsa-provenance": true,
+}
+
+// ParseSBOM parses the given bytes as an SBOM, either in the SPDX or the
+// CycloneDX JSON format. Returns an error if the format cannot be detected.
+func ParseSBOM(bytes []byte) (*SBOM, error) {
+	var header struct {
+		SPDXVersion string `json:"spdxVersion"`
+		BOMFormat   string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(bytes, &header); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the SBOM: %v", err)
+	}
+
+	switch {
+	case header.SPDXVersion != "":
+		return parseSPDX(bytes)
+	case header.BOMFormat == CycloneDXFormat:
+		return parseCycloneDX(bytes)
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format: neither SPDX nor CycloneDX")
+	}
+}
+
+func parseSPDX(bytes []byte) (*SBOM, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(bytes, &doc); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the SPDX document: %v", err)
+	}
+
+	sbom := &SBOM{Format: SPDXFormat}
+	for _, pkg := range doc.Packages {
+		digest := make(map[string]string, len(pkg.Checksums))
+		for _, checksum := range pkg.Checksums {
+			digest[checksum.Algorithm] = checksum.ChecksumValue
+		}
+		sbom.addComponent(pkg.Name, digest)
+		for _, ref := range pkg.ExternalRefs {
+			if spdxProvenanceReferenceTypes[strings.ToLower(ref.ReferenceType)] {
+				sbom.addProvenanceURI(ref.ReferenceLocator)
+			}
+		}
+	}
+	return sbom, nil
+}
+
+func parseCycloneDX(bytes []byte) (*SBOM, error) {
+	var bom cyclonedx.BOM
+	if err := json.Unmarshal(bytes, &bom); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the CycloneDX BOM: %v", err)
+	}
+
+	sbom := &SBOM{Format: CycloneDXFormat}
+	sbom.addCycloneDXReferences(bom.ExternalReferences)
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		sbom.addCycloneDXComponent(*bom.Metadata.Component)
+	}
+	for _, component := range bom.Components {
+		sbom.addCycloneDXComponent(component)
+	}
+	return sbom, nil
+}
+
+func (s *SBOM) addCycloneDXComponent(component cyclonedx.Component) {
+	digest := make(map[string]string, len(component.Hashes))
+	for _, hash := range component.Hashes {
+		digest[hash.Alg] = hash.Content
+	}
+	s.addComponent(component.Name, digest)
+	s.addCycloneDXReferences(component.ExternalReferences)
+	for _, c := range component.Components {
+		s.addCycloneDXComponent(c)
+	}
+}
+
+func (s *SBOM) addCycloneDXReferences(refs []cyclonedx.ExternalReference) {
+	for _, ref := range refs {
+		if ref.Type == cyclonedx.AttestationReferenceType {
+			s.addProvenanceURI(ref.URL)
+		}
+	}
+}
+
+func (s *SBOM) addProvenanceURI(uri string) {
+	if uri == "" {
+		return
+	}
+	for _, u := range s.ProvenanceURIs {
+		if u == uri {
+			return
+		}
+	}
+	s.ProvenanceURIs = append(s.ProvenanceURIs, uri)
+}
+
+func (s *SBOM) addComponent(name string, digest map[string]string) {
+	normalized := normalizeSBOMDigests(digest)
+	if len(normalized) == 0 {
+		return
+	}
+	s.Components = append(s.Components, SBOMComponent{Name: name, Digest: normalized})
+}
+
+// normalizeSBOMDigests maps the names of digest algorithms used in SBOMs,
+// e.g., "SHA256" in SPDX or "SHA-256" in CycloneDX, to in-toto names, e.g.,
+// "sha256", and the digests to lowercase. Empty digests are dropped.
+// CycloneDX names are mapped with cyclonedx.DigestAlgorithm, and other names
+// are lowercased, which maps SPDX names to in-toto names.
+func normalizeSBOMDigests(digest map[string]string) map[string]string {
+	normalized := make(map[string]string, len(digest))
+	for alg, value := range digest {
+		if value == "" {
+			continue
+		}
+		if intotoAlg, found := cyclonedx.DigestAlgorithm(alg); found {
+			alg = intotoAlg
+		} else {
+			alg = strings.ToLower(alg)
+		}
+		normalized[alg] = strings.ToLower(value)
+	}
+	return normalized
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSBOM_SPDX(t *testing.T) {
+	sbom := parseSBOMFile(t, "sbom_spdx.json")
+
+	want := &SBOM{
+		Format:         SPDXFormat,
+		ProvenanceURIs: []string{"https://example.com/provenances/oak_functions_freestanding_bin.json"},
+		Components: []SBOMComponent{{
+			Name:   "oak_functions_freestanding_bin",
+			Digest: map[string]string{"sha256": "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"},
+		}},
+	}
+	if diff := cmp.Diff(sbom, want); diff != "" {
+		t.Errorf("unexpected SBOM: %s", diff)
+	}
+}
+
+func TestParseSBOM_CycloneDX(t *testing.T) {
+	sbom := parseSBOMFile(t, "sbom_cyclonedx.json")
+
+	want := &SBOM{
+		Format: CycloneDXFormat,
+		ProvenanceURIs: []string{
+			"https://example.com/provenances/oak_functions_freestanding_bin.json",
+			"https://example.com/provenances/oak_restricted_kernel.json",
+		},
+		Components: []SBOMComponent{{
+			Name:   "oak_functions_freestanding_bin",
+			Digest: map[string]string{"sha256": "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"},
+		}},
+	}
+	if diff := cmp.Diff(sbom, want); diff != "" {
+		t.Errorf("unexpected SBOM: %s", diff)
+	}
+}
+
+func TestSBOM_FindComponent(t *testing.T) {
+	sbom := &SBOM{Components: []SBOMComponent{
+		{Name: "a", Digest: map[string]string{"sha256": "aa", "sha512": "a5"}},
+		{Name: "b", Digest: map[string]string{"sha1": "bb"}},
+	}}
+
+	tests := []struct {
+		name   string
+		digest map[string]string
+		want   string
+	}{
+		{name: "single algorithm", digest: map[string]string{"sha256": "AA"}, want: "a"},
+		{name: "SBOM algorithm name", digest: map[string]string{"SHA-1": "bb"}, want: "b"},
+		{name: "all common algorithms", digest: map[string]string{"sha256": "aa", "sha512": "a5"}, want: "a"},
+		{name: "one common algorithm differs", digest: map[string]string{"sha256": "aa", "sha512": "bb"}},
+		{name: "no common algorithm", digest: map[string]string{"sha384": "aa"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, found := sbom.FindComponent(tt.digest)
+			if found != (tt.want != "") || component.Name != tt.want {
+				t.Errorf("got component %q (found: %t), want %q", component.Name, found, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSBOM_UnknownFormat(t *testing.T) {
+	if _, err := ParseSBOM([]byte(`{"predicateType": "https://slsa.dev/provenance/v0.2"}`)); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func parseSBOMFile(t *testing.T, name string) *SBOM {
+	bytes, err := os.ReadFile(filepath.Join(testdataPath, name))
+	if err != nil {
+		t.Fatalf("could not read the SBOM file: %v", err)
+	}
+	sbom, err := ParseSBOM(bytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the SBOM file: %v", err)
+	}
+	return sbom
+}
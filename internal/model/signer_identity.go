@@ -0,0 +1,74 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+)
+
+// Object identifiers of the Fulcio certificate extensions holding the OIDC
+// issuer. See https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md.
+//
+//nolint:gochecknoglobals
+var (
+	// Deprecated extension, holding the issuer as raw bytes.
+	oidFulcioIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// Extension holding the issuer as a DER-encoded UTF8String.
+	oidFulcioIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// SignerIdentity is the identity bound to a keyless signing certificate
+// issued by Fulcio.
+type SignerIdentity struct {
+	// SubjectAlternativeName is the URI or email SAN of the certificate. For
+	// GitHub Actions, this is the URI of the workflow that signed, e.g.,
+	// "https://github.com/<org>/<repo>/.github/workflows/<file>@<ref>".
+	SubjectAlternativeName string
+	// OIDCIssuer is the issuer of the OIDC token the certificate was issued
+	// for, e.g., "https://token.actions.githubusercontent.com".
+	OIDCIssuer string
+}
+
+// SignerIdentityFromCertificate extracts the identity bound to the given
+// Fulcio certificate. Returns an error if the certificate has no URI or email
+// SAN, or no OIDC issuer extension.
+func SignerIdentityFromCertificate(cert *x509.Certificate) (*SignerIdentity, error) {
+	var identity SignerIdentity
+	if len(cert.URIs) > 0 {
+		identity.SubjectAlternativeName = cert.URIs[0].String()
+	} else if len(cert.EmailAddresses) > 0 {
+		identity.SubjectAlternativeName = cert.EmailAddresses[0]
+	} else {
+		return nil, fmt.Errorf("the certificate has no URI or email SAN")
+	}
+
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidFulcioIssuerV2) {
+			if _, err := asn1.UnmarshalWithParams(ext.Value, &identity.OIDCIssuer, "utf8"); err != nil {
+				return nil, fmt.Errorf("parse the OIDC issuer extension: %w", err)
+			}
+			break
+		}
+		if ext.Id.Equal(oidFulcioIssuer) {
+			identity.OIDCIssuer = string(ext.Value)
+		}
+	}
+	if identity.OIDCIssuer == "" {
+		return nil, fmt.Errorf("the certificate has no OIDC issuer extension")
+	}
+	return &identity, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+const (
+	workflowSAN         = "https://github.com/project-oak/oak/.github/workflows/provenance.yaml@refs/heads/main"
+	githubOIDCIssuer    = "https://token.actions.githubusercontent.com"
+	certificateLifetime = 10 * time.Minute
+)
+
+// newFulcioCertificate returns a self-signed certificate with the given URI
+// SAN and OIDC issuer extensions, like the ones issued by Fulcio.
+func newFulcioCertificate(t *testing.T, san string, extensions ...pkix.Extension) *x509.Certificate {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		NotBefore:       time.Now(),
+		NotAfter:        time.Now().Add(certificateLifetime),
+		ExtraExtensions: extensions,
+	}
+	if san != "" {
+		uri, err := url.Parse(san)
+		if err != nil {
+			t.Fatalf("Could not parse SAN: %v", err)
+		}
+		template.URIs = []*url.URL{uri}
+	}
+	der, err := x509.CreateCertificate(nil, template, template, public, private)
+	if err != nil {
+		t.Fatalf("Could not create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Could not parse certificate: %v", err)
+	}
+	return cert
+}
+
+func issuerV2Extension(t *testing.T, issuer string) pkix.Extension {
+	value, err := asn1.MarshalWithParams(issuer, "utf8")
+	if err != nil {
+		t.Fatalf("Could not marshal issuer: %v", err)
+	}
+	return pkix.Extension{Id: oidFulcioIssuerV2, Value: value}
+}
+
+func TestSignerIdentityFromCertificate(t *testing.T) {
+	tests := map[string]*x509.Certificate{
+		"issuer v2":     newFulcioCertificate(t, workflowSAN, issuerV2Extension(t, githubOIDCIssuer)),
+		"legacy issuer": newFulcioCertificate(t, workflowSAN, pkix.Extension{Id: oidFulcioIssuer, Value: []byte(githubOIDCIssuer)}),
+		"both issuers": newFulcioCertificate(t, workflowSAN,
+			pkix.Extension{Id: oidFulcioIssuer, Value: []byte("https://legacy.example.com")},
+			issuerV2Extension(t, githubOIDCIssuer)),
+	}
+	for name, cert := range tests {
+		t.Run(name, func(t *testing.T) {
+			identity, err := SignerIdentityFromCertificate(cert)
+			if err != nil {
+				t.Fatalf("Could not extract the signer identity: %v", err)
+			}
+			testutil.AssertEq(t, "SAN", identity.SubjectAlternativeName, workflowSAN)
+			testutil.AssertEq(t, "OIDC issuer", identity.OIDCIssuer, githubOIDCIssuer)
+		})
+	}
+
+	if _, err := SignerIdentityFromCertificate(newFulcioCertificate(t, "", issuerV2Extension(t, githubOIDCIssuer))); err == nil {
+		t.Errorf("expected failure without a SAN")
+	}
+	if _, err := SignerIdentityFromCertificate(newFulcioCertificate(t, workflowSAN)); err == nil {
+		t.Errorf("expected failure without an OIDC issuer")
+	}
+}
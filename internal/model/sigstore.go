@@ -0,0 +1,347 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"go.uber.org/multierr"
+)
+
+// SigstoreTrustRoot holds the certificates and keys that Sigstore bundles are
+// verified against, see WithSigstoreTrustRoot. For the public-good Sigstore
+// instance, they are distributed with TUF, see
+// https://github.com/sigstore/root-signing.
+type SigstoreTrustRoot struct {
+	// FulcioRoots are the trusted root certificates of Fulcio.
+	FulcioRoots []*x509.Certificate
+	// FulcioIntermediates are intermediate certificates of Fulcio, in addition
+	// to those included in the bundles.
+	FulcioIntermediates []*x509.Certificate
+	// RekorPublicKeys are the public keys of the trusted Rekor transparency
+	// logs.
+	RekorPublicKeys []crypto.PublicKey
+}
+
+// ParseSigstoreTrustRoot parses the given PEM-encoded Fulcio certificates and
+// Rekor public keys into a SigstoreTrustRoot. Self-signed Fulcio certificates
+// are used as roots, and all others as intermediates.
+func ParseSigstoreTrustRoot(fulcioCertificatesPEM, rekorPublicKeysPEM []byte) (*SigstoreTrustRoot, error) {
+	var root SigstoreTrustRoot
+	for block, rest := pem.Decode(fulcioCertificatesPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse Fulcio certificate: %w", err)
+		}
+		if bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil {
+			root.FulcioRoots = append(root.FulcioRoots, cert)
+		} else {
+			root.FulcioIntermediates = append(root.FulcioIntermediates, cert)
+		}
+	}
+	for block, rest := pem.Decode(rekorPublicKeysPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse Rekor public key: %w", err)
+		}
+		root.RekorPublicKeys = append(root.RekorPublicKeys, key)
+	}
+	if len(root.FulcioRoots) == 0 {
+		return nil, fmt.Errorf("no Fulcio root certificate found")
+	}
+	if len(root.RekorPublicKeys) == 0 {
+		return nil, fmt.Errorf("no Rekor public key found")
+	}
+	return &root, nil
+}
+
+// verifiedBundle holds the data of a Sigstore bundle that has been verified
+// against a SigstoreTrustRoot.
+type verifiedBundle struct {
+	// certificate is the signing certificate, which chains to a trusted
+	// Fulcio root.
+	certificate *x509.Certificate
+	// integratedTime is the time the signature was integrated into a trusted
+	// transparency log.
+	integratedTime time.Time
+}
+
+// verify verifies the bundle against the given trust root: a signature of the
+// DSSE envelope must verify under the key of the signing certificate, an
+// entry of a trusted transparency log for the envelope and the certificate
+// must carry a valid signed entry timestamp (SET), the signature must have
+// been logged while the certificate was valid, and the certificate must chain
+// to a trusted Fulcio root at that time. Inclusion proofs are not checked, so
+// the SET is what attests to the entry.
+func (b *sigstoreBundle) verify(root *SigstoreTrustRoot) (*verifiedBundle, error) {
+	if len(root.FulcioRoots) == 0 {
+		return nil, fmt.Errorf("the trust root has no Fulcio root certificates")
+	}
+	certs, err := b.certificates()
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("the bundle contains no signing certificate")
+	}
+	cert := certs[0]
+
+	payload, err := b.DSSEEnvelope.DecodeB64Payload()
+	if err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+	if err := verifyEnvelopeSignature(b.DSSEEnvelope, payload, cert.PublicKey); err != nil {
+		return nil, err
+	}
+
+	integratedTime, err := b.verifyTlogEntries(root, payload, cert)
+	if err != nil {
+		return nil, err
+	}
+	if integratedTime.Before(cert.NotBefore) || integratedTime.After(cert.NotAfter) {
+		return nil, fmt.Errorf("the signature was logged at %v, outside the validity of the signing certificate (%v to %v)", integratedTime, cert.NotBefore, cert.NotAfter)
+	}
+
+	roots := x509.NewCertPool()
+	for _, c := range root.FulcioRoots {
+		roots.AddCert(c)
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range append(append([]*x509.Certificate{}, root.FulcioIntermediates...), certs[1:]...) {
+		intermediates.AddCert(c)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   integratedTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return nil, fmt.Errorf("the signing certificate does not chain to a trusted Fulcio root: %w", err)
+	}
+	return &verifiedBundle{certificate: cert, integratedTime: integratedTime}, nil
+}
+
+// verifyTlogEntries returns the integrated time of the first transparency log
+// entry of the bundle that passes verifyTlogEntry, or an error combining the
+// errors for all entries if none does.
+func (b *sigstoreBundle) verifyTlogEntries(root *SigstoreTrustRoot, payload []byte, cert *x509.Certificate) (time.Time, error) {
+	logKeys := make(map[string]crypto.PublicKey, len(root.RekorPublicKeys))
+	for _, key := range root.RekorPublicKeys {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("encode Rekor public key: %w", err)
+		}
+		logID := sha256.Sum256(der)
+		logKeys[hex.EncodeToString(logID[:])] = key
+	}
+
+	if b.VerificationMaterial == nil || len(b.VerificationMaterial.TlogEntries) == 0 {
+		return time.Time{}, fmt.Errorf("the bundle contains no transparency log entries")
+	}
+	var errs error
+	for i, entry := range b.VerificationMaterial.TlogEntries {
+		integratedTime, err := verifyTlogEntry(entry, logKeys, payload, cert)
+		if err == nil {
+			return integratedTime, nil
+		}
+		errs = multierr.Append(errs, fmt.Errorf("transparency log entry #%d: %w", i, err))
+	}
+	return time.Time{}, errs
+}
+
+// verifyTlogEntry verifies that the given entry is from one of the given logs,
+// keyed by hex-encoded log ID, that its SET is valid, and that its body
+// records the given payload and signing certificate. Returns the time the
+// entry was integrated into the log.
+func verifyTlogEntry(entry sigstoreTlogEntry, logKeys map[string]crypto.PublicKey, payload []byte, cert *x509.Certificate) (time.Time, error) {
+	keyID, err := base64.StdEncoding.DecodeString(entry.LogID.KeyID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode log ID: %w", err)
+	}
+	logID := hex.EncodeToString(keyID)
+	key, found := logKeys[logID]
+	if !found {
+		return time.Time{}, fmt.Errorf("the entry is from an untrusted log %s", logID)
+	}
+	if entry.InclusionPromise == nil || entry.InclusionPromise.SignedEntryTimestamp == "" {
+		return time.Time{}, fmt.Errorf("the entry has no signed entry timestamp")
+	}
+	logIndex, err := strconv.ParseInt(entry.LogIndex, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse log index %q: %w", entry.LogIndex, err)
+	}
+	seconds, err := strconv.ParseInt(entry.IntegratedTime, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse integrated time %q: %w", entry.IntegratedTime, err)
+	}
+
+	// The SET is signed over the canonical JSON encoding of these fields, in
+	// this order.
+	setPayload, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{entry.CanonicalizedBody, seconds, logID, logIndex})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("encode the signed entry timestamp payload: %w", err)
+	}
+	set, err := base64.StdEncoding.DecodeString(entry.InclusionPromise.SignedEntryTimestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode signed entry timestamp: %w", err)
+	}
+	if err := verifySignature(key, setPayload, set); err != nil {
+		return time.Time{}, fmt.Errorf("invalid signed entry timestamp: %w", err)
+	}
+
+	body, err := base64.StdEncoding.DecodeString(entry.CanonicalizedBody)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode entry body: %w", err)
+	}
+	if err := checkTlogEntryBody(body, payload, cert); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// checkTlogEntryBody checks that the given body of a transparency log entry,
+// e.g., of the "dsse" or "intoto" kind, records the SHA2-256 digest of the
+// given payload, and the given certificate as a base64-encoded PEM.
+func checkTlogEntryBody(body []byte, payload []byte, cert *x509.Certificate) error {
+	var content interface{}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return fmt.Errorf("unmarshal entry body: %w", err)
+	}
+	sum256 := sha256.Sum256(payload)
+	payloadDigest := hex.EncodeToString(sum256[:])
+	var hasPayload, hasCertificate bool
+	walkStrings(content, func(value string) {
+		hasPayload = hasPayload || strings.EqualFold(value, payloadDigest)
+		hasCertificate = hasCertificate || isEncodedCertificate(value, cert)
+	})
+	if !hasPayload {
+		return fmt.Errorf("the entry does not record the payload of the envelope")
+	}
+	if !hasCertificate {
+		return fmt.Errorf("the entry does not record the signing certificate")
+	}
+	return nil
+}
+
+// walkStrings calls the given function with every string in the given JSON
+// value.
+func walkStrings(value interface{}, f func(value string)) {
+	switch v := value.(type) {
+	case string:
+		f(v)
+	case []interface{}:
+		for _, element := range v {
+			walkStrings(element, f)
+		}
+	case map[string]interface{}:
+		for _, element := range v {
+			walkStrings(element, f)
+		}
+	}
+}
+
+// isEncodedCertificate reports whether the given string is the base64 encoding
+// of the given certificate in PEM format.
+func isEncodedCertificate(value string, cert *x509.Certificate) bool {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return false
+	}
+	block, _ := pem.Decode(decoded)
+	return block != nil && block.Type == "CERTIFICATE" && bytes.Equal(block.Bytes, cert.Raw)
+}
+
+// verifyEnvelopeSignature checks that one of the signatures of the given
+// envelope, with the given decoded payload, verifies under the given key.
+func verifyEnvelopeSignature(envelope *dsse.Envelope, payload []byte, key crypto.PublicKey) error {
+	pae := dsse.PAE(envelope.PayloadType, payload)
+	for _, s := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if verifySignature(key, pae, sig) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("none of the %d signatures of the envelope verifies under the key of the signing certificate", len(envelope.Signatures))
+}
+
+// errInvalidSignature is returned by verifySignature for invalid signatures.
+var errInvalidSignature = errors.New("invalid signature")
+
+// verifySignature verifies the given signature of the given message under the
+// given key: ECDSA with the hash matching the size of the curve, Ed25519, or
+// RSA PKCS #1 v1.5 or PSS with SHA2-256.
+func verifySignature(key crypto.PublicKey, message, sig []byte) error {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		var digest []byte
+		switch k.Curve.Params().BitSize {
+		case 384:
+			sum := sha512.Sum384(message)
+			digest = sum[:]
+		case 521:
+			sum := sha512.Sum512(message)
+			digest = sum[:]
+		default:
+			sum := sha256.Sum256(message)
+			digest = sum[:]
+		}
+		if !ecdsa.VerifyASN1(k, digest, sig) {
+			return errInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, message, sig) {
+			return errInvalidSignature
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) != nil && rsa.VerifyPSS(k, crypto.SHA256, digest[:], sig, nil) != nil {
+			return errInvalidSignature
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+	return nil
+}
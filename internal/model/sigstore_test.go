@@ -0,0 +1,298 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+	"github.com/project-oak/transparent-release/pkg/intoto"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// sigstoreFixture is a fake Sigstore instance, with a Fulcio CA and a Rekor
+// log.
+type sigstoreFixture struct {
+	caCert   *x509.Certificate
+	caKey    *ecdsa.PrivateKey
+	rekorKey *ecdsa.PrivateKey
+}
+
+func newSigstoreFixture(t *testing.T) *sigstoreFixture {
+	caKey := newECDSAKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	return &sigstoreFixture{
+		caCert:   createCertificate(t, template, template, &caKey.PublicKey, caKey),
+		caKey:    caKey,
+		rekorKey: newECDSAKey(t),
+	}
+}
+
+func (f *sigstoreFixture) trustRoot() *SigstoreTrustRoot {
+	return &SigstoreTrustRoot{
+		FulcioRoots:     []*x509.Certificate{f.caCert},
+		RekorPublicKeys: []crypto.PublicKey{&f.rekorKey.PublicKey},
+	}
+}
+
+// issue returns a signing certificate for the given SAN, issued by the Fulcio
+// CA of the fixture, or self-signed if selfSigned is set, and its key.
+func (f *sigstoreFixture) issue(t *testing.T, san string, selfSigned bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key := newECDSAKey(t)
+	uri, err := url.Parse(san)
+	if err != nil {
+		t.Fatalf("Could not parse SAN: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-time.Minute),
+		NotAfter:        time.Now().Add(certificateLifetime),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{uri},
+		ExtraExtensions: []pkix.Extension{issuerV2Extension(t, githubOIDCIssuer)},
+	}
+	if selfSigned {
+		return createCertificate(t, template, template, &key.PublicKey, key), key
+	}
+	return createCertificate(t, template, f.caCert, &key.PublicKey, f.caKey), key
+}
+
+// bundleSpec specifies how newSignedSigstoreBundle builds a bundle.
+type bundleSpec struct {
+	// cert is the signing certificate in the bundle.
+	cert *x509.Certificate
+	// signingKey signs the envelope.
+	signingKey *ecdsa.PrivateKey
+	// rekorKey signs the transparency log entry.
+	rekorKey *ecdsa.PrivateKey
+	// integratedTime is the time of the transparency log entry.
+	integratedTime time.Time
+	// loggedCert is the certificate recorded in the transparency log entry,
+	// cert if nil.
+	loggedCert *x509.Certificate
+}
+
+// newSignedSigstoreBundle returns a sigstore bundle wrapping the example
+// provenance in a DSSE envelope, signed and logged as given by the spec.
+func newSignedSigstoreBundle(t *testing.T, spec bundleSpec) []byte {
+	payload, err := os.ReadFile(provenanceExamplePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance file: %v", err)
+	}
+	sig := signECDSA(t, spec.signingKey, dsse.PAE(intoto.PayloadType, payload))
+
+	loggedCert := spec.loggedCert
+	if loggedCert == nil {
+		loggedCert = spec.cert
+	}
+	payloadDigest := sha256.Sum256(payload)
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "dsse",
+		"spec": map[string]interface{}{
+			"payloadHash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(payloadDigest[:])},
+			"signatures": []map[string]string{{
+				"signature": base64.StdEncoding.EncodeToString(sig),
+				"verifier":  base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: loggedCert.Raw})),
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Could not marshal the entry body: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&spec.rekorKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Could not marshal the Rekor key: %v", err)
+	}
+	logID := sha256.Sum256(der)
+	encodedBody := base64.StdEncoding.EncodeToString(body)
+	setPayload, err := json.Marshal(map[string]interface{}{
+		"body":           encodedBody,
+		"integratedTime": spec.integratedTime.Unix(),
+		"logID":          hex.EncodeToString(logID[:]),
+		"logIndex":       42,
+	})
+	if err != nil {
+		t.Fatalf("Could not marshal the signed entry timestamp payload: %v", err)
+	}
+
+	bundle := map[string]interface{}{
+		"dsseEnvelope": map[string]interface{}{
+			"payloadType": intoto.PayloadType,
+			"payload":     base64.StdEncoding.EncodeToString(payload),
+			"signatures":  []map[string]string{{"sig": base64.StdEncoding.EncodeToString(sig)}},
+		},
+		"verificationMaterial": map[string]interface{}{
+			"certificate": map[string]string{"rawBytes": base64.StdEncoding.EncodeToString(spec.cert.Raw)},
+			"tlogEntries": []map[string]interface{}{{
+				"logIndex":          "42",
+				"logId":             map[string]string{"keyId": base64.StdEncoding.EncodeToString(logID[:])},
+				"integratedTime":    strconv.FormatInt(spec.integratedTime.Unix(), 10),
+				"inclusionPromise":  map[string]string{"signedEntryTimestamp": base64.StdEncoding.EncodeToString(signECDSA(t, spec.rekorKey, setPayload))},
+				"canonicalizedBody": encodedBody,
+			}},
+		},
+	}
+	bytes, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("Could not marshal the sigstore bundle: %v", err)
+	}
+	return bytes
+}
+
+func TestParseEnvelope_VerifiedSigstoreBundle(t *testing.T) {
+	fixture := newSigstoreFixture(t)
+	cert, key := fixture.issue(t, workflowSAN, false)
+	integratedTime := time.Now().Truncate(time.Second).UTC()
+	bundle := newSignedSigstoreBundle(t, bundleSpec{cert: cert, signingKey: key, rekorKey: fixture.rekorKey, integratedTime: integratedTime})
+
+	validatedProvenance, err := ParseEnvelope(bundle, WithSigstoreTrustRoot(fixture.trustRoot()))
+	if err != nil {
+		t.Fatalf("Failed to parse the sigstore bundle: %v", err)
+	}
+	provenance, err := FromValidatedProvenance(validatedProvenance)
+	if err != nil {
+		t.Fatalf("Could not map provenance to ProvenanceIR: %v", err)
+	}
+	identity, err := provenance.SignerIdentity()
+	if err != nil {
+		t.Fatalf("Could not get the signer identity: %v", err)
+	}
+	testutil.AssertEq(t, "signer identity", identity, SignerIdentity{SubjectAlternativeName: workflowSAN, OIDCIssuer: githubOIDCIssuer})
+	signedOn, err := provenance.SignedOn()
+	if err != nil {
+		t.Fatalf("Could not get the signing time: %v", err)
+	}
+	testutil.AssertEq(t, "signedOn", signedOn, integratedTime)
+
+	// Without a trust root, the bundle is not verified and carries neither a
+	// signing time nor an identity.
+	validatedProvenance, err = ParseEnvelope(bundle)
+	if err != nil {
+		t.Fatalf("Failed to parse the sigstore bundle: %v", err)
+	}
+	provenance, err = FromValidatedProvenance(validatedProvenance)
+	if err != nil {
+		t.Fatalf("Could not map provenance to ProvenanceIR: %v", err)
+	}
+	testutil.AssertEq(t, "has signedOn", provenance.HasSignedOn(), false)
+	testutil.AssertEq(t, "has signer identity", provenance.HasSignerIdentity(), false)
+}
+
+func TestParseEnvelope_UnverifiedSigstoreBundle(t *testing.T) {
+	fixture := newSigstoreFixture(t)
+	cert, key := fixture.issue(t, workflowSAN, false)
+	selfSignedCert, selfSignedKey := fixture.issue(t, workflowSAN, true)
+	otherCert, _ := fixture.issue(t, workflowSAN, false)
+	tests := map[string]bundleSpec{
+		"self-signed certificate":          {cert: selfSignedCert, signingKey: selfSignedKey, rekorKey: fixture.rekorKey, integratedTime: time.Now()},
+		"envelope signed with another key": {cert: cert, signingKey: newECDSAKey(t), rekorKey: fixture.rekorKey, integratedTime: time.Now()},
+		"entry from an untrusted log":      {cert: cert, signingKey: key, rekorKey: newECDSAKey(t), integratedTime: time.Now()},
+		"entry for another certificate":    {cert: cert, signingKey: key, rekorKey: fixture.rekorKey, integratedTime: time.Now(), loggedCert: otherCert},
+		"logged after the certificate expired": {
+			cert: cert, signingKey: key, rekorKey: fixture.rekorKey, integratedTime: time.Now().Add(2 * certificateLifetime),
+		},
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle := newSignedSigstoreBundle(t, spec)
+			if _, err := ParseEnvelope(bundle, WithSigstoreTrustRoot(fixture.trustRoot())); err == nil {
+				t.Fatalf("expected failure verifying the sigstore bundle")
+			}
+		})
+	}
+}
+
+func TestParseSigstoreTrustRoot(t *testing.T) {
+	fixture := newSigstoreFixture(t)
+	intermediate, _ := fixture.issue(t, workflowSAN, false)
+	der, err := x509.MarshalPKIXPublicKey(&fixture.rekorKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Could not marshal the Rekor key: %v", err)
+	}
+	fulcioPEM := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: fixture.caCert.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.Raw})...)
+	rekorPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	root, err := ParseSigstoreTrustRoot(fulcioPEM, rekorPEM)
+	if err != nil {
+		t.Fatalf("Could not parse the trust root: %v", err)
+	}
+	testutil.AssertEq(t, "Fulcio roots", len(root.FulcioRoots), 1)
+	testutil.AssertEq(t, "Fulcio intermediates", len(root.FulcioIntermediates), 1)
+	testutil.AssertEq(t, "Rekor public keys", len(root.RekorPublicKeys), 1)
+
+	if _, err := ParseSigstoreTrustRoot(fulcioPEM, nil); err == nil {
+		t.Errorf("expected failure without Rekor public keys")
+	}
+	if _, err := ParseSigstoreTrustRoot(nil, rekorPEM); err == nil {
+		t.Errorf("expected failure without Fulcio roots")
+	}
+}
+
+func newECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err)
+	}
+	return key
+}
+
+func signECDSA(t *testing.T, key *ecdsa.PrivateKey, message []byte) []byte {
+	digest := sha256.Sum256(message)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("Could not sign: %v", err)
+	}
+	return sig
+}
+
+func createCertificate(t *testing.T, template, parent *x509.Certificate, public interface{}, private interface{}) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, public, private)
+	if err != nil {
+		t.Fatalf("Could not create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Could not parse certificate: %v", err)
+	}
+	return cert
+}
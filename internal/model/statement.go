@@ -0,0 +1,153 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/project-oak/transparent-release/pkg/intoto"
+	slsav1 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v1"
+)
+
+// ToStatement re-emits the given provenance as a SLSA v1 statement, so that
+// provenances are presented uniformly regardless of the version of their
+// original format. The fields are mapped as in the SLSA v0.2 to v1 migration:
+// invocation parameters become the external parameters, materials become
+// resolved dependencies, and the builder ID, the invocation ID, and the build
+// times go to the run details. The platform and the environment and external
+// parameters digests are recorded in the internal parameters, where
+// FromValidatedProvenance finds them. Provenances with the container-based
+// build type keep their build command, builder image, and source in the
+// external parameters of that build type; for all others, the source repo is
+// recorded as a resolved dependency.
+//
+// Data without a counterpart in SLSA v1, such as the reproducible and
+// completeness flags of SLSA v0.2, and data from outside the predicate, such
+// as signatures, are dropped. Returns an error if the provenance does not have
+// a builder ID, which SLSA v1 requires.
+func ToStatement(p *ProvenanceIR) (*intoto.Statement, error) {
+	builder, err := p.TrustedBuilder()
+	if err != nil {
+		return nil, fmt.Errorf("cannot map the provenance to SLSA v1: %v", err)
+	}
+
+	digests := intoto.DigestSet{"sha256": p.BinarySHA256Digest()}
+	if binaryDigests, err := p.BinaryDigests(); err == nil && len(binaryDigests) > 0 {
+		digests = intoto.DigestSet(binaryDigests)
+	}
+
+	predicate := slsav1.ProvenancePredicate{
+		BuildDefinition: slsav1.ProvenanceBuildDefinition{
+			BuildType:            p.BuildType(),
+			ExternalParameters:   externalParametersOf(p),
+			ResolvedDependencies: resolvedDependenciesOf(p),
+		},
+		RunDetails: slsav1.ProvenanceRunDetails{
+			Builder: slsav1.Builder{ID: builder},
+		},
+	}
+	if internalParameters := internalParametersOf(p); len(internalParameters) > 0 {
+		predicate.BuildDefinition.InternalParameters = internalParameters
+	}
+	if byproducts, err := p.Byproducts(); err == nil {
+		for _, b := range byproducts {
+			predicate.RunDetails.Byproducts = append(predicate.RunDetails.Byproducts,
+				slsav1.ResourceDescriptor{Name: b.Name, URI: b.URI, Digest: intoto.DigestSet(b.Digest)})
+		}
+	}
+	if invocationID, err := p.InvocationID(); err == nil {
+		predicate.RunDetails.BuildMetadata.InvocationID = invocationID
+	}
+	if startedOn, err := p.BuildStartedOn(); err == nil {
+		predicate.RunDetails.BuildMetadata.StartedOn = &startedOn
+	}
+	if finishedOn, err := p.BuildFinishedOn(); err == nil {
+		predicate.RunDetails.BuildMetadata.FinishedOn = &finishedOn
+	}
+
+	return &intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			Type:          intoto.StatementInTotoV01,
+			PredicateType: slsav1.PredicateSLSAProvenance,
+			Subject:       []intoto.Subject{{Name: p.BinaryName(), Digest: digests}},
+		},
+		Predicate: predicate,
+	}, nil
+}
+
+// externalParametersOf returns the SLSA v1 external parameters of the given
+// provenance: those of the container-based build type if it has that build
+// type, and its invocation parameters otherwise.
+func externalParametersOf(p *ProvenanceIR) interface{} {
+	if p.BuildType() == slsav1.DockerBasedBuildType {
+		var params slsav1.DockerBasedExternalParameters
+		if p.HasRepoURI() {
+			params.Source.URI = p.RepoURI()
+		}
+		if p.HasCommitSHA1Digest() {
+			params.Source.Digest = intoto.DigestSet{"sha1": p.CommitSHA1Digest()}
+		}
+		if digest, err := p.BuilderImageSHA256Digest(); err == nil {
+			params.BuilderImage.Digest = intoto.DigestSet{"sha256": digest}
+		}
+		if buildCmd, err := p.BuildCmd(); err == nil {
+			params.Config.Command = buildCmd
+		}
+		return params
+	}
+	// SLSA v1 requires the external parameters, so they are never omitted.
+	params := map[string]string{}
+	if invocationParameters, err := p.InvocationParameters(); err == nil {
+		params = invocationParameters
+	}
+	return params
+}
+
+// internalParametersOf returns the SLSA v1 internal parameters recording the
+// data of the given provenance that FromValidatedProvenance reads from them.
+func internalParametersOf(p *ProvenanceIR) map[string]interface{} {
+	params := make(map[string]interface{})
+	if platform, err := p.Platform(); err == nil {
+		params["platform"] = platform.String()
+	}
+	if digest, err := p.EnvironmentSHA256Digest(); err == nil {
+		params["baseImage"] = "sha256:" + digest
+	}
+	if digest, err := p.ExternalParametersSHA256Digest(); err == nil {
+		params[ExternalParametersDigestField] = "sha256:" + digest
+	}
+	return params
+}
+
+// resolvedDependenciesOf returns the SLSA v1 resolved dependencies of the
+// given provenance, with the source repo first unless it is already one of
+// them.
+func resolvedDependenciesOf(p *ProvenanceIR) []slsav1.ResourceDescriptor {
+	dependencies, _ := p.ResolvedDependencies()
+	var descriptors []slsav1.ResourceDescriptor
+	if p.HasRepoURI() && p.HasCommitSHA1Digest() && p.BuildType() != slsav1.DockerBasedBuildType {
+		found := false
+		for _, d := range dependencies {
+			found = found || d.URI == p.RepoURI()
+		}
+		if !found {
+			descriptors = append(descriptors, slsav1.ResourceDescriptor{URI: p.RepoURI(), Digest: intoto.DigestSet{"sha1": p.CommitSHA1Digest()}})
+		}
+	}
+	for _, d := range dependencies {
+		descriptors = append(descriptors, slsav1.ResourceDescriptor{URI: d.URI, Digest: intoto.DigestSet(d.Digest)})
+	}
+	return descriptors
+}
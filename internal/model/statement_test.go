@@ -0,0 +1,169 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/project-oak/transparent-release/internal/testutil"
+	slsav02 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
+	slsav1 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v1"
+)
+
+// roundTrip maps the given provenance to a SLSA v1 statement with ToStatement,
+// and back to ProvenanceIR.
+func roundTrip(t *testing.T, provenance *ProvenanceIR) (*ProvenanceIR, int) {
+	t.Helper()
+	statement, err := ToStatement(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map the provenance to a statement: %v", err)
+	}
+	testutil.AssertEq(t, "predicate type", statement.PredicateType, slsav1.PredicateSLSAProvenance)
+	statementBytes, err := json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("couldn't marshal the statement: %v", err)
+	}
+	validated, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the statement: %v", err)
+	}
+	got, err := FromValidatedProvenance(validated)
+	if err != nil {
+		t.Fatalf("couldn't map the statement to ProvenanceIR: %v", err)
+	}
+	return got, len(statementBytes)
+}
+
+func TestToStatement_Slsav02(t *testing.T) {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav02ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance file: %v", err)
+	}
+	provenanceIR, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+
+	got, size := roundTrip(t, provenanceIR)
+
+	// The reproducible and completeness flags have no counterpart in SLSA v1.
+	want := NewProvenanceIR("d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc",
+		slsav02.GenericSLSABuildType, "oak_functions_freestanding_bin",
+		WithRepoURI("git+https://github.com/project-oak/oak@refs/heads/main"),
+		WithCommitSHA1Digest("1b128fb2556e4bdcc4f92552654bfbca9d2fb8c6"),
+		WithTrustedBuilder("https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"),
+		WithInvocationID("3230206088-1"),
+		WithDocumentSize(size),
+		WithBinaryDigests(map[string]string{"sha256": "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"}),
+		WithResolvedDependencies([]Dependency{{
+			URI:    "git+https://github.com/project-oak/oak@refs/heads/main",
+			Digest: map[string]string{"sha1": "1b128fb2556e4bdcc4f92552654bfbca9d2fb8c6"},
+		}}),
+	)
+	if diff := cmp.Diff(got, want, cmp.AllowUnexported(ProvenanceIR{})); diff != "" {
+		t.Errorf("unexpected provenanceIR: %s", diff)
+	}
+}
+
+func TestToStatement_RoundTrip(t *testing.T) {
+	startedOn := time.Date(2023, 8, 28, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		buildType string
+		options   []func(p *ProvenanceIR)
+	}{
+		{
+			name:      "generic",
+			buildType: slsav02.GenericSLSABuildType,
+			options: []func(p *ProvenanceIR){
+				WithRepoURI("git+https://github.com/project-oak/oak"),
+				WithCommitSHA1Digest("6bac02b6b0442ed944f57b7cba9a5f1119863ca4"),
+				WithResolvedDependencies([]Dependency{
+					{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": "6bac02b6b0442ed944f57b7cba9a5f1119863ca4"}},
+					{URI: "https://example.com/toolchain.tar.gz", Digest: map[string]string{"sha256": "1234"}},
+				}),
+				WithByproducts([]Byproduct{{Name: "build.log", Digest: map[string]string{"sha256": "5678"}}}),
+				WithPlatform(Platform{OS: "linux", Architecture: "amd64"}),
+				WithEnvironmentSHA256Digest("abcd"),
+				WithExternalParametersSHA256Digest("ef01"),
+				WithInvocationID("https://example.com/runs/1"),
+				WithBuildStartedOn(startedOn),
+				WithBuildFinishedOn(startedOn.Add(time.Hour)),
+			},
+		},
+		{
+			name:      "container-based",
+			buildType: slsav1.DockerBasedBuildType,
+			options: []func(p *ProvenanceIR){
+				WithRepoURI("git+https://github.com/project-oak/oak"),
+				WithCommitSHA1Digest("6bac02b6b0442ed944f57b7cba9a5f1119863ca4"),
+				WithBuildCmd([]string{"cargo", "build", "--release"}),
+				WithBuilderImageSHA256Digest("51532c757d1008bbff696d053a1d05226f6387cf232aa80b6f9c13b0759ccea0"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]func(p *ProvenanceIR){
+				WithTrustedBuilder("https://example.com/builder"),
+				WithBinaryDigests(map[string]string{"sha256": wantTOMLDigest}),
+			}, tt.options...)
+			provenance := NewProvenanceIR(wantTOMLDigest, tt.buildType, "static.txt", options...)
+
+			got, size := roundTrip(t, provenance)
+
+			want := NewProvenanceIR(wantTOMLDigest, tt.buildType, "static.txt", append(options, WithDocumentSize(size))...)
+			if diff := cmp.Diff(got, want, cmp.AllowUnexported(ProvenanceIR{})); diff != "" {
+				t.Errorf("unexpected provenanceIR: %s", diff)
+			}
+		})
+	}
+}
+
+func TestToStatement_InvocationParameters(t *testing.T) {
+	// External parameters are specific to the build type, so they are not
+	// mapped back by FromValidatedProvenance.
+	parameters := map[string]string{"target": "release"}
+	provenance := NewProvenanceIR(wantTOMLDigest, slsav02.GenericSLSABuildType, "static.txt",
+		WithTrustedBuilder("https://example.com/builder"),
+		WithInvocationParameters(parameters))
+	statement, err := ToStatement(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map the provenance to a statement: %v", err)
+	}
+	predicate, ok := statement.Predicate.(slsav1.ProvenancePredicate)
+	if !ok {
+		t.Fatalf("unexpected predicate type %T", statement.Predicate)
+	}
+	if diff := cmp.Diff(predicate.BuildDefinition.ExternalParameters, parameters); diff != "" {
+		t.Errorf("unexpected external parameters: %s", diff)
+	}
+}
+
+func TestToStatement_NoBuilder(t *testing.T) {
+	provenance := NewProvenanceIR(wantTOMLDigest, slsav02.GenericSLSABuildType, "static.txt")
+	if _, err := ToStatement(provenance); err == nil {
+		t.Fatalf("expected failure mapping a provenance without a builder ID")
+	}
+}
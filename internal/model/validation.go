@@ -15,8 +15,12 @@
 package model
 
 import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/project-oak/transparent-release/pkg/intoto"
 	"github.com/secure-systems-lab/go-securesystemslib/dsse"
@@ -27,15 +31,63 @@ import (
 // See https://github.com/sigstore/protobuf-specs/blob/main/protos/sigstore_bundle.proto
 type sigstoreBundle struct {
 	// DSSEEnvelope is made public to allow unmarshalling
-	DSSEEnvelope *dsse.Envelope `json:"dsseEnvelope"`
+	DSSEEnvelope         *dsse.Envelope                `json:"dsseEnvelope"`
+	VerificationMaterial *sigstoreVerificationMaterial `json:"verificationMaterial,omitempty"`
+}
+
+// sigstoreVerificationMaterial is a partial representation of the
+// verification material in a Sigstore Bundle.
+type sigstoreVerificationMaterial struct {
+	X509CertificateChain *struct {
+		Certificates []sigstoreCertificate `json:"certificates"`
+	} `json:"x509CertificateChain,omitempty"`
+	Certificate *sigstoreCertificate `json:"certificate,omitempty"`
+	TlogEntries []sigstoreTlogEntry  `json:"tlogEntries,omitempty"`
+}
+
+// sigstoreTlogEntry is a partial representation of a transparency log entry
+// in a Sigstore Bundle. Bytes are base64-encoded, and int64 values are encoded
+// as strings.
+type sigstoreTlogEntry struct {
+	LogIndex string `json:"logIndex"`
+	LogID    struct {
+		KeyID string `json:"keyId"`
+	} `json:"logId"`
+	// IntegratedTime is a Unix timestamp.
+	IntegratedTime   string `json:"integratedTime"`
+	InclusionPromise *struct {
+		SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+	} `json:"inclusionPromise,omitempty"`
+	CanonicalizedBody string `json:"canonicalizedBody"`
+}
+
+// sigstoreCertificate contains a base64-encoded DER X.509 certificate.
+type sigstoreCertificate struct {
+	RawBytes string `json:"rawBytes"`
 }
 
 // ValidatedProvenance wraps an intoto.Statement representing a valid SLSA
 // provenance statement. A provenance statement is valid if it contains a
-// single subject, with a SHA2-256 hash.
+// single subject, with a SHA2-256 hash, or a directory tree hash if the
+// subject is a directory.
 type ValidatedProvenance struct {
 	// The field is private so that invalid instances cannot be created.
 	provenance intoto.Statement
+	// signedOn is the time the provenance was signed, if it was parsed from
+	// a Sigstore bundle that was verified against a Sigstore trust root. This
+	// is the time the signature was integrated into the transparency log.
+	signedOn *time.Time
+	// documentSize is the size in bytes of the raw document the provenance
+	// was parsed from, if known.
+	documentSize *int
+	// signerIdentity is the identity bound to the signing certificate, if
+	// the provenance was parsed from a Sigstore bundle with a certificate,
+	// and the bundle was verified against a Sigstore trust root.
+	signerIdentity *SignerIdentity
+	// signers are the identities of the signers whose signatures of the
+	// envelope were verified, see SignerIDs, if the provenance was parsed
+	// with signature verifiers.
+	signers []string
 }
 
 // FindBinarySHA256Digest looks for a "sha256" or "sha2-256" entry in the input
@@ -80,9 +132,13 @@ func (p *ValidatedProvenance) PredicateType() string {
 // GetProvenance returns a partial copy of the provenance statement wrapped in this instance.
 // The partial copy guarantees that the validity condition will not be violated.
 func (p *ValidatedProvenance) GetProvenance() intoto.Statement {
+	digest := make(intoto.DigestSet, len(p.provenance.Subject[0].Digest))
+	for alg, value := range p.provenance.Subject[0].Digest {
+		digest[alg] = value
+	}
 	subject := intoto.Subject{
 		Name:   p.provenance.Subject[0].Name,
-		Digest: intoto.DigestSet{"sha256": p.provenance.Subject[0].Digest["sha256"]},
+		Digest: digest,
 	}
 
 	statementHeader := intoto.StatementHeader{
@@ -98,42 +154,196 @@ func (p *ValidatedProvenance) GetProvenance() intoto.Statement {
 }
 
 // ParseStatementData validates that the given bytes represent a valid intoto
-// Statement containing a single subject and its SHA256 digest. Returns an
+// Statement containing a single subject with at least one digest, e.g., its
+// SHA256 digest, or its directory tree hash (see DirHashAlgorithm). Returns an
 // instance of ValidatedProvenance, or an error if the above checks fail.
+// Statements with several subjects can be parsed with
+// ParseStatementDataPerSubject.
 func ParseStatementData(statementBytes []byte) (*ValidatedProvenance, error) {
+	statement, err := parseStatement(statementBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(statement.Subject) != 1 {
+		return nil, fmt.Errorf("the provenance must have exactly one subject with at least one digest, got %d subjects", len(statement.Subject))
+	}
+
+	documentSize := len(statementBytes)
+	return &ValidatedProvenance{provenance: *statement, documentSize: &documentSize}, nil
+}
+
+// ParseStatementDataPerSubject works like ParseStatementData, but accepts
+// statements with one or more subjects, each with at least one digest, and
+// returns one ValidatedProvenance per subject, in the order of the subjects.
+// All returned instances share the predicate of the statement.
+func ParseStatementDataPerSubject(statementBytes []byte) ([]*ValidatedProvenance, error) {
+	statement, err := parseStatement(statementBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	documentSize := len(statementBytes)
+	provenances := make([]*ValidatedProvenance, 0, len(statement.Subject))
+	for _, subject := range statement.Subject {
+		provenance := *statement
+		provenance.Subject = []intoto.Subject{subject}
+		provenances = append(provenances, &ValidatedProvenance{provenance: provenance, documentSize: &documentSize})
+	}
+	return provenances, nil
+}
+
+// parseStatement unmarshals the given bytes into an intoto Statement, and
+// checks that it has at least one subject, and that each subject has at least
+// one digest.
+func parseStatement(statementBytes []byte) (*intoto.Statement, error) {
 	var statement intoto.Statement
 	if err := json.Unmarshal(statementBytes, &statement); err != nil {
 		return nil, fmt.Errorf("could not unmarshal the provenance file:\n%v", err)
 	}
 
-	if len(statement.Subject) != 1 || statement.Subject[0].Digest["sha256"] == "" {
-		return nil, fmt.Errorf("the provenance must have exactly one subject with a sha256 digest")
+	if len(statement.Subject) == 0 {
+		return nil, fmt.Errorf("the provenance must have at least one subject")
 	}
+	for i, subject := range statement.Subject {
+		if !hasDigest(subject.Digest) {
+			return nil, fmt.Errorf("subject %d (%q) of the provenance has no digest", i, subject.Name)
+		}
+	}
+	return &statement, nil
+}
+
+// hasDigest reports whether the given digest set contains a non-empty digest.
+func hasDigest(digestSet intoto.DigestSet) bool {
+	for _, digest := range digestSet {
+		if digest != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvelopeOptions configures the parsing of DSSE envelopes in ParseEnvelope.
+type EnvelopeOptions struct {
+	// AcceptedPayloadTypes lists the payload types accepted in addition to
+	// intoto.PayloadType.
+	AcceptedPayloadTypes []string
+	// SigstoreTrustRoot is the trust root that Sigstore bundles are verified
+	// against. If nil, bundles are not verified, and carry neither a signing
+	// time nor a signer identity.
+	SigstoreTrustRoot *SigstoreTrustRoot
+	// SignatureVerifiers are the verifiers that the signatures of envelopes
+	// are verified with, within DefaultSignatureVerificationLimits. If empty,
+	// signatures are not verified, and provenances have no signers.
+	SignatureVerifiers []dsse.Verifier
+}
+
+// WithAcceptedPayloadTypes makes ParseEnvelope accept envelopes with any of
+// the given payload types, in addition to intoto.PayloadType.
+func WithAcceptedPayloadTypes(payloadTypes ...string) func(o *EnvelopeOptions) {
+	return func(o *EnvelopeOptions) {
+		o.AcceptedPayloadTypes = append(o.AcceptedPayloadTypes, payloadTypes...)
+	}
+}
+
+// WithSigstoreTrustRoot makes ParseEnvelope verify Sigstore bundles against
+// the given trust root, and reject those that do not verify. Only the signing
+// times and the signer identities of verified bundles are recorded.
+func WithSigstoreTrustRoot(root *SigstoreTrustRoot) func(o *EnvelopeOptions) {
+	return func(o *EnvelopeOptions) {
+		o.SigstoreTrustRoot = root
+	}
+}
 
-	return &ValidatedProvenance{provenance: statement}, nil
+// WithSignatureVerifiers makes ParseEnvelope verify the signatures of
+// envelopes with the given verifiers, and reject envelopes none of whose
+// signatures verify. The identities of the verified signers are recorded, see
+// SignerIDs.
+func WithSignatureVerifiers(verifiers ...dsse.Verifier) func(o *EnvelopeOptions) {
+	return func(o *EnvelopeOptions) {
+		o.SignatureVerifiers = append(o.SignatureVerifiers, verifiers...)
+	}
 }
 
 // ParseEnvelope (1) parses the given bytes as a DSSE envelope; (2) if that is
-// successful, parses the envelope payload into an intoto.Statement; (3) if
-// that is successful, parses the statement into a ValidatedProvenance; (4) and
+// successful, checks that the payload type of the envelope is accepted; (3)
+// parses the envelope payload into an intoto.Statement; (4) if that is
+// successful, parses the statement into a ValidatedProvenance; (5) and
 // returns it if the operation is successful, or an error otherwise.
 // If step(1) fails, parses the given bytes into a Sigstore bundle, and if
 // successful, performs the rest of the steps with the envelope inside the
 // bundle. Returns with an error otherwise.
-func ParseEnvelope(bytes []byte) (*ValidatedProvenance, error) {
+// Only the in-toto payload type is accepted, unless additional payload types
+// are specified using WithAcceptedPayloadTypes.
+func ParseEnvelope(bytes []byte, options ...func(o *EnvelopeOptions)) (*ValidatedProvenance, error) {
+	provenances, err := parseEnvelope(bytes, func(payload []byte) ([]*ValidatedProvenance, error) {
+		vp, err := ParseStatementData(payload)
+		if err != nil {
+			return nil, err
+		}
+		return []*ValidatedProvenance{vp}, nil
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return provenances[0], nil
+}
+
+// ParseEnvelopePerSubject works like ParseEnvelope, but parses the payload
+// using ParseStatementDataPerSubject, and returns one ValidatedProvenance per
+// subject of the statement.
+func ParseEnvelopePerSubject(bytes []byte, options ...func(o *EnvelopeOptions)) ([]*ValidatedProvenance, error) {
+	return parseEnvelope(bytes, ParseStatementDataPerSubject, options...)
+}
+
+// parseEnvelope implements ParseEnvelope and ParseEnvelopePerSubject, using
+// the given function to parse the payload of the envelope.
+func parseEnvelope(bytes []byte, parsePayload func(payload []byte) ([]*ValidatedProvenance, error), options ...func(o *EnvelopeOptions)) ([]*ValidatedProvenance, error) {
+	var opts EnvelopeOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
 	var envelope dsse.Envelope
 	var errs error
 	if err := json.Unmarshal(bytes, &envelope); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("unmarshal bytes as a DSSE envelope: %w", err))
 	}
 
+	var signedOn *time.Time
+	var signerIdentity *SignerIdentity
 	if envelope.Payload == "" {
-		e, err := parseSigstoreBundle(bytes)
+		bundle, err := parseSigstoreBundle(bytes)
 		if err != nil {
 			errs = multierr.Append(errs, fmt.Errorf("parse bytes as a sigstore bundle: %w", err))
 			return nil, fmt.Errorf("getting the DSSE envelope: %w", errs)
 		}
-		envelope = *e
+		envelope = *bundle.DSSEEnvelope
+		// The signing time and the signer identity are only trusted if the
+		// bundle is verified.
+		if opts.SigstoreTrustRoot != nil {
+			verified, err := bundle.verify(opts.SigstoreTrustRoot)
+			if err != nil {
+				return nil, fmt.Errorf("verifying the sigstore bundle: %w", err)
+			}
+			signedOn = &verified.integratedTime
+			// Certificates without a Fulcio identity are not an error.
+			if identity, err := SignerIdentityFromCertificate(verified.certificate); err == nil {
+				signerIdentity = identity
+			}
+		}
+	}
+
+	if err := checkPayloadType(envelope.PayloadType, opts.AcceptedPayloadTypes); err != nil {
+		return nil, err
+	}
+
+	var signers []string
+	if len(opts.SignatureVerifiers) > 0 {
+		accepted, err := VerifyEnvelopeSignatures(context.Background(), &envelope, opts.SignatureVerifiers, DefaultSignatureVerificationLimits)
+		if err != nil {
+			return nil, fmt.Errorf("verifying the DSSE signatures: %w", err)
+		}
+		signers = SignerIDs(accepted)
 	}
 
 	payload, err := envelope.DecodeB64Payload()
@@ -141,22 +351,74 @@ func ParseEnvelope(bytes []byte) (*ValidatedProvenance, error) {
 		return nil, fmt.Errorf("decode payload: %w", err)
 	}
 
-	vp, err := ParseStatementData(payload)
+	provenances, err := parsePayload(payload)
 	if err != nil {
 		return nil, fmt.Errorf("parsing DSSE payload: %w", err)
 	}
+	documentSize := len(bytes)
+	for _, vp := range provenances {
+		vp.signedOn = signedOn
+		vp.signerIdentity = signerIdentity
+		vp.signers = signers
+		vp.documentSize = &documentSize
+	}
 
-	return vp, nil
+	return provenances, nil
+}
+
+// checkPayloadType returns an error if the given payload type is neither the
+// in-toto payload type nor among the accepted ones.
+func checkPayloadType(payloadType string, accepted []string) error {
+	if payloadType == intoto.PayloadType {
+		return nil
+	}
+	for _, a := range accepted {
+		if payloadType == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected DSSE payload type %q, want %q", payloadType, intoto.PayloadType)
 }
 
 // parseSigstoreBundle parses the given bytes into a Sigstore bundle, and
-// extracts the DSSE envelope from it.
+// checks that it contains a DSSE envelope.
 // See https://github.com/slsa-framework/slsa-verifier/blob/623cf20a23f3360549eafac6efe1a158960f15f9/verifiers/internal/gha/bundle.go#L64-L80
-func parseSigstoreBundle(bytes []byte) (*dsse.Envelope, error) {
+func parseSigstoreBundle(bytes []byte) (*sigstoreBundle, error) {
 	var bundle sigstoreBundle
 	if err := json.Unmarshal(bytes, &bundle); err != nil {
 		return nil, fmt.Errorf("unmarshal bytes as a sigstore bundle: %w", err)
 	}
+	if bundle.DSSEEnvelope == nil {
+		return nil, fmt.Errorf("the sigstore bundle does not contain a DSSE envelope")
+	}
 
-	return bundle.DSSEEnvelope, nil
+	return &bundle, nil
+}
+
+// certificates returns the certificates in the bundle, starting with the leaf
+// signing certificate.
+func (b *sigstoreBundle) certificates() ([]*x509.Certificate, error) {
+	material := b.VerificationMaterial
+	if material == nil {
+		return nil, nil
+	}
+	var encoded []sigstoreCertificate
+	if material.Certificate != nil {
+		encoded = append(encoded, *material.Certificate)
+	} else if material.X509CertificateChain != nil {
+		encoded = material.X509CertificateChain.Certificates
+	}
+	certs := make([]*x509.Certificate, 0, len(encoded))
+	for _, cert := range encoded {
+		der, err := base64.StdEncoding.DecodeString(cert.RawBytes)
+		if err != nil {
+			return nil, fmt.Errorf("decode certificate: %w", err)
+		}
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate: %w", err)
+		}
+		certs = append(certs, parsed)
+	}
+	return certs, nil
 }
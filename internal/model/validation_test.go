@@ -15,8 +15,15 @@
 package model
 
 import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/project-oak/transparent-release/internal/testutil"
 	slsa "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
@@ -51,3 +58,107 @@ func TestParseStatementData(t *testing.T) {
 	testutil.AssertEq(t, "subjectName", validatedProvenance.GetBinaryName(), "oak_functions_freestanding_bin")
 	testutil.AssertNonEmpty(t, "builderId", predicate.Builder.ID)
 }
+
+func TestParseEnvelope_SigstoreBundleUnverifiedSigningTime(t *testing.T) {
+	// Neither the integrated time nor the validity of the certificate is
+	// trusted unless the bundle is verified.
+	bundle := newSigstoreBundle(t, map[string]interface{}{
+		"certificate": map[string]string{"rawBytes": newCertificate(t, time.Now())},
+		"tlogEntries": []map[string]string{{"integratedTime": "1682942400"}},
+	})
+
+	validatedProvenance, err := ParseEnvelope(bundle)
+	if err != nil {
+		t.Fatalf("Failed to parse the sigstore bundle: %v", err)
+	}
+	provenance, err := FromValidatedProvenance(validatedProvenance)
+	if err != nil {
+		t.Fatalf("Could not map provenance to ProvenanceIR: %v", err)
+	}
+	testutil.AssertEq(t, "has signedOn", provenance.HasSignedOn(), false)
+}
+
+func TestParseEnvelope_InTotoPayloadType(t *testing.T) {
+	envelope := newEnvelope(t, "application/vnd.in-toto+json")
+
+	validatedProvenance, err := ParseEnvelope(envelope)
+	if err != nil {
+		t.Fatalf("Failed to parse the envelope: %v", err)
+	}
+	testutil.AssertEq(t, "subjectName", validatedProvenance.GetBinaryName(), "oak_functions_freestanding_bin")
+}
+
+func TestParseEnvelope_UnexpectedPayloadType(t *testing.T) {
+	envelope := newEnvelope(t, "application/json")
+
+	if _, err := ParseEnvelope(envelope); err == nil {
+		t.Fatalf("expected failure")
+	}
+
+	validatedProvenance, err := ParseEnvelope(envelope, WithAcceptedPayloadTypes("text/plain", "application/json"))
+	if err != nil {
+		t.Fatalf("Failed to parse the envelope with an accepted payload type: %v", err)
+	}
+	testutil.AssertEq(t, "subjectName", validatedProvenance.GetBinaryName(), "oak_functions_freestanding_bin")
+}
+
+// newEnvelope returns a DSSE envelope with the given payload type, wrapping
+// the example provenance.
+func newEnvelope(t *testing.T, payloadType string) []byte {
+	statementBytes, err := os.ReadFile(provenanceExamplePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance file: %v", err)
+	}
+	envelope := map[string]interface{}{
+		"payloadType": payloadType,
+		"payload":     base64.StdEncoding.EncodeToString(statementBytes),
+		"signatures":  []interface{}{},
+	}
+	bytes, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Could not marshal the envelope: %v", err)
+	}
+	return bytes
+}
+
+// newSigstoreBundle returns a sigstore bundle with the given verification
+// material, wrapping the example provenance in a DSSE envelope.
+func newSigstoreBundle(t *testing.T, verificationMaterial map[string]interface{}) []byte {
+	statementBytes, err := os.ReadFile(provenanceExamplePath)
+	if err != nil {
+		t.Fatalf("Could not read the provenance file: %v", err)
+	}
+	bundle := map[string]interface{}{
+		"dsseEnvelope": map[string]interface{}{
+			"payloadType": "application/vnd.in-toto+json",
+			"payload":     base64.StdEncoding.EncodeToString(statementBytes),
+			"signatures":  []interface{}{},
+		},
+		"verificationMaterial": verificationMaterial,
+	}
+	bytes, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("Could not marshal the sigstore bundle: %v", err)
+	}
+	return bytes
+}
+
+// newCertificate returns a base64-encoded, self-signed DER certificate that
+// is valid from the given time.
+func newCertificate(t *testing.T, notBefore time.Time) string {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Could not generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(10 * time.Minute),
+	}
+	der, err := x509.CreateCertificate(nil, template, template, public, private)
+	if err != nil {
+		t.Fatalf("Could not create certificate: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ValidityField is the name of the predicate field in which builders may
+// record the window in which the provenance is valid evidence, in the same
+// form as the validity of claims, i.e., a JSON object of the form
+// `{"notBefore": "<RFC 3339>", "notAfter": "<RFC 3339>"}`. Either bound may
+// be omitted.
+const ValidityField = "validity"
+
+// Validity is the validity window recorded in a provenance.
+type Validity struct {
+	// NotBefore is the time from which the provenance is valid, if bounded.
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	// NotAfter is the time from which the provenance is no longer valid, if
+	// bounded.
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+}
+
+// Contains reports whether the given time is within the validity window.
+func (v *Validity) Contains(t time.Time) bool {
+	if v.NotBefore != nil && t.Before(*v.NotBefore) {
+		return false
+	}
+	if v.NotAfter != nil && !t.Before(*v.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// extractValidity returns the validity window recorded in the given
+// predicate, or nil if the predicate records none.
+func extractValidity(predicate interface{}) (*Validity, error) {
+	fields, ok := predicate.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	value, found := fields[ValidityField]
+	if !found {
+		return nil, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the validity: %v", err)
+	}
+	var validity Validity
+	if err := json.Unmarshal(valueBytes, &validity); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the validity: %v", err)
+	}
+	if validity.NotBefore != nil && validity.NotAfter != nil && !validity.NotAfter.After(*validity.NotBefore) {
+		return nil, fmt.Errorf("notAfter (%v) is not after notBefore (%v)", *validity.NotAfter, *validity.NotBefore)
+	}
+	return &validity, nil
+}
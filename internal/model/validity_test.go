@@ -0,0 +1,87 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+)
+
+// provenanceWithValidity loads the SLSA v0.2 test provenance, sets the given
+// value as its validity, and parses the result.
+func provenanceWithValidity(t *testing.T, validity interface{}) *ValidatedProvenance {
+	statementBytes, err := os.ReadFile(filepath.Join(testdataPath, slsav02ProvenancePath))
+	if err != nil {
+		t.Fatalf("could not read the provenance file: %v", err)
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(statementBytes, &statement); err != nil {
+		t.Fatalf("could not unmarshal the provenance file: %v", err)
+	}
+	statement["predicate"].(map[string]interface{})[ValidityField] = validity
+	statementBytes, err = json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("could not marshal the provenance: %v", err)
+	}
+	provenance, err := ParseStatementData(statementBytes)
+	if err != nil {
+		t.Fatalf("couldn't parse the provenance: %v", err)
+	}
+	return provenance
+}
+
+func TestFromValidatedProvenance_Validity(t *testing.T) {
+	provenance := provenanceWithValidity(t, map[string]string{
+		"notBefore": "2023-06-01T00:00:00Z",
+		"notAfter":  "2023-07-01T00:00:00Z",
+	})
+	provenanceIR, err := FromValidatedProvenance(provenance)
+	if err != nil {
+		t.Fatalf("couldn't map provenance to ProvenanceIR: %v", err)
+	}
+	validity, err := provenanceIR.Validity()
+	if err != nil {
+		t.Fatalf("could not get the validity: %v", err)
+	}
+	testutil.AssertEq(t, "notBefore", *validity.NotBefore, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	testutil.AssertEq(t, "notAfter", *validity.NotAfter, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC))
+	testutil.AssertEq(t, "contains start", validity.Contains(*validity.NotBefore), true)
+	testutil.AssertEq(t, "contains end", validity.Contains(*validity.NotAfter), false)
+	testutil.AssertEq(t, "contains earlier", validity.Contains(validity.NotBefore.Add(-time.Second)), false)
+
+	if loadProvenanceIR(t, slsav02ProvenancePath).HasValidity() {
+		t.Errorf("unexpected validity in a provenance without one")
+	}
+}
+
+func TestFromValidatedProvenance_InvalidValidity(t *testing.T) {
+	tests := map[string]interface{}{
+		"not a timestamp": map[string]string{"notAfter": "tomorrow"},
+		"empty window":    map[string]string{"notBefore": "2023-07-01T00:00:00Z", "notAfter": "2023-06-01T00:00:00Z"},
+		"not an object":   "2023-07-01T00:00:00Z",
+	}
+	for name, validity := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := FromValidatedProvenance(provenanceWithValidity(t, validity)); err == nil {
+				t.Errorf("expected failure")
+			}
+		})
+	}
+}
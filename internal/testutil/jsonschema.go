@@ -0,0 +1,283 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ValidateJSONSchema validates the given JSON document against the given
+// JSON schema (draft 7). Only the validation keywords are supported, with
+// references within the schema, and the date-time and iri-reference formats.
+// Other keywords, such as titles and descriptions, are ignored.
+func ValidateJSONSchema(schema, document []byte) error {
+	var root, instance interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return fmt.Errorf("could not unmarshal the schema: %v", err)
+	}
+	if err := json.Unmarshal(document, &instance); err != nil {
+		return fmt.Errorf("could not unmarshal the document: %v", err)
+	}
+	v := schemaValidator{root: root}
+	return v.validate(root, instance, "$")
+}
+
+type schemaValidator struct {
+	root interface{}
+}
+
+func (v schemaValidator) validate(schema, instance interface{}, path string) error {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return fmt.Errorf("%s: not allowed", path)
+		}
+		return nil
+	case map[string]interface{}:
+		if ref, found := s["$ref"].(string); found {
+			// In draft 7, $ref overrides all other keywords.
+			target, err := v.resolve(ref)
+			if err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+			return v.validate(target, instance, path)
+		}
+		return v.validateObject(s, instance, path)
+	default:
+		return fmt.Errorf("%s: invalid schema of type %T", path, schema)
+	}
+}
+
+// resolve returns the subschema that the given reference, a JSON pointer
+// within the root schema, points to.
+func (v schemaValidator) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported reference %q", ref)
+	}
+	target := v.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := target.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference %q", ref)
+		}
+		if target, ok = object[token]; !ok {
+			return nil, fmt.Errorf("unresolvable reference %q", ref)
+		}
+	}
+	return target, nil
+}
+
+func (v schemaValidator) validateObject(s map[string]interface{}, instance interface{}, path string) error {
+	if t, found := s["type"]; found && !hasType(instance, t) {
+		return fmt.Errorf("%s: %v is not of type %v", path, instance, t)
+	}
+	if enum, found := s["enum"].([]interface{}); found {
+		if !contains(enum, instance) {
+			return fmt.Errorf("%s: %v is not one of %v", path, instance, enum)
+		}
+	}
+	if c, found := s["const"]; found && !reflect.DeepEqual(c, instance) {
+		return fmt.Errorf("%s: %v is not %v", path, instance, c)
+	}
+	if err := v.validateCombinators(s, instance, path); err != nil {
+		return err
+	}
+
+	switch i := instance.(type) {
+	case string:
+		return validateString(s, i, path)
+	case float64:
+		return validateNumber(s, i, path)
+	case []interface{}:
+		return v.validateArray(s, i, path)
+	case map[string]interface{}:
+		return v.validateProperties(s, i, path)
+	}
+	return nil
+}
+
+func (v schemaValidator) validateCombinators(s map[string]interface{}, instance interface{}, path string) error {
+	for _, sub := range asSlice(s["allOf"]) {
+		if err := v.validate(sub, instance, path); err != nil {
+			return err
+		}
+	}
+	if anyOf, found := s["anyOf"]; found && v.countValid(asSlice(anyOf), instance, path) == 0 {
+		return fmt.Errorf("%s: %v matches none of anyOf", path, instance)
+	}
+	if oneOf, found := s["oneOf"]; found {
+		if n := v.countValid(asSlice(oneOf), instance, path); n != 1 {
+			return fmt.Errorf("%s: %v matches %d schemas of oneOf, want 1", path, instance, n)
+		}
+	}
+	if not, found := s["not"]; found && v.validate(not, instance, path) == nil {
+		return fmt.Errorf("%s: %v matches the schema of not", path, instance)
+	}
+	return nil
+}
+
+func (v schemaValidator) countValid(schemas []interface{}, instance interface{}, path string) int {
+	count := 0
+	for _, sub := range schemas {
+		if v.validate(sub, instance, path) == nil {
+			count++
+		}
+	}
+	return count
+}
+
+func validateString(s map[string]interface{}, str string, path string) error {
+	length := float64(len([]rune(str)))
+	if min, found := s["minLength"].(float64); found && length < min {
+		return fmt.Errorf("%s: %q is shorter than %v", path, str, min)
+	}
+	if max, found := s["maxLength"].(float64); found && length > max {
+		return fmt.Errorf("%s: %q is longer than %v", path, str, max)
+	}
+	if pattern, found := s["pattern"].(string); found {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("%s: invalid pattern %q: %v", path, pattern, err)
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("%s: %q does not match %q", path, str, pattern)
+		}
+	}
+	switch s["format"] {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			return fmt.Errorf("%s: %q is not a date-time: %v", path, str, err)
+		}
+	case "iri-reference", "uri-reference":
+		if _, err := url.Parse(str); err != nil {
+			return fmt.Errorf("%s: %q is not a reference: %v", path, str, err)
+		}
+	}
+	return nil
+}
+
+func validateNumber(s map[string]interface{}, n float64, path string) error {
+	if min, found := s["minimum"].(float64); found && n < min {
+		return fmt.Errorf("%s: %v is less than %v", path, n, min)
+	}
+	if max, found := s["maximum"].(float64); found && n > max {
+		return fmt.Errorf("%s: %v is greater than %v", path, n, max)
+	}
+	return nil
+}
+
+func (v schemaValidator) validateArray(s map[string]interface{}, items []interface{}, path string) error {
+	if min, found := s["minItems"].(float64); found && float64(len(items)) < min {
+		return fmt.Errorf("%s: fewer than %v items", path, min)
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if reflect.DeepEqual(items[i], items[j]) {
+					return fmt.Errorf("%s: items %d and %d are equal", path, i, j)
+				}
+			}
+		}
+	}
+	if schema, found := s["items"]; found {
+		for i, item := range items {
+			if err := v.validate(schema, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v schemaValidator) validateProperties(s map[string]interface{}, object map[string]interface{}, path string) error {
+	for _, key := range asSlice(s["required"]) {
+		if _, found := object[key.(string)]; !found {
+			return fmt.Errorf("%s: missing required property %q", path, key)
+		}
+	}
+	properties, _ := s["properties"].(map[string]interface{})
+	for key, value := range object {
+		propertyPath := path + "." + key
+		if schema, found := properties[key]; found {
+			if err := v.validate(schema, value, propertyPath); err != nil {
+				return err
+			}
+		} else if additional, found := s["additionalProperties"]; found {
+			if err := v.validate(additional, value, propertyPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasType returns true if the given instance has the given JSON schema type,
+// or one of the given types.
+func hasType(instance interface{}, t interface{}) bool {
+	if types, ok := t.([]interface{}); ok {
+		for _, t := range types {
+			if hasType(instance, t) {
+				return true
+			}
+		}
+		return false
+	}
+	switch t {
+	case "null":
+		return instance == nil
+	case "boolean":
+		_, ok := instance.(bool)
+		return ok
+	case "number":
+		_, ok := instance.(float64)
+		return ok
+	case "integer":
+		n, ok := instance.(float64)
+		return ok && n == math.Trunc(n)
+	case "string":
+		_, ok := instance.(string)
+		return ok
+	case "array":
+		_, ok := instance.([]interface{})
+		return ok
+	case "object":
+		_, ok := instance.(map[string]interface{})
+		return ok
+	default:
+		return false
+	}
+}
+
+func contains(values []interface{}, instance interface{}) bool {
+	for _, value := range values {
+		if reflect.DeepEqual(value, instance) {
+			return true
+		}
+	}
+	return false
+}
+
+func asSlice(value interface{}) []interface{} {
+	values, _ := value.([]interface{})
+	return values
+}
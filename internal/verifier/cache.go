@@ -0,0 +1,172 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/project-oak/transparent-release/internal/model"
+	pb "github.com/project-oak/transparent-release/pkg/proto/oak/release"
+)
+
+// Cache remembers successful verifications, so that verifying the same set of
+// provenance documents against the same VerificationOptions again can be
+// skipped. Entries are keyed by the digests of the provenance documents and a
+// hash of the full VerificationOptions, so that any change in either the
+// provenances or the policy results in a cache miss. Failed verifications are
+// never cached, nor are verifications against time-dependent options, see
+// timeDependent. Expired entries are removed whenever an entry is added. A
+// Cache is safe for concurrent use.
+type Cache struct {
+	ttl time.Duration
+	// now returns the current time, and can be replaced in tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]time.Time
+	hits    int
+}
+
+// NewCache creates a new empty Cache, in which entries expire after the
+// given time-to-live.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]time.Time),
+	}
+}
+
+// Verify works like the package-level Verify, but returns immediately without
+// re-verifying if the same provenance documents have been successfully
+// verified against the same options within the time-to-live of the cache.
+// `documentDigests[i]` must be the digest of the document from which
+// `provenances[i]` was parsed.
+func (c *Cache) Verify(documentDigests []string, provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) error {
+	if len(documentDigests) != len(provenances) {
+		return fmt.Errorf("got %d document digests for %d provenances", len(documentDigests), len(provenances))
+	}
+	if timeDependent(verOpts) {
+		return Verify(provenances, verOpts)
+	}
+
+	key, err := cacheKey(documentDigests, verOpts)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	expiry, found := c.entries[key]
+	if found && c.now().Before(expiry) {
+		c.hits++
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	if err := Verify(provenances, verOpts); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	for k, expiry := range c.entries {
+		if !now.Before(expiry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = now.Add(c.ttl)
+	c.mu.Unlock()
+	return nil
+}
+
+// timeDependent returns true if the outcome of verifying against the given
+// options may change over time without the provenances or the options
+// changing, i.e., if the options check build times or validity windows
+// against the current time or a time bound. A pass on such options must not
+// be replayed from the cache after it would fail.
+func timeDependent(verOpts *pb.VerificationOptions) bool {
+	return verOpts.GetAllBuiltWithinDays() != nil ||
+		verOpts.GetNotOlderThan() != nil ||
+		(verOpts.GetAllWithinOwnValidity() != nil && verOpts.GetAllWithinOwnValidity().GetAtTimestamp() == "")
+}
+
+// Hits returns the number of verifications that were skipped because of a
+// cache hit.
+func (c *Cache) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits
+}
+
+// cacheKey computes the cache key from the given document digests and
+// options. Since the outcome of verification does not depend on the order of
+// provenances, neither does the key.
+func cacheKey(documentDigests []string, verOpts *pb.VerificationOptions) (string, error) {
+	digests := append([]string(nil), documentDigests...)
+	sort.Strings(digests)
+
+	hash, err := HashVerificationOptions(verOpts)
+	if err != nil {
+		return "", err
+	}
+	return hash + ":" + strings.Join(digests, ","), nil
+}
+
+// HashVerificationOptions returns the hex-encoded SHA2-256 digest of the
+// canonical form of the given options, see CanonicalVerificationOptions, for
+// use in cache keys and for recording which policy produced an endorsement.
+// Returns an error if the options cannot be encoded, which only happens if
+// they contain strings with invalid UTF-8.
+func HashVerificationOptions(verOpts *pb.VerificationOptions) (string, error) {
+	canonicalJSON, err := CanonicalVerificationOptions(verOpts)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(canonicalJSON)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// CanonicalVerificationOptions returns the canonical form of the given
+// options, which is the protojson encoding with the original field names,
+// object keys sorted, and without whitespace, so that semantically equal
+// options, e.g., with map entries inserted in a different order, have the same
+// canonical form. The options can be restored using protojson.Unmarshal.
+func CanonicalVerificationOptions(verOpts *pb.VerificationOptions) ([]byte, error) {
+	optsJSON, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(verOpts)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling VerificationOptions: %v", err)
+	}
+	// protojson deliberately produces unstable output, so re-encode it with
+	// encoding/json, which sorts object keys and omits whitespace.
+	var canonical interface{}
+	if err := json.Unmarshal(optsJSON, &canonical); err != nil {
+		return nil, fmt.Errorf("unmarshalling VerificationOptions JSON: %v", err)
+	}
+	canonicalJSON, err := json.Marshal(canonical)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling canonical VerificationOptions JSON: %v", err)
+	}
+	return canonicalJSON, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/project-oak/transparent-release/internal/model"
+	"github.com/project-oak/transparent-release/internal/testutil"
+	slsav02 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
+	pb "github.com/project-oak/transparent-release/pkg/proto/oak/release"
+)
+
+const documentDigest = "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"
+
+func TestCache_SecondVerifyIsHit(t *testing.T) {
+	cache := NewCache(time.Hour)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := cache.Verify([]string{documentDigest}, provenances, &verOpts); err != nil {
+			t.Fatalf("verify failed: %v", err)
+		}
+	}
+	testutil.AssertEq(t, "cache hits", cache.Hits(), 1)
+}
+
+func TestCache_ChangedOptionsIsMiss(t *testing.T) {
+	cache := NewCache(time.Hour)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+
+	if err := cache.Verify([]string{documentDigest}, provenances, &pb.VerificationOptions{}); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: binaryName + "other"},
+	}
+	if err := cache.Verify([]string{documentDigest}, provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "cache hits", cache.Hits(), 0)
+}
+
+func TestCache_ExpiredEntryIsMiss(t *testing.T) {
+	cache := NewCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+
+	if err := cache.Verify([]string{documentDigest}, provenances, &pb.VerificationOptions{}); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := cache.Verify([]string{documentDigest}, provenances, &pb.VerificationOptions{}); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	testutil.AssertEq(t, "cache hits", cache.Hits(), 0)
+}
+
+func TestCache_TimeDependentOptionsAreNotCached(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithBuildFinishedOn(time.Now()))
+	provenances := []model.ProvenanceIR{*provenance}
+	tests := map[string]*pb.VerificationOptions{
+		"all_built_within_days":                        {AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 1}},
+		"not_older_than":                               {NotOlderThan: &pb.VerifyNotOlderThan{PriorTimestamp: "2000-01-01T00:00:00Z"}},
+		"all_within_own_validity without at_timestamp": {AllWithinOwnValidity: &pb.VerifyAllWithinOwnValidity{}},
+	}
+	for name, verOpts := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache := NewCache(time.Hour)
+			for i := 0; i < 2; i++ {
+				// Failures are fine, as long as nothing is cached.
+				_ = cache.Verify([]string{documentDigest}, provenances, verOpts)
+			}
+			testutil.AssertEq(t, "cache hits", cache.Hits(), 0)
+			testutil.AssertEq(t, "cache entries", len(cache.entries), 0)
+		})
+	}
+}
+
+func TestCache_ExpiredEntriesAreSwept(t *testing.T) {
+	cache := NewCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+
+	if err := cache.Verify([]string{documentDigest}, provenances, &pb.VerificationOptions{}); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := cache.Verify([]string{binaryDigest}, provenances, &pb.VerificationOptions{}); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	testutil.AssertEq(t, "cache entries", len(cache.entries), 1)
+}
+
+func TestCache_InvalidOptions(t *testing.T) {
+	cache := NewCache(time.Hour)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: "\xff"},
+	}
+	err := cache.Verify([]string{documentDigest}, []model.ProvenanceIR{*provenance}, &verOpts)
+	if err == nil || !strings.Contains(err.Error(), "marshalling VerificationOptions") {
+		t.Errorf("expected the options not to be encodable, got %v", err)
+	}
+}
+
+func TestHashVerificationOptions(t *testing.T) {
+	verOpts, err := ParseVerificationOptions(`
+		all_with_binary_name { binary_name: "oak_functions_freestanding_bin" }
+		provenance_count_at_least { count: 1 }
+		severities { key: "all_with_binary_name" value: WARN }
+		severities { key: "provenance_count_at_least" value: ERROR }
+	`)
+	if err != nil {
+		t.Fatalf("could not parse options: %v", err)
+	}
+	equalOpts, err := ParseVerificationOptions(`
+		severities { key: "provenance_count_at_least" value: ERROR }
+		provenance_count_at_least { count: 1 }
+		severities { key: "all_with_binary_name" value: WARN }
+		all_with_binary_name { binary_name: "oak_functions_freestanding_bin" }
+	`)
+	if err != nil {
+		t.Fatalf("could not parse options: %v", err)
+	}
+	testutil.AssertEq(t, "hash of equal options", hashOptions(t, equalOpts), hashOptions(t, verOpts))
+
+	equalOpts.ProvenanceCountAtLeast.Count = 2
+	if hashOptions(t, equalOpts) == hashOptions(t, verOpts) {
+		t.Errorf("expected changed options to hash differently")
+	}
+}
+
+func TestHashVerificationOptions_InvalidUTF8(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: "\xff"},
+	}
+	if _, err := HashVerificationOptions(&verOpts); err == nil {
+		t.Errorf("expected failure")
+	}
+}
+
+func hashOptions(t *testing.T, verOpts *pb.VerificationOptions) string {
+	hash, err := HashVerificationOptions(verOpts)
+	if err != nil {
+		t.Fatalf("could not hash options: %v", err)
+	}
+	return hash
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReasonCode is a stable, machine-readable identifier for the kind of a
+// verification failure, suitable for aggregating failures without parsing
+// error messages.
+type ReasonCode string
+
+// Reason codes for verification failures. The values are stable and must not
+// be changed once released.
+const (
+	InvalidVerificationOptions       ReasonCode = "INVALID_VERIFICATION_OPTIONS"
+	ProvenanceCountTooLow            ReasonCode = "PROVENANCE_COUNT_TOO_LOW"
+	ProvenanceCountTooHigh           ReasonCode = "PROVENANCE_COUNT_TOO_HIGH"
+	BinaryNameInconsistent           ReasonCode = "BINARY_NAME_INCONSISTENT"
+	DigestInconsistent               ReasonCode = "DIGEST_INCONSISTENT"
+	BuildCommandMissing              ReasonCode = "BUILD_COMMAND_MISSING"
+	BinaryNameMismatch               ReasonCode = "BINARY_NAME_MISMATCH"
+	DigestMismatch                   ReasonCode = "DIGEST_MISMATCH"
+	RepositoryMismatch               ReasonCode = "REPOSITORY_MISMATCH"
+	BuilderNotTrusted                ReasonCode = "BUILDER_NOT_TRUSTED"
+	BuilderDigestMismatch            ReasonCode = "BUILDER_DIGEST_MISMATCH"
+	InvocationParameterMissing       ReasonCode = "INVOCATION_PARAMETER_MISSING"
+	InvocationParameterWrong         ReasonCode = "INVOCATION_PARAMETER_MISMATCH"
+	TimestampMissing                 ReasonCode = "TIMESTAMP_MISSING"
+	SignedTooLate                    ReasonCode = "SIGNED_TOO_LATE"
+	GeneratorNotTrusted              ReasonCode = "GENERATOR_NOT_TRUSTED"
+	GeneratorVersionInvalid          ReasonCode = "GENERATOR_VERSION_INVALID"
+	GeneratorVersionTooOld           ReasonCode = "GENERATOR_VERSION_TOO_OLD"
+	SourceIdentityMismatch           ReasonCode = "SOURCE_IDENTITY_MISMATCH"
+	NotReproducible                  ReasonCode = "NOT_REPRODUCIBLE"
+	DocumentTooLarge                 ReasonCode = "DOCUMENT_TOO_LARGE"
+	BuilderSignatureMissing          ReasonCode = "BUILDER_SIGNATURE_MISSING"
+	BuilderSignatureInvalid          ReasonCode = "BUILDER_SIGNATURE_INVALID"
+	TooFewSigners                    ReasonCode = "TOO_FEW_SIGNERS"
+	OCIAnnotationMissing             ReasonCode = "OCI_ANNOTATION_MISSING"
+	OCIAnnotationMismatch            ReasonCode = "OCI_ANNOTATION_MISMATCH"
+	ProvenanceNotFresh               ReasonCode = "PROVENANCE_NOT_FRESH"
+	DigestBlocklisted                ReasonCode = "DIGEST_BLOCKLISTED"
+	CISystemMismatch                 ReasonCode = "CI_SYSTEM_MISMATCH"
+	EmptyArtifact                    ReasonCode = "EMPTY_ARTIFACT"
+	DependenciesNotCanonical         ReasonCode = "DEPENDENCIES_NOT_CANONICAL"
+	PlatformMissing                  ReasonCode = "PLATFORM_MISSING"
+	PlatformMismatch                 ReasonCode = "PLATFORM_MISMATCH"
+	TooFewDependencies               ReasonCode = "TOO_FEW_DEPENDENCIES"
+	NotIndependentlyReproduced       ReasonCode = "NOT_INDEPENDENTLY_REPRODUCED"
+	EnvironmentDigestMissing         ReasonCode = "ENVIRONMENT_DIGEST_MISSING"
+	EnvironmentDigestMismatch        ReasonCode = "ENVIRONMENT_DIGEST_MISMATCH"
+	ImageReferenceMismatch           ReasonCode = "IMAGE_REFERENCE_MISMATCH"
+	SourceMaterialMissing            ReasonCode = "SOURCE_MATERIAL_MISSING"
+	BuildDurationOutOfRange          ReasonCode = "BUILD_DURATION_OUT_OF_RANGE"
+	InvocationIDMissing              ReasonCode = "INVOCATION_ID_MISSING"
+	MaterialsIncomplete              ReasonCode = "MATERIALS_INCOMPLETE"
+	PassthroughBuild                 ReasonCode = "PASSTHROUGH_BUILD"
+	ProvenanceTooOld                 ReasonCode = "PROVENANCE_TOO_OLD"
+	BuilderIdentityMissing           ReasonCode = "BUILDER_IDENTITY_MISSING"
+	BuilderOrgNotAllowed             ReasonCode = "BUILDER_ORG_NOT_ALLOWED"
+	ProvenanceNotYetValid            ReasonCode = "PROVENANCE_NOT_YET_VALID"
+	ProvenanceExpired                ReasonCode = "PROVENANCE_EXPIRED"
+	BuildTypeNotAllowed              ReasonCode = "BUILD_TYPE_NOT_ALLOWED"
+	BuildTypeDeprecated              ReasonCode = "BUILD_TYPE_DEPRECATED"
+	CommitSignatureMissing           ReasonCode = "COMMIT_SIGNATURE_MISSING"
+	CommitSignerNotTrusted           ReasonCode = "COMMIT_SIGNER_NOT_TRUSTED"
+	BuilderIDMissing                 ReasonCode = "BUILDER_ID_MISSING"
+	BuilderIDNotAllowed              ReasonCode = "BUILDER_ID_NOT_ALLOWED"
+	SourceRepoMissing                ReasonCode = "SOURCE_REPO_MISSING"
+	SourceRepoMismatch               ReasonCode = "SOURCE_REPO_MISMATCH"
+	ValidityMissing                  ReasonCode = "VALIDITY_MISSING"
+	ImageManifestDigestMismatch      ReasonCode = "IMAGE_MANIFEST_DIGEST_MISMATCH"
+	TooFewByproducts                 ReasonCode = "TOO_FEW_BYPRODUCTS"
+	ExternalParametersDigestMissing  ReasonCode = "EXTERNAL_PARAMETERS_DIGEST_MISSING"
+	ExternalParametersDigestMismatch ReasonCode = "EXTERNAL_PARAMETERS_DIGEST_MISMATCH"
+)
+
+// VerificationError is a single verification failure, carrying a reason code
+// in addition to a human-readable message. Verify returns a combination of
+// VerificationErrors, which can be inspected using ReasonCodes.
+type VerificationError struct {
+	Code ReasonCode
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *VerificationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// failure creates a new VerificationError with the given code, and a message
+// formatted as in fmt.Errorf.
+func failure(code ReasonCode, format string, args ...interface{}) error {
+	return &VerificationError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// ReasonCodes returns the reason codes of all verification failures contained
+// in the given error, in order. The error may be the result of Verify, or an
+// error wrapping it.
+func ReasonCodes(err error) []ReasonCode {
+	var codes []ReasonCode
+	var collect func(err error)
+	collect = func(err error) {
+		if err == nil {
+			return
+		}
+		if verificationErr, ok := err.(*VerificationError); ok {
+			codes = append(codes, verificationErr.Code)
+			return
+		}
+		if group, ok := err.(interface{ Errors() []error }); ok {
+			for _, e := range group.Errors() {
+				collect(e)
+			}
+			return
+		}
+		collect(errors.Unwrap(err))
+	}
+	collect(err)
+	return codes
+}
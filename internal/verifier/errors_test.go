@@ -0,0 +1,100 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/project-oak/transparent-release/internal/model"
+	slsav02 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
+	pb "github.com/project-oak/transparent-release/pkg/proto/oak/release"
+)
+
+func TestReasonCodes_EachFailureType(t *testing.T) {
+	buildTime := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithRepoURI(repoURI),
+		model.WithTrustedBuilder(builderName),
+		model.WithBuildFinishedOn(buildTime),
+		model.WithSignedOn(buildTime.AddDate(0, 0, 30)),
+		model.WithInvocationParameters(map[string]string{"target": "release"}),
+		model.WithDocumentSize(2048),
+	)
+	other := model.NewProvenanceIR(builderDigest, slsav02.GenericSLSABuildType, binaryName+"-other")
+	sha256Digest := func(digest string) []*pb.Digest {
+		return []*pb.Digest{{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): digest}}}
+	}
+
+	cases := []struct {
+		name        string
+		provenances []model.ProvenanceIR
+		verOpts     *pb.VerificationOptions
+		want        ReasonCode
+	}{
+		{"invalid options", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithBinaryNamePattern: &pb.VerifyAllWithBinaryNamePattern{Pattern: "("}}, InvalidVerificationOptions},
+		{"count at least", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 2}}, ProvenanceCountTooLow},
+		{"count at most", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{ProvenanceCountAtMost: &pb.VerifyProvenanceCountAtMost{Count: 0}}, ProvenanceCountTooHigh},
+		{"same binary name", []model.ProvenanceIR{*provenance, *other}, &pb.VerificationOptions{AllSameBinaryName: &pb.VerifyAllSameBinaryName{}}, BinaryNameInconsistent},
+		{"same binary digest", []model.ProvenanceIR{*provenance, *other}, &pb.VerificationOptions{AllSameBinaryDigest: &pb.VerifyAllSameBinaryDigest{}}, DigestInconsistent},
+		{"build command", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithBuildCommand: &pb.VerifyAllWithBuildCommand{}}, BuildCommandMissing},
+		{"binary name", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: "other"}}, BinaryNameMismatch},
+		{"binary digest", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{Digests: sha256Digest(builderDigest)}}, DigestMismatch},
+		{"repository", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithRepository: &pb.VerifyAllWithRepository{RepositoryUri: otherRepoURI}}, RepositoryMismatch},
+		{"builder name", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithBuilderNames: &pb.VerifyAllWithBuilderNames{BuilderNames: []string{"other"}}}, BuilderNotTrusted},
+		{"builder digest", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithBuilderDigests: &pb.VerifyAllWithBuilderDigests{Digests: sha256Digest(builderDigest)}}, BuilderDigestMismatch},
+		{"missing parameter", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithInvocationParameters: &pb.VerifyAllWithInvocationParameters{Parameters: map[string]string{"other": "x"}}}, InvocationParameterMissing},
+		{"wrong parameter", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithInvocationParameters: &pb.VerifyAllWithInvocationParameters{Parameters: map[string]string{"target": "debug"}}}, InvocationParameterWrong},
+		{"missing timestamp", []model.ProvenanceIR{*other}, &pb.VerificationOptions{AllSignedWithinDaysOfBuild: &pb.VerifyAllSignedWithinDaysOfBuild{Days: 1}}, TimestampMissing},
+		{"signed too late", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllSignedWithinDaysOfBuild: &pb.VerifyAllSignedWithinDaysOfBuild{Days: 1}}, SignedTooLate},
+		{"generator", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{TrustedGenerator: &pb.VerifyTrustedGenerator{MinimumVersions: map[string]string{"other": "v1.0.0"}}}, GeneratorNotTrusted},
+		{"generator version", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{TrustedGenerator: &pb.VerifyTrustedGenerator{MinimumVersions: map[string]string{genericGenerator: "v2.0.0"}}}, GeneratorVersionTooOld},
+		{"source identity", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{SourceIdentity: &pb.VerifySourceIdentity{Identities: []*pb.SourceIdentity{{RepositoryUri: repoURI, CommitSha1Digest: commitDigest}}}}, SourceIdentityMismatch},
+		{"reproducible", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllReproducible: &pb.VerifyAllReproducible{}}, NotReproducible},
+		{"document size", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithinDocumentSize: &pb.VerifyAllWithinDocumentSize{MaxBytes: 1024}}, DocumentTooLarge},
+		{"distinct signers", []model.ProvenanceIR{*provenance}, &pb.VerificationOptions{DistinctSigners: &pb.VerifyDistinctSigners{Count: 1}}, TooFewSigners},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Verify(tc.provenances, tc.verOpts)
+			if err == nil {
+				t.Fatalf("expected failure")
+			}
+			if diff := cmp.Diff(ReasonCodes(err), []ReasonCode{tc.want}); diff != "" {
+				t.Errorf("unexpected reason codes for %q: %s", err, diff)
+			}
+		})
+	}
+}
+
+func TestReasonCodes_MultipleWrappedFailures(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	verOpts := pb.VerificationOptions{
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 2},
+		AllWithBinaryName:      &pb.VerifyAllWithBinaryName{BinaryName: "other"},
+		AllWithRepository:      &pb.VerifyAllWithRepository{RepositoryUri: repoURI},
+	}
+
+	err := fmt.Errorf("failed to verify provenances: %w", Verify([]model.ProvenanceIR{*provenance}, &verOpts))
+
+	want := []ReasonCode{ProvenanceCountTooLow, BinaryNameMismatch, RepositoryMismatch}
+	if diff := cmp.Diff(ReasonCodes(err), want); diff != "" {
+		t.Errorf("unexpected reason codes: %s", diff)
+	}
+}
@@ -15,49 +15,360 @@
 package verifier
 
 import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/project-oak/transparent-release/internal/model"
 	pb "github.com/project-oak/transparent-release/pkg/proto/oak/release"
 	"go.uber.org/multierr"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // Verify checks that the provenance conforms to expectations, returning a
-// list of errors whenever the verification failed.
-//
-//nolint:cyclop,gocognit,gocyclo,maintidx
+// list of errors whenever the verification failed. Verification does not stop
+// at the first failure: the returned error combines, using multierr, the
+// failures of all verification steps for all provenances. Each failure is a
+// VerificationError carrying a ReasonCode, see ReasonCodes. Failures of
+// verification steps with severity WARN are ignored, use VerifyWithWarnings
+// to obtain them.
 func Verify(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) error {
+	_, err := VerifyWithWarnings(provenances, verOpts)
+	return err
+}
+
+// VerifyWithWarnings works like Verify, but in addition returns the failures
+// of verification steps with severity WARN as a list of warnings. These
+// failures never cause the verification to fail.
+func VerifyWithWarnings(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) ([]error, error) {
+	if provenances == nil {
+		panic(fmt.Errorf("provenances must not be nil"))
+	}
+	if err := ValidateVerificationOptions(verOpts); err != nil {
+		return nil, failure(InvalidVerificationOptions, "invalid verification options: %v", err)
+	}
+
+	errorOpts, warnOpts := splitBySeverity(verOpts)
+	warnings := deprecatedBuildTypeWarnings(provenances, verOpts.AllWithBuildType)
+	for _, opts := range warnOpts {
+		missingWarnings, err := verifyWithMissingWarnings(provenances, opts)
+		warnings = append(warnings, missingWarnings...)
+		warnings = append(warnings, multierr.Errors(err)...)
+	}
+	missingWarnings, err := verifyWithMissingWarnings(provenances, errorOpts)
+	return append(warnings, missingWarnings...), err
+}
+
+// ProvenanceCallback is called by VerifyWithCallback with the index of a
+// provenance, and the result of verifying it, i.e., nil if it passed.
+type ProvenanceCallback func(index int, err error)
+
+// VerifyWithCallback works like Verify, but in addition calls the given
+// callback for every provenance, in order, as soon as that provenance has
+// been verified, and before the aggregate result is returned. The per-
+// provenance results cover only the verification steps with severity ERROR
+// that concern a single provenance. Steps comparing provenances with each
+// other, such as all_same_binary_name or provenance_count_at_least, are
+// only part of the aggregate result.
+func VerifyWithCallback(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions, callback ProvenanceCallback) error {
 	if provenances == nil {
 		panic(fmt.Errorf("provenances must not be nil"))
 	}
+	if err := ValidateVerificationOptions(verOpts); err != nil {
+		return failure(InvalidVerificationOptions, "invalid verification options: %v", err)
+	}
+
+	errorOpts, _ := splitBySeverity(verOpts)
+	singleOpts := withoutAggregateSteps(errorOpts)
+	for index, provenance := range provenances {
+		callback(index, verify([]model.ProvenanceIR{provenance}, singleOpts))
+	}
+	return Verify(provenances, verOpts)
+}
+
+// aggregateSteps lists the names of the verification steps that compare
+// provenances with each other, rather than concern a single provenance.
+//
+//nolint:gochecknoglobals
+var aggregateSteps = map[protoreflect.Name]bool{
+	"provenance_count_at_least": true,
+	"provenance_count_at_most":  true,
+	"all_same_binary_name":      true,
+	"all_same_binary_digest":    true,
+	"distinct_signers":          true,
+	"independent_reproduction":  true,
+}
+
+// withoutAggregateSteps returns a copy of the given options without the
+// verification steps that compare provenances with each other.
+func withoutAggregateSteps(verOpts *pb.VerificationOptions) *pb.VerificationOptions {
+	opts, _ := proto.Clone(verOpts).(*pb.VerificationOptions)
+	message := opts.ProtoReflect()
+	for name := range aggregateSteps {
+		message.Clear(message.Descriptor().Fields().ByName(name))
+	}
+	return opts
+}
+
+// VerificationResult details which provenances satisfied which verification
+// steps, e.g., for audit logs.
+type VerificationResult struct {
+	// SatisfiedBy maps the names of the verification steps set in the
+	// options, e.g., "all_with_binary_name", to the indices of the provenances
+	// that satisfied them, in increasing order. Steps that compare provenances
+	// with each other, such as provenance_count_at_least, are satisfied by
+	// either all provenances or none.
+	SatisfiedBy map[string][]int
+	// Warnings lists the failures of verification steps with severity WARN,
+	// as returned by VerifyWithWarnings.
+	Warnings []error
+}
+
+// VerifyDetailed works like VerifyWithWarnings, but in addition returns which
+// provenances satisfied which verification steps. The returned error is the
+// same as that of Verify. The result is returned even if the verification
+// fails, unless the options are invalid.
+func VerifyDetailed(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) (*VerificationResult, error) {
+	warnings, err := VerifyWithWarnings(provenances, verOpts)
+	if ValidateVerificationOptions(verOpts) != nil {
+		return nil, err
+	}
+
+	result := &VerificationResult{SatisfiedBy: make(map[string][]int), Warnings: warnings}
+	opts, _ := proto.Clone(verOpts).(*pb.VerificationOptions)
+	opts.Severities = nil
+	opts.OnMissing = nil
+	opts.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		step := &pb.VerificationOptions{OnMissing: verOpts.OnMissing}
+		step.ProtoReflect().Set(field, value)
+		satisfiedBy := []int{}
+		if aggregateSteps[field.Name()] {
+			if verify(provenances, step) == nil {
+				for index := range provenances {
+					satisfiedBy = append(satisfiedBy, index)
+				}
+			}
+		} else {
+			for index := range provenances {
+				if verify(provenances[index:index+1], step) == nil {
+					satisfiedBy = append(satisfiedBy, index)
+				}
+			}
+		}
+		result.SatisfiedBy[string(field.Name())] = satisfiedBy
+		return true
+	})
+	return result, err
+}
+
+// deprecatedBuildTypeWarnings returns a warning for every provenance with a
+// build type that is deprecated according to the given option, if set.
+func deprecatedBuildTypeWarnings(provenances []model.ProvenanceIR, opt *pb.VerifyAllWithBuildType) []error {
+	var warnings []error
+	for index, provenance := range provenances {
+		if containsString(opt.GetDeprecated(), provenance.BuildType()) {
+			warnings = append(warnings, failure(BuildTypeDeprecated, "provenance #%d has the deprecated build type %q", index, provenance.BuildType()))
+		}
+	}
+	return warnings
+}
+
+// containsString reports whether the given list contains the given string.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyBuilderID reports whether the given builder ID matches any of the
+// allowed ones. Allowed builder IDs without a ref match the builder at any
+// ref.
+func matchesAnyBuilderID(builderID string, allowed []string) bool {
+	builder, _, _ := strings.Cut(builderID, "@")
+	for _, a := range allowed {
+		if a == builderID || (!strings.Contains(a, "@") && a == builder) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrustedCommitSigner reports whether the given commit signature is by one
+// of the given trusted signers. GPG fingerprints are compared ignoring case
+// and spaces.
+func isTrustedCommitSigner(signature model.CommitSignature, trustedSigners []string) bool {
+	for _, trusted := range trustedSigners {
+		if signature.Format == model.CommitSignatureFormatGPG {
+			if normalizeGPGFingerprint(trusted) == normalizeGPGFingerprint(signature.Signer) {
+				return true
+			}
+		} else if trusted == signature.Signer {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeGPGFingerprint(fingerprint string) string {
+	return strings.ToUpper(strings.ReplaceAll(fingerprint, " ", ""))
+}
+
+// splitBySeverity splits the given options into the options containing the
+// verification steps with severity ERROR, and one options instance for every
+// verification step with severity WARN.
+func splitBySeverity(verOpts *pb.VerificationOptions) (*pb.VerificationOptions, []*pb.VerificationOptions) {
+	errorOpts, _ := proto.Clone(verOpts).(*pb.VerificationOptions)
+	errorOpts.Severities = nil
+	if len(verOpts.Severities) == 0 {
+		return errorOpts, nil
+	}
+
+	names := make([]string, 0, len(verOpts.Severities))
+	for name := range verOpts.Severities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var warnOpts []*pb.VerificationOptions
+	message := errorOpts.ProtoReflect()
+	for _, name := range names {
+		if verOpts.Severities[name] != pb.Severity_WARN {
+			continue
+		}
+		// The field names have already been validated.
+		field := message.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if !message.Has(field) {
+			continue
+		}
+		opts := &pb.VerificationOptions{OnMissing: verOpts.OnMissing}
+		opts.ProtoReflect().Set(field, message.Get(field))
+		message.Clear(field)
+		warnOpts = append(warnOpts, opts)
+	}
+	return errorOpts, warnOpts
+}
+
+// verify runs all verification steps in the given validated options.
+// Provenances lacking a field with the on_missing policy MISSING_WARN pass the
+// step, see verifyWithMissingWarnings to obtain the warnings.
+func verify(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) error {
+	_, err := verifyWithMissingWarnings(provenances, verOpts)
+	return err
+}
+
+// verifyWithMissingWarnings works like verify, but in addition returns a
+// warning for every provenance lacking a field with the on_missing policy
+// MISSING_WARN.
+func verifyWithMissingWarnings(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) ([]error, error) {
+	var warnings []error
+	var errs error
+	for _, err := range multierr.Errors(verifySteps(provenances, verOpts)) {
+		if warning, ok := err.(*missingFieldWarning); ok {
+			warnings = append(warnings, warning.err)
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return warnings, errs
+}
+
+// missingFieldWarning wraps the failure of a provenance lacking a field with
+// the on_missing policy MISSING_WARN, so that it can be reported as a
+// warning.
+type missingFieldWarning struct {
+	err error
+}
+
+// Error implements the error interface.
+func (w *missingFieldWarning) Error() string {
+	return w.err.Error()
+}
+
+// missingPolicySteps lists the names of the verification steps that support
+// an on_missing policy, with their default policies.
+//
+//nolint:gochecknoglobals
+var missingPolicySteps = map[string]pb.MissingPolicy{
+	"all_with_environment_digest":         pb.MissingPolicy_MISSING_FAIL,
+	"all_signed_within_days_of_build":     pb.MissingPolicy_MISSING_FAIL,
+	"trusted_generator":                   pb.MissingPolicy_MISSING_FAIL,
+	"all_with_platform":                   pb.MissingPolicy_MISSING_FAIL,
+	"all_with_build_duration":             pb.MissingPolicy_MISSING_PASS,
+	"all_built_within_days":               pb.MissingPolicy_MISSING_FAIL,
+	"not_older_than":                      pb.MissingPolicy_MISSING_FAIL,
+	"all_within_own_validity":             pb.MissingPolicy_MISSING_PASS,
+	"commit_signature":                    pb.MissingPolicy_MISSING_FAIL,
+	"builder_org":                         pb.MissingPolicy_MISSING_FAIL,
+	"all_with_builder_id":                 pb.MissingPolicy_MISSING_FAIL,
+	"all_with_source_repo":                pb.MissingPolicy_MISSING_FAIL,
+	"all_with_external_parameters_digest": pb.MissingPolicy_MISSING_FAIL,
+}
+
+// missing returns the outcome of the given step for a provenance lacking the
+// field checked by the step, according to the on_missing policy of the step,
+// or its default policy: nil if the provenance passes, a missingFieldWarning
+// if it passes with a warning, and a failure with the given code and message
+// otherwise.
+func missing(verOpts *pb.VerificationOptions, step string, code ReasonCode, format string, args ...interface{}) error {
+	policy := verOpts.OnMissing[step]
+	if policy == pb.MissingPolicy_MISSING_DEFAULT {
+		policy = missingPolicySteps[step]
+	}
+	switch policy {
+	case pb.MissingPolicy_MISSING_PASS:
+		return nil
+	case pb.MissingPolicy_MISSING_WARN:
+		return &missingFieldWarning{err: failure(code, format, args...)}
+	default:
+		return failure(code, format, args...)
+	}
+}
 
+// verifySteps implements verifyWithMissingWarnings, returning the warnings
+// as missingFieldWarnings among the errors.
+//
+//nolint:cyclop,gocognit,gocyclo,maintidx
+func verifySteps(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) error {
 	var errs error
 
 	if verOpts.ProvenanceCountAtLeast != nil && len(provenances) < int(verOpts.ProvenanceCountAtLeast.Count) {
-		errs = multierr.Append(errs, fmt.Errorf("too few provenances: have %d but want at least %d", len(provenances), verOpts.ProvenanceCountAtLeast.Count))
+		errs = multierr.Append(errs, failure(ProvenanceCountTooLow, "too few provenances: have %d but want at least %d", len(provenances), verOpts.ProvenanceCountAtLeast.Count))
 	}
 
 	if verOpts.ProvenanceCountAtMost != nil && len(provenances) > int(verOpts.ProvenanceCountAtMost.Count) {
-		errs = multierr.Append(errs, fmt.Errorf("too many provenances: have %d but want at most %d", len(provenances), verOpts.ProvenanceCountAtMost.Count))
+		errs = multierr.Append(errs, failure(ProvenanceCountTooHigh, "too many provenances: have %d but want at most %d", len(provenances), verOpts.ProvenanceCountAtMost.Count))
 	}
 
 	if verOpts.AllSameBinaryName != nil && len(provenances) > 1 {
 		expectedBinaryName := provenances[0].BinaryName()
-		for _, p := range provenances {
+		for index, p := range provenances {
 			if p.BinaryName() != expectedBinaryName {
-				errs = multierr.Append(errs, fmt.Errorf("not all have same binary name"))
+				errs = multierr.Append(errs, failure(BinaryNameInconsistent, "not all have same binary name: #%d has %q but #0 has %q", index, p.BinaryName(), expectedBinaryName))
 			}
 		}
 	}
 
 	if verOpts.AllSameBinaryDigest != nil && len(provenances) > 1 {
-		expectedDigest := provenances[0].BinarySHA256Digest()
-		for _, p := range provenances {
-			if p.BinarySHA256Digest() != expectedDigest {
-				errs = multierr.Append(errs, fmt.Errorf("not all have same SHA2-256 binary digest"))
+		expected := binaryDigestsByType(&provenances[0])
+		for index := 1; index < len(provenances); index++ {
+			digests := binaryDigestsByType(&provenances[index])
+			if !sameDigests(digests, expected) {
+				errs = multierr.Append(errs, failure(DigestInconsistent, "not all have same binary digest: #%d has %s but #0 has %s", index, formatDigests(digests), formatDigests(expected)))
 			}
 		}
 	}
@@ -65,7 +376,7 @@ func Verify(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) e
 	if verOpts.AllWithBuildCommand != nil {
 		for i, p := range provenances {
 			if buildCmd, err := p.BuildCmd(); err != nil || len(buildCmd) == 0 {
-				errs = multierr.Append(errs, fmt.Errorf("no build command found in #%d", i))
+				errs = multierr.Append(errs, failure(BuildCommandMissing, "no build command found in #%d", i))
 			}
 		}
 	}
@@ -73,41 +384,86 @@ func Verify(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) e
 	if verOpts.AllWithBinaryName != nil {
 		for i, p := range provenances {
 			if p.BinaryName() != verOpts.AllWithBinaryName.BinaryName {
-				errs = multierr.Append(errs, fmt.Errorf("unexpected binary name in #%d: got %q but want %q", i, p.BinaryName(), verOpts.AllWithBinaryName.BinaryName))
+				errs = multierr.Append(errs, failure(BinaryNameMismatch, "unexpected binary name in #%d: got %q but want %q", i, p.BinaryName(), verOpts.AllWithBinaryName.BinaryName))
+			}
+		}
+	}
+
+	if verOpts.AllWithBinaryNamePattern != nil {
+		// The pattern has already been validated above.
+		pattern := regexp.MustCompile(anchored(verOpts.AllWithBinaryNamePattern.Pattern))
+		for i, p := range provenances {
+			if !pattern.MatchString(p.BinaryName()) {
+				errs = multierr.Append(errs, failure(BinaryNameMismatch, "binary name in #%d does not match pattern: got %q but want %q", i, p.BinaryName(), verOpts.AllWithBinaryNamePattern.Pattern))
+			}
+		}
+	}
+
+	if verOpts.AllWithImageReference != nil {
+		// The reference has already been validated.
+		expected, _ := parseImageReference(verOpts.AllWithImageReference.ImageReference)
+		for index, provenance := range provenances {
+			got, err := parseImageReference(provenance.BinaryName())
+			if err != nil || !got.matches(expected) {
+				errs = multierr.Append(errs, failure(ImageReferenceMismatch, "subject name of #%d does not reference the expected image: got %q but want %q", index, provenance.BinaryName(), verOpts.AllWithImageReference.ImageReference))
+			}
+		}
+	}
+
+	if opt := verOpts.AllWithImageManifestDigest; opt != nil {
+		// The reference and the manifest have already been validated.
+		reference, _ := parseImageReference(opt.ImageReference)
+		manifest, _ := parseOCIManifest(opt.Manifest)
+		for index, provenance := range provenances {
+			digest := "sha256:" + strings.ToLower(provenance.BinarySHA256Digest())
+			if digest == reference.digest {
+				continue
+			}
+			if role := manifest.describeDigest(digest); role != "" {
+				errs = multierr.Append(errs, failure(ImageManifestDigestMismatch, "subject digest of #%d is %s of the image, want the manifest digest %q", index, role, reference.digest))
+			} else {
+				errs = multierr.Append(errs, failure(ImageManifestDigestMismatch, "subject digest of #%d is %q, want the manifest digest %q", index, digest, reference.digest))
+			}
+		}
+	}
+
+	if verOpts.EmptyArtifacts != nil && !verOpts.EmptyArtifacts.Allow {
+		for index, provenance := range provenances {
+			if provenance.BinarySHA256Digest() == emptySHA256Digest {
+				errs = multierr.Append(errs, failure(EmptyArtifact, "provenance #%d is for an empty artifact", index))
 			}
 		}
 	}
 
-	//nolint:nestif
 	if verOpts.AllWithBinaryDigests != nil {
 		for index, provenance := range provenances {
-			digest := provenance.BinarySHA256Digest()
-			found := false
-			for _, digests := range verOpts.AllWithBinaryDigests.Digests {
-				for f, d := range digests.Binary {
-					if f != int32(pb.Digest_SHA2_256) {
-						continue
-					}
-					if digest == hex.EncodeToString(d) {
-						found = true
-						break
-					}
-				}
-				if found {
-					break
-				}
-				for f, d := range digests.Hexadecimal {
-					if f != int32(pb.Digest_SHA2_256) {
-						continue
-					}
-					if digest == d {
-						found = true
-						break
-					}
-				}
+			matches := matchesBinaryDigests(verOpts.AllWithBinaryDigests.Digests, &provenance)
+			if matches && provenance.BinarySHA256Digest() == emptySHA256Digest && verOpts.EmptyArtifacts != nil {
+				// The empty digest is pinned, and empty artifacts are handled
+				// above, whatever their subject name.
+				continue
 			}
-			if !found {
-				errs = multierr.Append(errs, fmt.Errorf("could not match binary digest in #%d: %q", index, digest))
+			if !matches {
+				errs = multierr.Append(errs, failure(DigestMismatch, "could not match binary digest in #%d: %q", index, binaryDigestString(&provenance)))
+			} else if verOpts.AllWithBinaryDigests.SubjectMatching == pb.VerifyAllWithBinaryDigests_NAME_AND_DIGEST && provenance.BinaryName() != verOpts.AllWithBinaryDigests.BinaryName {
+				errs = multierr.Append(errs, failure(BinaryNameMismatch, "binary digest in #%d is listed under an unexpected subject: got %q but want %q", index, provenance.BinaryName(), verOpts.AllWithBinaryDigests.BinaryName))
+			}
+		}
+	}
+
+	if verOpts.AllWithBinaryDigestsAnyOf != nil {
+		digests := flattenDigests(verOpts.AllWithBinaryDigestsAnyOf)
+		for index, provenance := range provenances {
+			if !matchesBinaryDigests(digests, &provenance) {
+				errs = multierr.Append(errs, failure(DigestMismatch, "binary digest in #%d matches none of the acceptable digests: %q", index, binaryDigestString(&provenance)))
+			}
+		}
+	}
+
+	if verOpts.AllNotInDigestBlocklist != nil {
+		for index, provenance := range provenances {
+			if isBlocklisted(verOpts.AllNotInDigestBlocklist.Digests, &provenance) {
+				errs = multierr.Append(errs, failure(DigestBlocklisted, "binary digest in #%d is blocklisted: %q", index, binaryDigestString(&provenance)))
 			}
 		}
 	}
@@ -120,7 +476,7 @@ func Verify(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) e
 				repoURI = provenance.RepoURI()
 			}
 			if repoURI != expected {
-				errs = multierr.Append(errs, fmt.Errorf("repository mismatch in #%d: got %q but want %q", index, repoURI, expected))
+				errs = multierr.Append(errs, failure(RepositoryMismatch, "repository mismatch in #%d: got %q but want %q", index, repoURI, expected))
 			}
 		}
 	}
@@ -139,7 +495,20 @@ func Verify(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) e
 				}
 			}
 			if !found {
-				errs = multierr.Append(errs, fmt.Errorf("could not match builder name in #%d: %q", index, buiilderName))
+				errs = multierr.Append(errs, failure(BuilderNotTrusted, "could not match builder name in #%d: %q", index, buiilderName))
+			}
+		}
+	}
+
+	if verOpts.AllWithBuilderId != nil {
+		for index, provenance := range provenances {
+			builderID, err := provenance.TrustedBuilder()
+			if err != nil || builderID == "" {
+				errs = multierr.Append(errs, missing(verOpts, "all_with_builder_id", BuilderIDMissing, "no builder ID found in #%d", index))
+				continue
+			}
+			if !matchesAnyBuilderID(builderID, verOpts.AllWithBuilderId.BuilderIds) {
+				errs = multierr.Append(errs, failure(BuilderIDNotAllowed, "builder ID of #%d is %q, want one of %q", index, builderID, verOpts.AllWithBuilderId.BuilderIds))
 			}
 		}
 	}
@@ -176,28 +545,1300 @@ func Verify(provenances []model.ProvenanceIR, verOpts *pb.VerificationOptions) e
 				}
 			}
 			if !found {
-				errs = multierr.Append(errs, fmt.Errorf("could not match builder digest in #%d: %q", index, digest))
+				errs = multierr.Append(errs, failure(BuilderDigestMismatch, "could not match builder digest in #%d: %q", index, digest))
 			}
 		}
 	}
 
-	return errs
-}
+	if verOpts.AllWithEnvironmentDigest != nil {
+		expected := []*pb.Digest{verOpts.AllWithEnvironmentDigest.Digest}
+		for index, provenance := range provenances {
+			digest, err := provenance.EnvironmentSHA256Digest()
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "all_with_environment_digest", EnvironmentDigestMissing, "no environment digest found in #%d", index))
+				continue
+			}
+			if !containsDigest(expected, pb.Digest_SHA2_256, digest) {
+				errs = multierr.Append(errs, failure(EnvironmentDigestMismatch, "could not match environment digest in #%d: %q", index, digest))
+			}
+		}
+	}
 
-// LoadVerificationOptions loads VerificationOptions from a textproto file.
-func LoadVerificationOptions(path string) (*pb.VerificationOptions, error) {
-	bytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("reading file from %q: %v", path, err)
+	if verOpts.AllWithExternalParametersDigest != nil {
+		expected := []*pb.Digest{verOpts.AllWithExternalParametersDigest.Digest}
+		for index, provenance := range provenances {
+			digest, err := provenance.ExternalParametersSHA256Digest()
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "all_with_external_parameters_digest", ExternalParametersDigestMissing, "no external parameters digest found in #%d", index))
+				continue
+			}
+			if !containsDigest(expected, pb.Digest_SHA2_256, digest) {
+				errs = multierr.Append(errs, failure(ExternalParametersDigestMismatch, "could not match external parameters digest in #%d: %q", index, digest))
+			}
+		}
 	}
-	return ParseVerificationOptions(string(bytes))
-}
 
-// LoadVerificationOptions parses VerificationOptions from textproto.
-func ParseVerificationOptions(textproto string) (*pb.VerificationOptions, error) {
-	var opts pb.VerificationOptions
-	if err := prototext.Unmarshal([]byte(textproto), &opts); err != nil {
-		return nil, fmt.Errorf("parse VerificationOptions: %v", err)
+	if verOpts.AllWithInvocationParameters != nil {
+		for index, provenance := range provenances {
+			params, err := provenance.InvocationParameters()
+			if err != nil {
+				params = map[string]string{}
+			}
+			for key, want := range verOpts.AllWithInvocationParameters.Parameters {
+				got, found := params[key]
+				if !found {
+					errs = multierr.Append(errs, failure(InvocationParameterMissing, "missing invocation parameter %q in #%d", key, index))
+				} else if got != want {
+					errs = multierr.Append(errs, failure(InvocationParameterWrong, "invocation parameter %q mismatch in #%d: got %q but want %q", key, index, got, want))
+				}
+			}
+		}
+	}
+
+	if verOpts.AllSignedWithinDaysOfBuild != nil {
+		maxGap := time.Duration(verOpts.AllSignedWithinDaysOfBuild.Days) * 24 * time.Hour
+		for index, provenance := range provenances {
+			signedOn, err := provenance.SignedOn()
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "all_signed_within_days_of_build", TimestampMissing, "no signing time found in #%d", index))
+				continue
+			}
+			builtOn, err := buildTime(&provenance)
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "all_signed_within_days_of_build", TimestampMissing, "no build time found in #%d", index))
+				continue
+			}
+			gap := signedOn.Sub(builtOn)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap > maxGap {
+				errs = multierr.Append(errs, failure(SignedTooLate, "signing time of #%d is %v away from its build time, want at most %d days", index, gap, verOpts.AllSignedWithinDaysOfBuild.Days))
+			}
+		}
+	}
+
+	if verOpts.TrustedGenerator != nil {
+		for index, provenance := range provenances {
+			builder, err := provenance.TrustedBuilder()
+			if err != nil {
+				builder = ""
+			}
+			id, version := splitBuilderVersion(builder)
+			minimum, found := verOpts.TrustedGenerator.MinimumVersions[id]
+			if !found {
+				errs = multierr.Append(errs, failure(GeneratorNotTrusted, "untrusted generator in #%d: %q", index, builder))
+				continue
+			}
+			if version == "" {
+				errs = multierr.Append(errs, missing(verOpts, "trusted_generator", GeneratorVersionInvalid, "no generator version found in #%d: %q", index, builder))
+				continue
+			}
+			cmp, err := compareVersions(version, minimum)
+			if err != nil {
+				errs = multierr.Append(errs, failure(GeneratorVersionInvalid, "invalid generator version in #%d: %v", index, err))
+				continue
+			}
+			if cmp < 0 {
+				errs = multierr.Append(errs, failure(GeneratorVersionTooOld, "generator version in #%d is too old: got %q but want at least %q", index, version, minimum))
+			}
+		}
+	}
+
+	if verOpts.AllWithCanonicalDependencies != nil {
+		for index, provenance := range provenances {
+			if !provenance.HasResolvedDependencies() {
+				continue
+			}
+			dependencies, err := provenance.ResolvedDependencies()
+			if err != nil {
+				errs = multierr.Append(errs, failure(DependenciesNotCanonical, "could not get the resolved dependencies of #%d: %v", index, err))
+				continue
+			}
+			if !model.IsCanonicalDependencies(dependencies) {
+				errs = multierr.Append(errs, failure(DependenciesNotCanonical, "resolved dependencies of #%d are not in canonical order", index))
+			}
+		}
+	}
+
+	if verOpts.AllWithSourceMaterial != nil {
+		for index, provenance := range provenances {
+			if !hasSourceMaterial(&provenance) {
+				errs = multierr.Append(errs, failure(SourceMaterialMissing, "source repository of #%d is not listed as a material with its commit digest", index))
+			}
+		}
+	}
+
+	if verOpts.AllWithSourceRepo != nil {
+		for index, provenance := range provenances {
+			repo, found := sourceRepo(&provenance)
+			if !found {
+				errs = multierr.Append(errs, missing(verOpts, "all_with_source_repo", SourceRepoMissing, "no source repository found in #%d", index))
+				continue
+			}
+			if err := matchSourceRepo(repo, verOpts.AllWithSourceRepo); err != nil {
+				errs = multierr.Append(errs, failure(SourceRepoMismatch, "source repository of #%d is %q: %v", index, repo, err))
+			}
+		}
+	}
+
+	if verOpts.AllDistinctFromMaterials != nil {
+		for index, provenance := range provenances {
+			if uri, found := passthroughMaterial(&provenance); found {
+				errs = multierr.Append(errs, failure(PassthroughBuild, "binary of #%d has the same digest as the material %q", index, uri))
+			}
+		}
+	}
+
+	if verOpts.AllWithMinDependencies != nil {
+		for index, provenance := range provenances {
+			count := 0
+			if dependencies, err := provenance.ResolvedDependencies(); err == nil {
+				count = len(dependencies)
+			}
+			if count < int(verOpts.AllWithMinDependencies.Count) {
+				errs = multierr.Append(errs, failure(TooFewDependencies, "provenance #%d has too few resolved dependencies: got %d but want at least %d", index, count, verOpts.AllWithMinDependencies.Count))
+			}
+		}
+	}
+
+	if verOpts.AllWithMinByproducts != nil {
+		for index, provenance := range provenances {
+			count := 0
+			if byproducts, err := provenance.Byproducts(); err == nil {
+				count = len(byproducts)
+			}
+			if count < int(verOpts.AllWithMinByproducts.Count) {
+				errs = multierr.Append(errs, failure(TooFewByproducts, "provenance #%d has too few byproducts: got %d but want at least %d", index, count, verOpts.AllWithMinByproducts.Count))
+			}
+		}
+	}
+
+	if verOpts.AllHaveInvocationId != nil {
+		for index, provenance := range provenances {
+			if invocationID, err := provenance.InvocationID(); err != nil || invocationID == "" {
+				errs = multierr.Append(errs, failure(InvocationIDMissing, "no invocation ID found in #%d", index))
+			}
+		}
+	}
+
+	if verOpts.IndependentReproduction != nil {
+		invocationsByCommit := make(map[string]map[string]bool)
+		reproduced := false
+		for _, provenance := range provenances {
+			if !provenance.HasCommitSHA1Digest() || !provenance.HasInvocationID() {
+				continue
+			}
+			commit := strings.ToLower(provenance.CommitSHA1Digest())
+			invocationID, _ := provenance.InvocationID()
+			if invocationID == "" {
+				continue
+			}
+			if invocationsByCommit[commit] == nil {
+				invocationsByCommit[commit] = make(map[string]bool)
+			}
+			invocationsByCommit[commit][invocationID] = true
+			if len(invocationsByCommit[commit]) >= int(verOpts.IndependentReproduction.Count) {
+				reproduced = true
+			}
+		}
+		if !reproduced {
+			errs = multierr.Append(errs, failure(NotIndependentlyReproduced, "fewer than %d provenances record the same commit with distinct invocation IDs", verOpts.IndependentReproduction.Count))
+		}
+	}
+
+	if verOpts.AllWithPlatform != nil {
+		expectedOS := strings.ToLower(verOpts.AllWithPlatform.Os)
+		expectedArch := model.NormalizeArchitecture(verOpts.AllWithPlatform.Architecture)
+		for index, provenance := range provenances {
+			platform, err := provenance.Platform()
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "all_with_platform", PlatformMissing, "no platform found in #%d", index))
+				continue
+			}
+			if (expectedOS != "" && platform.OS != expectedOS) || (expectedArch != "" && platform.Architecture != expectedArch) {
+				errs = multierr.Append(errs, failure(PlatformMismatch, "platform of #%d mismatched: got %s but want %s/%s", index, platform, expectedOS, expectedArch))
+			}
+		}
+	}
+
+	if verOpts.AllWithCiSystem != nil {
+		expected := verOpts.AllWithCiSystem.CiSystem
+		for index, provenance := range provenances {
+			got := classifyCISystem(&provenance)
+			if got != expected {
+				errs = multierr.Append(errs, failure(CISystemMismatch, "CI system of #%d mismatched: got %s but want %s", index, got, expected))
+			}
+		}
+	}
+
+	if verOpts.SourceIdentity != nil {
+		for index, provenance := range provenances {
+			repoURI, commit := "", ""
+			if provenance.HasRepoURI() {
+				repoURI = provenance.RepoURI()
+			}
+			if provenance.HasCommitSHA1Digest() {
+				commit = provenance.CommitSHA1Digest()
+			}
+			found := false
+			for _, identity := range verOpts.SourceIdentity.Identities {
+				if repoURI == identity.RepositoryUri && strings.EqualFold(commit, identity.CommitSha1Digest) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				errs = multierr.Append(errs, failure(SourceIdentityMismatch, "could not match source identity in #%d: repository %q at commit %q", index, repoURI, commit))
+			}
+		}
+	}
+
+	if verOpts.AllReproducible != nil {
+		for index, provenance := range provenances {
+			if reproducible, err := provenance.Reproducible(); err != nil || !reproducible {
+				errs = multierr.Append(errs, failure(NotReproducible, "provenance #%d does not assert a reproducible build", index))
+			}
+		}
+	}
+
+	if verOpts.MaterialsComplete != nil {
+		for index, provenance := range provenances {
+			if complete, err := provenance.MaterialsComplete(); err != nil || !complete {
+				errs = multierr.Append(errs, failure(MaterialsIncomplete, "provenance #%d does not assert complete materials", index))
+			}
+		}
+	}
+
+	if verOpts.AllWithinDocumentSize != nil {
+		for index, provenance := range provenances {
+			if !provenance.HasDocumentSize() {
+				continue
+			}
+			size, _ := provenance.DocumentSize()
+			if int64(size) > verOpts.AllWithinDocumentSize.MaxBytes {
+				errs = multierr.Append(errs, failure(DocumentTooLarge, "provenance document #%d is too large: have %d bytes but want at most %d", index, size, verOpts.AllWithinDocumentSize.MaxBytes))
+			}
+		}
+	}
+
+	if verOpts.BuilderSignature != nil {
+		// The public key has already been validated above.
+		publicKey, _ := model.ParsePublicKey([]byte(verOpts.BuilderSignature.PublicKeyPem))
+		for index, provenance := range provenances {
+			signature, err := provenance.BuilderSignature()
+			if err != nil {
+				if verOpts.BuilderSignature.Required {
+					errs = multierr.Append(errs, failure(BuilderSignatureMissing, "no builder signature found in #%d", index))
+				}
+				continue
+			}
+			if err := signature.Verify(publicKey); err != nil {
+				errs = multierr.Append(errs, failure(BuilderSignatureInvalid, "could not verify builder signature in #%d: %v", index, err))
+			}
+		}
+	}
+
+	if verOpts.DistinctSigners != nil {
+		signers := make(map[string]bool)
+		for _, provenance := range provenances {
+			ids, err := provenance.Signers()
+			if err != nil {
+				continue
+			}
+			for _, id := range ids {
+				signers[id] = true
+			}
+		}
+		if len(signers) < int(verOpts.DistinctSigners.Count) {
+			errs = multierr.Append(errs, failure(TooFewSigners, "too few distinct signers: have %d but want at least %d", len(signers), verOpts.DistinctSigners.Count))
+		}
+	}
+
+	if verOpts.AllWithOciAnnotations != nil {
+		for index, provenance := range provenances {
+			annotations, err := provenance.OCIAnnotations()
+			if err != nil {
+				annotations = map[string]string{}
+			}
+			for key, want := range verOpts.AllWithOciAnnotations.Annotations {
+				got, found := annotations[key]
+				if !found {
+					errs = multierr.Append(errs, failure(OCIAnnotationMissing, "missing OCI annotation %q in #%d", key, index))
+				} else if got != want {
+					errs = multierr.Append(errs, failure(OCIAnnotationMismatch, "OCI annotation %q mismatch in #%d: got %q but want %q", key, index, got, want))
+				}
+			}
+		}
+	}
+
+	if verOpts.AllWithBuildDuration != nil {
+		minDuration := time.Duration(verOpts.AllWithBuildDuration.MinSeconds) * time.Second
+		maxDuration := time.Duration(verOpts.AllWithBuildDuration.MaxSeconds) * time.Second
+		for index, provenance := range provenances {
+			if !provenance.HasBuildStartedOn() || !provenance.HasBuildFinishedOn() {
+				errs = multierr.Append(errs, missing(verOpts, "all_with_build_duration", TimestampMissing, "no build start and end times found in #%d", index))
+				continue
+			}
+			startedOn, _ := provenance.BuildStartedOn()
+			finishedOn, _ := provenance.BuildFinishedOn()
+			duration := finishedOn.Sub(startedOn)
+			if duration < 0 {
+				errs = multierr.Append(errs, failure(BuildDurationOutOfRange, "build of #%d finished %v before it started", index, -duration))
+			} else if duration < minDuration || (maxDuration > 0 && duration > maxDuration) {
+				errs = multierr.Append(errs, failure(BuildDurationOutOfRange, "build of #%d took %v, want between %v and %v", index, duration, minDuration, maxDuration))
+			}
+		}
+	}
+
+	if verOpts.AllBuiltWithinDays != nil {
+		maxAge := time.Duration(verOpts.AllBuiltWithinDays.Days) * 24 * time.Hour
+		for index, provenance := range provenances {
+			builtOn, err := buildTime(&provenance)
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "all_built_within_days", TimestampMissing, "no build time found in #%d", index))
+				continue
+			}
+			if age := time.Since(builtOn); age > maxAge {
+				errs = multierr.Append(errs, failure(ProvenanceNotFresh, "provenance #%d is not fresh: built %v ago, want at most %d days", index, age.Round(time.Second), verOpts.AllBuiltWithinDays.Days))
+			}
+		}
+	}
+
+	if verOpts.NotOlderThan != nil {
+		// The timestamp has already been validated.
+		prior, _ := time.Parse(time.RFC3339, verOpts.NotOlderThan.PriorTimestamp)
+		for index, provenance := range provenances {
+			builtOn, err := buildTime(&provenance)
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "not_older_than", TimestampMissing, "no build time found in #%d", index))
+				continue
+			}
+			if builtOn.Before(prior) {
+				errs = multierr.Append(errs, failure(ProvenanceTooOld, "provenance #%d was built at %v, before the prior build at %v", index, builtOn.UTC().Format(time.RFC3339), prior.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+
+	if verOpts.AllWithinOwnValidity != nil {
+		// The timestamp has already been validated.
+		at := time.Now()
+		if verOpts.AllWithinOwnValidity.AtTimestamp != "" {
+			at, _ = time.Parse(time.RFC3339, verOpts.AllWithinOwnValidity.AtTimestamp)
+		}
+		for index, provenance := range provenances {
+			validity, err := provenance.Validity()
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "all_within_own_validity", ValidityMissing, "no validity found in #%d", index))
+				continue
+			}
+			if validity.NotBefore != nil && at.Before(*validity.NotBefore) {
+				errs = multierr.Append(errs, failure(ProvenanceNotYetValid, "provenance #%d is not valid before %v", index, validity.NotBefore.UTC().Format(time.RFC3339)))
+			} else if !validity.Contains(at) {
+				errs = multierr.Append(errs, failure(ProvenanceExpired, "provenance #%d expired at %v", index, validity.NotAfter.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+
+	if verOpts.AllWithBuildType != nil {
+		for index, provenance := range provenances {
+			buildType := provenance.BuildType()
+			if !containsString(verOpts.AllWithBuildType.Allowed, buildType) && !containsString(verOpts.AllWithBuildType.Deprecated, buildType) {
+				errs = multierr.Append(errs, failure(BuildTypeNotAllowed, "build type %q of #%d is not allowed", provenance.BuildType(), index))
+			}
+		}
+	}
+
+	if verOpts.CommitSignature != nil {
+		for index, provenance := range provenances {
+			signature, err := provenance.CommitSignature()
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "commit_signature", CommitSignatureMissing, "no commit signature found in #%d", index))
+				continue
+			}
+			if !isTrustedCommitSigner(signature, verOpts.CommitSignature.TrustedSigners) {
+				errs = multierr.Append(errs, failure(CommitSignerNotTrusted, "the source commit of #%d is signed by untrusted %s key %q", index, signature.Format, signature.Signer))
+			}
+		}
+	}
+
+	if verOpts.BuilderOrg != nil {
+		for index, provenance := range provenances {
+			identity, err := provenance.SignerIdentity()
+			if err != nil {
+				errs = multierr.Append(errs, missing(verOpts, "builder_org", BuilderIdentityMissing, "no verified signing certificate identity found in #%d", index))
+				continue
+			}
+			if identity.OIDCIssuer != githubActionsOIDCIssuer {
+				errs = multierr.Append(errs, failure(BuilderOrgNotAllowed, "provenance #%d was signed with an identity from OIDC issuer %q, want %q", index, identity.OIDCIssuer, githubActionsOIDCIssuer))
+				continue
+			}
+			org := githubOwner(identity.SubjectAlternativeName)
+			allowed := false
+			for _, want := range verOpts.BuilderOrg.Orgs {
+				if org != "" && strings.EqualFold(org, want) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				errs = multierr.Append(errs, failure(BuilderOrgNotAllowed, "provenance #%d was signed by %q, which is not in any of the orgs %v", index, identity.SubjectAlternativeName, verOpts.BuilderOrg.Orgs))
+			}
+		}
+	}
+
+	return errs
+}
+
+// githubActionsOIDCIssuer is the OIDC issuer of the identities of GitHub
+// Actions workflows in Fulcio certificates.
+const githubActionsOIDCIssuer = "https://token.actions.githubusercontent.com"
+
+// githubOwner returns the owner of the GitHub repository in the given URI,
+// e.g., the SAN of a certificate issued to a GitHub Actions workflow, or an
+// empty string if the URI does not identify a GitHub repository.
+func githubOwner(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "https" || parsed.Host != "github.com" {
+		return ""
+	}
+	segments := strings.Split(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return ""
+	}
+	return segments[0]
+}
+
+// ciSystemHosts maps the hosts of builder IDs and build types to the CI
+// systems they identify.
+//
+//nolint:gochecknoglobals
+var ciSystemHosts = map[string]pb.VerifyAllWithCISystem_CISystem{
+	"github.com":                pb.VerifyAllWithCISystem_GITHUB_ACTIONS,
+	"gitlab.com":                pb.VerifyAllWithCISystem_GITLAB_CI,
+	"cloudbuild.googleapis.com": pb.VerifyAllWithCISystem_GOOGLE_CLOUD_BUILD,
+}
+
+// classifyCISystem determines the CI system that generated the given
+// provenance from the host of its builder ID, falling back to the host of its
+// build type. Hosts of self-managed GitLab instances are recognized by the
+// "gitlab." prefix.
+func classifyCISystem(provenance *model.ProvenanceIR) pb.VerifyAllWithCISystem_CISystem {
+	var candidates []string
+	if builder, err := provenance.TrustedBuilder(); err == nil {
+		candidates = append(candidates, builder)
+	}
+	candidates = append(candidates, provenance.BuildType())
+
+	for _, candidate := range candidates {
+		uri, err := url.Parse(candidate)
+		if err != nil {
+			continue
+		}
+		host := strings.ToLower(uri.Hostname())
+		if system, found := ciSystemHosts[host]; found {
+			return system
+		}
+		if strings.HasPrefix(host, "gitlab.") {
+			return pb.VerifyAllWithCISystem_GITLAB_CI
+		}
+	}
+	return pb.VerifyAllWithCISystem_UNKNOWN
+}
+
+// hasSourceMaterial reports whether the source repository of the given
+// provenance is among its resolved dependencies, with the SHA1 digest of the
+// source commit.
+func hasSourceMaterial(provenance *model.ProvenanceIR) bool {
+	if !provenance.HasRepoURI() || !provenance.HasCommitSHA1Digest() || provenance.CommitSHA1Digest() == "" {
+		return false
+	}
+	repo := withoutGitRef(provenance.RepoURI())
+	commit := strings.ToLower(provenance.CommitSHA1Digest())
+	dependencies, err := provenance.ResolvedDependencies()
+	if err != nil {
+		return false
+	}
+	for _, dependency := range dependencies {
+		if withoutGitRef(dependency.URI) == repo && strings.ToLower(dependency.Digest["sha1"]) == commit {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceRepo returns the normalized source repository of the given
+// provenance, see VerifyAllWithSourceRepo.
+func sourceRepo(provenance *model.ProvenanceIR) (string, bool) {
+	dependencies, _ := provenance.ResolvedDependencies()
+	for _, dependency := range dependencies {
+		if !strings.HasPrefix(dependency.URI, "git+") {
+			continue
+		}
+		if dependency.Digest["sha1"] != "" || dependency.Digest["gitCommit"] != "" {
+			return normalizeSourceRepo(dependency.URI), true
+		}
+	}
+	if provenance.HasRepoURI() && provenance.RepoURI() != "" {
+		return normalizeSourceRepo(provenance.RepoURI()), true
+	}
+	return "", false
+}
+
+// normalizeSourceRepo returns the given repository URI without the "git+"
+// prefix, the Git ref, and the ".git" suffix.
+func normalizeSourceRepo(uri string) string {
+	uri = withoutGitRef(strings.TrimPrefix(uri, "git+"))
+	return strings.TrimSuffix(strings.TrimSuffix(uri, "/"), ".git")
+}
+
+// matchSourceRepo returns an error if the given normalized repository does
+// not satisfy all constraints of the given option.
+func matchSourceRepo(repo string, opt *pb.VerifyAllWithSourceRepo) error {
+	if opt.Uri != "" && repo != normalizeSourceRepo(opt.Uri) {
+		return fmt.Errorf("want %q", opt.Uri)
+	}
+	if opt.UriPrefix != "" {
+		prefix := strings.TrimSuffix(normalizeSourceRepo(opt.UriPrefix), "/")
+		if repo != prefix && !strings.HasPrefix(repo, prefix+"/") {
+			return fmt.Errorf("want prefix %q", opt.UriPrefix)
+		}
+	}
+	if opt.Host != "" {
+		parsed, err := url.Parse(repo)
+		if err != nil || !strings.EqualFold(parsed.Hostname(), opt.Host) {
+			return fmt.Errorf("want host %q", opt.Host)
+		}
+	}
+	return nil
+}
+
+// passthroughMaterial returns the URI of a resolved dependency of the given
+// provenance that has the same digest as the binary for any digest type, which
+// indicates that the binary was copied from its inputs rather than built.
+func passthroughMaterial(provenance *model.ProvenanceIR) (string, bool) {
+	dependencies, err := provenance.ResolvedDependencies()
+	if err != nil {
+		return "", false
+	}
+	binaryDigests := binaryDigestsByType(provenance)
+	for _, dependency := range dependencies {
+		for alg, digest := range dependency.Digest {
+			digestType, found := digestTypes[strings.ToLower(alg)]
+			if found && digest != "" && binaryDigests[digestType] == strings.ToLower(digest) {
+				return dependency.URI, true
+			}
+		}
+	}
+	return "", false
+}
+
+// withoutGitRef returns the given repository URI without the Git ref, if
+// any, e.g., "git+https://github.com/project-oak/oak" for
+// "git+https://github.com/project-oak/oak@refs/heads/main".
+func withoutGitRef(uri string) string {
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return uri
+	}
+	rest, _, _ = strings.Cut(rest, "@")
+	return scheme + "://" + rest
+}
+
+// imageReference is a parsed and normalized OCI image reference.
+type imageReference struct {
+	// repository is the repository including the registry, e.g.,
+	// "docker.io/library/debian".
+	repository string
+	// tag and digest are empty if not specified.
+	tag    string
+	digest string
+}
+
+// parseImageReference parses a reference of the form
+// `[<registry>/]<repository>[:<tag>][@<digest>]`, normalizing it as Docker
+// does, i.e., with "docker.io" as the default registry, and "library" as the
+// default namespace on that registry.
+func parseImageReference(reference string) (imageReference, error) {
+	var result imageReference
+	name := reference
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, result.digest = name[:i], strings.ToLower(name[i+1:])
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, result.tag = name[:i], name[i+1:]
+	}
+	if name == "" || strings.HasSuffix(name, "/") || strings.Contains(name, "//") {
+		return imageReference{}, fmt.Errorf("invalid image reference %q", reference)
+	}
+
+	registry, path, found := strings.Cut(name, "/")
+	if !found || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		registry, path = "docker.io", name
+	}
+	if registry == "docker.io" && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+	result.repository = strings.ToLower(registry) + "/" + path
+	return result, nil
+}
+
+// matches reports whether the reference refers to the same image as the given
+// one. The tags and digests are only compared if both references specify one.
+func (r imageReference) matches(other imageReference) bool {
+	if r.repository != other.repository {
+		return false
+	}
+	if r.tag != "" && other.tag != "" && r.tag != other.tag {
+		return false
+	}
+	return r.digest == "" || other.digest == "" || r.digest == other.digest
+}
+
+// ociManifest is the part of an OCI image manifest or index, or of the
+// equivalent Docker manifest list or v2 manifest, that identifies the
+// content of the image.
+type ociManifest struct {
+	Config    *ociDescriptor  `json:"config"`
+	Layers    []ociDescriptor `json:"layers"`
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+// ociDescriptor is the part of an OCI descriptor that identifies the content.
+type ociDescriptor struct {
+	Digest string `json:"digest"`
+}
+
+// parseOCIManifest parses the given content of an image manifest or index.
+// Returns nil if the content is empty.
+func parseOCIManifest(content []byte) (*ociManifest, error) {
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("could not parse the manifest: %v", err)
+	}
+	if manifest.Config == nil && len(manifest.Manifests) == 0 {
+		return nil, fmt.Errorf("the manifest is neither an image manifest nor an index")
+	}
+	return &manifest, nil
+}
+
+// isSHA256ImageDigest reports whether the given digest of an image reference
+// is a SHA2-256 digest of the form "sha256:<hex>".
+func isSHA256ImageDigest(digest string) bool {
+	hexDigest := strings.TrimPrefix(digest, "sha256:")
+	if hexDigest == digest || len(hexDigest) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(hexDigest)
+	return err == nil
+}
+
+// describeDigest returns which part of the image the given digest is the
+// digest of, e.g., "the config digest", or an empty string if none.
+func (m *ociManifest) describeDigest(digest string) string {
+	if m == nil {
+		return ""
+	}
+	if m.Config != nil && strings.EqualFold(m.Config.Digest, digest) {
+		return "the config digest"
+	}
+	for i, layer := range m.Layers {
+		if strings.EqualFold(layer.Digest, digest) {
+			return fmt.Sprintf("the digest of layer #%d", i)
+		}
+	}
+	for i, platformManifest := range m.Manifests {
+		if strings.EqualFold(platformManifest.Digest, digest) {
+			return fmt.Sprintf("the digest of platform manifest #%d", i)
+		}
+	}
+	return ""
+}
+
+// splitBuilderVersion splits a builder ID of the form
+// "<id>@refs/tags/<version>" into the ID and the version. The version is
+// empty if the builder ID has no such suffix.
+func splitBuilderVersion(builder string) (string, string) {
+	id, ref, found := strings.Cut(builder, "@")
+	if !found {
+		return builder, ""
+	}
+	return id, strings.TrimPrefix(ref, "refs/tags/")
+}
+
+// compareVersions compares two semantic versions, optionally prefixed with
+// "v", and returns -1, 0, or 1 if `a` is respectively lower than, equal to,
+// or greater than `b`. A pre-release version is lower than the corresponding
+// release version.
+func compareVersions(a, b string) (int, error) {
+	aCore, aPre, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bCore, bPre, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range aCore {
+		if aCore[i] != bCore[i] {
+			if aCore[i] < bCore[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	switch {
+	case aPre == bPre:
+		return 0, nil
+	case aPre == "":
+		return 1, nil
+	case bPre == "":
+		return -1, nil
+	case aPre < bPre:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+// parseVersion parses a semantic version into its major, minor and patch
+// numbers, and its pre-release suffix. Missing minor and patch numbers are
+// treated as zero.
+func parseVersion(version string) ([3]int, string, error) {
+	var core [3]int
+	coreStr, pre, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	parts := strings.Split(coreStr, ".")
+	if len(parts) > len(core) {
+		return core, "", fmt.Errorf("invalid version %q", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return core, "", fmt.Errorf("invalid version %q", version)
+		}
+		core[i] = n
+	}
+	return core, pre, nil
+}
+
+// emptySHA256Digest is the hex-encoded SHA2-256 digest of empty content.
+const emptySHA256Digest = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// matchesBinaryDigests reports whether the binary in the given provenance
+// matches one of the given digests, i.e., whether the provenance lists a digest
+// of the binary with at least one of its algorithms, and all digests listed
+// with its algorithms agree.
+func matchesBinaryDigests(digests []*pb.Digest, provenance *model.ProvenanceIR) bool {
+	provenanceDigests := binaryDigestsByType(provenance)
+	for _, digest := range digests {
+		if digestMatches(digest, provenanceDigests) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlocklisted reports whether any digest of the binary in the given
+// provenance is among the given digests.
+func isBlocklisted(digests []*pb.Digest, provenance *model.ProvenanceIR) bool {
+	for digestType, digest := range binaryDigestsByType(provenance) {
+		if containsDigest(digests, digestType, digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// digestMatches reports whether the given digest and the given hex-encoded
+// digests have at least one type in common, and agree on all common types.
+func digestMatches(digest *pb.Digest, digests map[pb.Digest_Type]string) bool {
+	common := 0
+	for f, d := range digest.Binary {
+		got, found := digests[pb.Digest_Type(f)]
+		if !found {
+			continue
+		}
+		if got != hex.EncodeToString(d) {
+			return false
+		}
+		common++
+	}
+	for f, d := range digest.Hexadecimal {
+		got, found := digests[pb.Digest_Type(f)]
+		if !found {
+			continue
+		}
+		if got != canonicalHex(pb.Digest_Type(f), d) {
+			return false
+		}
+		common++
+	}
+	return common > 0
+}
+
+// sameDigests reports whether the given digests agree for all the algorithms
+// they have in common, and have at least one algorithm in common, like
+// digestMatches.
+func sameDigests(a, b map[pb.Digest_Type]string) bool {
+	common := 0
+	for digestType, digest := range a {
+		other, found := b[digestType]
+		if !found {
+			continue
+		}
+		if other != digest {
+			return false
+		}
+		common++
+	}
+	return common > 0
+}
+
+// formatDigests returns the given digests as "<type>:<hex>", ordered by
+// type, for use in error messages.
+func formatDigests(digests map[pb.Digest_Type]string) string {
+	types := make([]pb.Digest_Type, 0, len(digests))
+	for digestType := range digests {
+		types = append(types, digestType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	formatted := make([]string, 0, len(types))
+	for _, digestType := range types {
+		formatted = append(formatted, fmt.Sprintf("%s:%s", digestType, digests[digestType]))
+	}
+	return "[" + strings.Join(formatted, " ") + "]"
+}
+
+// binaryDigestsByType returns the lowercase hex-encoded digests of the binary
+// in the given provenance, keyed by their types. Digests with unknown
+// algorithms are omitted.
+func binaryDigestsByType(provenance *model.ProvenanceIR) map[pb.Digest_Type]string {
+	result := make(map[pb.Digest_Type]string)
+	if digest := provenance.BinarySHA256Digest(); digest != "" {
+		result[pb.Digest_SHA2_256] = strings.ToLower(digest)
+	}
+	digests, err := provenance.BinaryDigests()
+	if err != nil {
+		return result
+	}
+	for alg, digest := range digests {
+		digestType, found := digestTypes[strings.ToLower(alg)]
+		if !found || digest == "" {
+			continue
+		}
+		if _, found := result[digestType]; !found {
+			result[digestType] = strings.ToLower(digest)
+		}
+	}
+	return result
+}
+
+// digestTypes maps the lowercase in-toto names of digest algorithms to digest
+// types.
+//
+//nolint:gochecknoglobals
+var digestTypes = map[string]pb.Digest_Type{
+	"sha1":                                  pb.Digest_SHA1,
+	"sha256":                                pb.Digest_SHA2_256,
+	"sha2-256":                              pb.Digest_SHA2_256,
+	"sha384":                                pb.Digest_SHA2_384,
+	"sha2-384":                              pb.Digest_SHA2_384,
+	"sha512":                                pb.Digest_SHA2_512,
+	"sha2-512":                              pb.Digest_SHA2_512,
+	"sha3-224":                              pb.Digest_SHA3_224,
+	"sha3-256":                              pb.Digest_SHA3_256,
+	"sha3-384":                              pb.Digest_SHA3_384,
+	"sha3-512":                              pb.Digest_SHA3_512,
+	strings.ToLower(model.DirHashAlgorithm): pb.Digest_DIRHASH_H1,
+}
+
+// DigestType returns the digest type corresponding to the given in-toto name
+// of a digest algorithm, e.g., "sha2-256" or "sha512", and whether the
+// algorithm is known and available in this build.
+func DigestType(algorithm string) (pb.Digest_Type, bool) {
+	digestType, found := digestTypes[strings.ToLower(algorithm)]
+	if !found || !hashAvailable(digestAlgorithms[digestType]) {
+		return 0, false
+	}
+	return digestType, true
+}
+
+// flattenDigests returns the acceptable digests in the given option as a list
+// with one digest per entry, ordered by algorithm.
+func flattenDigests(anyOf *pb.VerifyAllWithBinaryDigestsAnyOf) []*pb.Digest {
+	algorithms := make([]int32, 0, len(anyOf.Digests))
+	for algorithm := range anyOf.Digests {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Slice(algorithms, func(i, j int) bool { return algorithms[i] < algorithms[j] })
+
+	var digests []*pb.Digest
+	for _, algorithm := range algorithms {
+		for _, digest := range anyOf.Digests[algorithm].GetDigests() {
+			digests = append(digests, &pb.Digest{Hexadecimal: map[int32]string{algorithm: digest}})
+		}
+	}
+	return digests
+}
+
+// binaryDirHash returns the directory tree hash of the binary in the given
+// provenance, or an empty string if the provenance does not have one.
+func binaryDirHash(provenance *model.ProvenanceIR) string {
+	digests, err := provenance.BinaryDigests()
+	if err != nil {
+		return ""
+	}
+	return digests[model.DirHashAlgorithm]
+}
+
+// binaryDigestString returns the SHA2-256 digest of the binary in the given
+// provenance for use in error messages, or its directory tree hash if it has
+// no SHA2-256 digest.
+func binaryDigestString(provenance *model.ProvenanceIR) string {
+	if digest := provenance.BinarySHA256Digest(); digest != "" {
+		return digest
+	}
+	return binaryDirHash(provenance)
+}
+
+// containsDigest reports whether the given hex-encoded digest is among the
+// entries of the given type of the given digests, in either encoding, see
+// canonicalHex. An empty digest is never contained.
+func containsDigest(digests []*pb.Digest, digestType pb.Digest_Type, digest string) bool {
+	if digest == "" {
+		return false
+	}
+	digest = strings.ToLower(digest)
+	for _, digests := range digests {
+		if d, found := digests.Binary[int32(digestType)]; found && digest == hex.EncodeToString(d) {
+			return true
+		}
+		if d, found := digests.Hexadecimal[int32(digestType)]; found && digest == canonicalHex(digestType, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalHex returns the given digest of the given type as lowercase hex.
+// If the digest is not hex-encoded, but is base64-encoded with the length
+// expected for the type, it is converted to hex. Otherwise it is returned
+// unchanged.
+func canonicalHex(digestType pb.Digest_Type, digest string) string {
+	hash, found := digestAlgorithms[digestType]
+	if !found {
+		return digest
+	}
+	if decoded, err := hex.DecodeString(digest); err == nil && len(decoded) == hash.Size() {
+		return strings.ToLower(digest)
+	}
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if decoded, err := encoding.DecodeString(digest); err == nil && len(decoded) == hash.Size() {
+			return hex.EncodeToString(decoded)
+		}
+	}
+	return digest
+}
+
+// buildTime returns the time the build finished if available, and otherwise
+// the time the build started.
+func buildTime(provenance *model.ProvenanceIR) (time.Time, error) {
+	if provenance.HasBuildFinishedOn() {
+		return provenance.BuildFinishedOn()
+	}
+	return provenance.BuildStartedOn()
+}
+
+// digestAlgorithms maps the supported digest types to the corresponding hash
+// functions.
+//
+//nolint:gochecknoglobals
+var digestAlgorithms = map[pb.Digest_Type]crypto.Hash{
+	pb.Digest_SHA1:     crypto.SHA1,
+	pb.Digest_SHA2_256: crypto.SHA256,
+	pb.Digest_SHA2_384: crypto.SHA384,
+	pb.Digest_SHA2_512: crypto.SHA512,
+	pb.Digest_SHA3_224: crypto.SHA3_224,
+	pb.Digest_SHA3_256: crypto.SHA3_256,
+	pb.Digest_SHA3_384: crypto.SHA3_384,
+	pb.Digest_SHA3_512: crypto.SHA3_512,
+	// Directory tree hashes are computed using SHA2-256.
+	pb.Digest_DIRHASH_H1: crypto.SHA256,
+}
+
+// hashAvailable reports whether the given hash function is linked into the
+// binary. It can be replaced in tests.
+//
+//nolint:gochecknoglobals
+var hashAvailable = crypto.Hash.Available
+
+// checkDigests returns an error for every malformed entry in the given
+// digests, and for every algorithm used that is not available in this build,
+// so that such digests are reported explicitly rather than silently failing to
+// match. The given name of the option is used in the errors.
+func checkDigests(name string, digests []*pb.Digest) error {
+	var errs error
+	if len(digests) == 0 {
+		errs = multierr.Append(errs, fmt.Errorf("%s must specify at least one digest", name))
+	}
+	reported := make(map[int32]bool)
+	check := func(f int32) (crypto.Hash, bool) {
+		hash, found := digestAlgorithms[pb.Digest_Type(f)]
+		if !found || !hashAvailable(hash) {
+			if !reported[f] {
+				errs = multierr.Append(errs, fmt.Errorf("algorithm %s not available in this build", pb.Digest_Type(f)))
+			}
+			reported[f] = true
+			return 0, false
+		}
+		return hash, true
+	}
+	for i, digest := range digests {
+		if len(digest.Binary) == 0 && len(digest.Hexadecimal) == 0 {
+			errs = multierr.Append(errs, fmt.Errorf("digest #%d in %s is empty", i, name))
+		}
+		for f, d := range digest.Binary {
+			if hash, ok := check(f); ok && len(d) != hash.Size() {
+				errs = multierr.Append(errs, fmt.Errorf("%s digest #%d in %s has %d bytes, want %d", pb.Digest_Type(f), i, name, len(d), hash.Size()))
+			}
+		}
+		for f, d := range digest.Hexadecimal {
+			if _, ok := check(f); !ok {
+				continue
+			}
+			if d == "" {
+				errs = multierr.Append(errs, fmt.Errorf("%s digest #%d in %s is empty", pb.Digest_Type(f), i, name))
+				continue
+			}
+			if b, found := digest.Binary[f]; found && hex.EncodeToString(b) != canonicalHex(pb.Digest_Type(f), d) {
+				errs = multierr.Append(errs, fmt.Errorf("binary and hexadecimal %s digests #%d in %s disagree", pb.Digest_Type(f), i, name))
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateVerificationOptions checks that the given VerificationOptions are
+// well-formed and consistent, e.g., that required fields are set, that the
+// provenance count bounds do not contradict each other, that all digests are
+// non-empty, use available algorithms, and agree between their binary and
+// hexadecimal encodings, and that all regular expressions compile. Returns all
+// problems found, combined using multierr, or an error if the options are nil.
+// Verify calls this before running any verification steps.
+//
+//nolint:cyclop,gocognit,gocyclo
+func ValidateVerificationOptions(verOpts *pb.VerificationOptions) error {
+	if verOpts == nil {
+		return fmt.Errorf("verification options must not be nil")
+	}
+	var errs error
+	if verOpts.ProvenanceCountAtLeast != nil && verOpts.ProvenanceCountAtMost != nil && verOpts.ProvenanceCountAtLeast.Count > verOpts.ProvenanceCountAtMost.Count {
+		errs = multierr.Append(errs, fmt.Errorf("provenance_count_at_least (%d) exceeds provenance_count_at_most (%d)", verOpts.ProvenanceCountAtLeast.Count, verOpts.ProvenanceCountAtMost.Count))
+	}
+	if verOpts.AllWithBinaryName != nil && verOpts.AllWithBinaryName.BinaryName == "" {
+		errs = multierr.Append(errs, fmt.Errorf("binary_name in all_with_binary_name must be set"))
+	}
+	if verOpts.AllWithRepository != nil && verOpts.AllWithRepository.RepositoryUri == "" {
+		errs = multierr.Append(errs, fmt.Errorf("repository_uri in all_with_repository must be set"))
+	}
+	if verOpts.AllWithBuilderNames != nil && len(verOpts.AllWithBuilderNames.BuilderNames) == 0 {
+		errs = multierr.Append(errs, fmt.Errorf("all_with_builder_names must specify at least one builder name"))
+	}
+	if verOpts.AllWithPlatform != nil && verOpts.AllWithPlatform.Os == "" && verOpts.AllWithPlatform.Architecture == "" {
+		errs = multierr.Append(errs, fmt.Errorf("all_with_platform must specify an OS or an architecture"))
+	}
+	if verOpts.AllWithBinaryDigests != nil {
+		errs = multierr.Append(errs, checkDigests("all_with_binary_digests", verOpts.AllWithBinaryDigests.Digests))
+		if verOpts.AllWithBinaryDigests.SubjectMatching == pb.VerifyAllWithBinaryDigests_NAME_AND_DIGEST && verOpts.AllWithBinaryDigests.BinaryName == "" {
+			errs = multierr.Append(errs, fmt.Errorf("binary_name in all_with_binary_digests must be set when matching by name and digest"))
+		}
+	}
+	if verOpts.AllWithBuilderDigests != nil {
+		errs = multierr.Append(errs, checkDigests("all_with_builder_digests", verOpts.AllWithBuilderDigests.Digests))
+	}
+	if d := verOpts.AllWithBuildDuration; d != nil {
+		if d.MinSeconds < 0 || d.MaxSeconds < 0 {
+			errs = multierr.Append(errs, fmt.Errorf("durations in all_with_build_duration must not be negative, got %d and %d", d.MinSeconds, d.MaxSeconds))
+		} else if d.MaxSeconds > 0 && d.MinSeconds > d.MaxSeconds {
+			errs = multierr.Append(errs, fmt.Errorf("min_seconds (%d) in all_with_build_duration exceeds max_seconds (%d)", d.MinSeconds, d.MaxSeconds))
+		}
+	}
+	if verOpts.AllSignedWithinDaysOfBuild != nil && verOpts.AllSignedWithinDaysOfBuild.Days < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("days in all_signed_within_days_of_build must not be negative, got %d", verOpts.AllSignedWithinDaysOfBuild.Days))
+	}
+	if verOpts.TrustedGenerator != nil {
+		for id, minimum := range verOpts.TrustedGenerator.MinimumVersions {
+			if _, _, err := parseVersion(minimum); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("invalid minimum version for generator %q: %v", id, err))
+			}
+		}
+	}
+	if verOpts.AllWithCiSystem != nil && verOpts.AllWithCiSystem.CiSystem == pb.VerifyAllWithCISystem_UNKNOWN {
+		errs = multierr.Append(errs, fmt.Errorf("ci_system in all_with_ci_system must be specified"))
+	}
+	if verOpts.AllWithImageReference != nil {
+		if _, err := parseImageReference(verOpts.AllWithImageReference.ImageReference); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid all_with_image_reference: %v", err))
+		}
+	}
+	if verOpts.AllWithEnvironmentDigest != nil {
+		if verOpts.AllWithEnvironmentDigest.Digest == nil {
+			errs = multierr.Append(errs, fmt.Errorf("all_with_environment_digest must specify a digest"))
+		} else {
+			errs = multierr.Append(errs, checkDigests("all_with_environment_digest", []*pb.Digest{verOpts.AllWithEnvironmentDigest.Digest}))
+		}
+	}
+	if verOpts.AllWithExternalParametersDigest != nil {
+		if verOpts.AllWithExternalParametersDigest.Digest == nil {
+			errs = multierr.Append(errs, fmt.Errorf("all_with_external_parameters_digest must specify a digest"))
+		} else {
+			errs = multierr.Append(errs, checkDigests("all_with_external_parameters_digest", []*pb.Digest{verOpts.AllWithExternalParametersDigest.Digest}))
+		}
+	}
+	if verOpts.AllWithBinaryDigestsAnyOf != nil {
+		errs = multierr.Append(errs, checkDigests("all_with_binary_digests_any_of", flattenDigests(verOpts.AllWithBinaryDigestsAnyOf)))
+	}
+	if verOpts.AllNotInDigestBlocklist != nil {
+		errs = multierr.Append(errs, checkDigests("all_not_in_digest_blocklist", verOpts.AllNotInDigestBlocklist.Digests))
+	}
+	if verOpts.AllWithMinDependencies != nil && verOpts.AllWithMinDependencies.Count < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("count in all_with_min_dependencies must not be negative, got %d", verOpts.AllWithMinDependencies.Count))
+	}
+	if verOpts.AllWithMinByproducts != nil && verOpts.AllWithMinByproducts.Count < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("count in all_with_min_byproducts must not be negative, got %d", verOpts.AllWithMinByproducts.Count))
+	}
+	if verOpts.IndependentReproduction != nil && verOpts.IndependentReproduction.Count < 2 {
+		errs = multierr.Append(errs, fmt.Errorf("count in independent_reproduction must be at least 2, got %d", verOpts.IndependentReproduction.Count))
+	}
+	if verOpts.MaxValidity != nil && verOpts.MaxValidity.Days <= 0 {
+		errs = multierr.Append(errs, fmt.Errorf("days in max_validity must be positive, got %d", verOpts.MaxValidity.Days))
+	}
+	if verOpts.AllBuiltWithinDays != nil && verOpts.AllBuiltWithinDays.Days < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("days in all_built_within_days must not be negative, got %d", verOpts.AllBuiltWithinDays.Days))
+	}
+	if verOpts.NotOlderThan != nil {
+		if _, err := time.Parse(time.RFC3339, verOpts.NotOlderThan.PriorTimestamp); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid prior_timestamp in not_older_than: %v", err))
+		}
+	}
+	if verOpts.AllWithinOwnValidity != nil && verOpts.AllWithinOwnValidity.AtTimestamp != "" {
+		if _, err := time.Parse(time.RFC3339, verOpts.AllWithinOwnValidity.AtTimestamp); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid at_timestamp in all_within_own_validity: %v", err))
+		}
+	}
+	if verOpts.AllWithBuildType != nil && len(verOpts.AllWithBuildType.Allowed)+len(verOpts.AllWithBuildType.Deprecated) == 0 {
+		errs = multierr.Append(errs, fmt.Errorf("all_with_build_type must allow at least one build type"))
+	}
+	if opt := verOpts.AllWithImageManifestDigest; opt != nil {
+		reference, err := parseImageReference(opt.ImageReference)
+		switch {
+		case err != nil:
+			errs = multierr.Append(errs, fmt.Errorf("invalid all_with_image_manifest_digest: %v", err))
+		case !isSHA256ImageDigest(reference.digest):
+			errs = multierr.Append(errs, fmt.Errorf("image_reference in all_with_image_manifest_digest must reference the image by SHA2-256 digest, got %q", opt.ImageReference))
+		case len(opt.Manifest) > 0:
+			if _, err := parseOCIManifest(opt.Manifest); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("invalid manifest in all_with_image_manifest_digest: %v", err))
+			}
+			sum := sha256.Sum256(opt.Manifest)
+			if got := "sha256:" + hex.EncodeToString(sum[:]); got != reference.digest {
+				errs = multierr.Append(errs, fmt.Errorf("the manifest in all_with_image_manifest_digest has digest %q, want %q", got, reference.digest))
+			}
+		}
+	}
+	if opt := verOpts.AllWithSourceRepo; opt != nil && opt.Uri == "" && opt.UriPrefix == "" && opt.Host == "" {
+		errs = multierr.Append(errs, fmt.Errorf("all_with_source_repo must specify at least one of uri, uri_prefix, and host"))
+	}
+	if verOpts.AllWithBuilderId != nil {
+		if len(verOpts.AllWithBuilderId.BuilderIds) == 0 {
+			errs = multierr.Append(errs, fmt.Errorf("builder_ids in all_with_builder_id must not be empty"))
+		}
+		for i, id := range verOpts.AllWithBuilderId.BuilderIds {
+			if id == "" {
+				errs = multierr.Append(errs, fmt.Errorf("empty builder ID #%d in all_with_builder_id", i))
+			}
+		}
+	}
+	if verOpts.CommitSignature != nil {
+		if len(verOpts.CommitSignature.TrustedSigners) == 0 {
+			errs = multierr.Append(errs, fmt.Errorf("trusted_signers in commit_signature must not be empty"))
+		}
+		for i, signer := range verOpts.CommitSignature.TrustedSigners {
+			if strings.TrimSpace(signer) == "" {
+				errs = multierr.Append(errs, fmt.Errorf("empty trusted signer #%d in commit_signature", i))
+			}
+		}
+	}
+	if verOpts.BuilderOrg != nil {
+		if len(verOpts.BuilderOrg.Orgs) == 0 {
+			errs = multierr.Append(errs, fmt.Errorf("orgs in builder_org must not be empty"))
+		}
+		for i, org := range verOpts.BuilderOrg.Orgs {
+			if org == "" || strings.Contains(org, "/") {
+				errs = multierr.Append(errs, fmt.Errorf("invalid org #%d in builder_org: %q", i, org))
+			}
+		}
+	}
+	fields := verOpts.ProtoReflect().Descriptor().Fields()
+	for name := range verOpts.Severities {
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil || field.Message() == nil || field.IsMap() {
+			errs = multierr.Append(errs, fmt.Errorf("severity set for unknown verification step %q", name))
+		}
+	}
+	for name := range verOpts.OnMissing {
+		if _, found := missingPolicySteps[name]; !found {
+			errs = multierr.Append(errs, fmt.Errorf("on_missing set for unsupported verification step %q", name))
+		}
+	}
+	if verOpts.BuilderSignature != nil {
+		if _, err := model.ParsePublicKey([]byte(verOpts.BuilderSignature.PublicKeyPem)); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid builder public key: %v", err))
+		}
+	}
+	if verOpts.AllWithinDocumentSize != nil && verOpts.AllWithinDocumentSize.MaxBytes <= 0 {
+		errs = multierr.Append(errs, fmt.Errorf("max_bytes in all_within_document_size must be positive, got %d", verOpts.AllWithinDocumentSize.MaxBytes))
+	}
+	if verOpts.SourceIdentity != nil {
+		for i, identity := range verOpts.SourceIdentity.Identities {
+			if identity.RepositoryUri == "" || identity.CommitSha1Digest == "" {
+				errs = multierr.Append(errs, fmt.Errorf("source identity #%d must specify both a repository URI and a commit digest", i))
+			}
+		}
+	}
+	if verOpts.AllWithBinaryNamePattern != nil {
+		if _, err := regexp.Compile(anchored(verOpts.AllWithBinaryNamePattern.Pattern)); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid binary name pattern %q: %v", verOpts.AllWithBinaryNamePattern.Pattern, err))
+		}
+	}
+	return errs
+}
+
+// anchored returns the given pattern modified to match whole strings only.
+func anchored(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+// LoadVerificationOptions loads VerificationOptions from a textproto file.
+func LoadVerificationOptions(path string) (*pb.VerificationOptions, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file from %q: %v", path, err)
+	}
+	return ParseVerificationOptions(string(bytes))
+}
+
+// LoadVerificationOptions parses VerificationOptions from textproto.
+func ParseVerificationOptions(textproto string) (*pb.VerificationOptions, error) {
+	var opts pb.VerificationOptions
+	if err := prototext.Unmarshal([]byte(textproto), &opts); err != nil {
+		return nil, fmt.Errorf("parse VerificationOptions: %v", err)
+	}
+	if err := ValidateVerificationOptions(&opts); err != nil {
+		return nil, fmt.Errorf("invalid VerificationOptions: %v", err)
 	}
 	return &opts, nil
 }
@@ -15,20 +15,40 @@
 package verifier
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/multierr"
 
 	"github.com/project-oak/transparent-release/internal/model"
+	"github.com/project-oak/transparent-release/internal/testutil"
 	slsav02 "github.com/project-oak/transparent-release/pkg/intoto/slsa_provenance/v0.2"
 	pb "github.com/project-oak/transparent-release/pkg/proto/oak/release"
 )
 
 const (
-	binaryName    = "test.txt-9b5f98310dbbad675834474fa68c37d880687cb9"
-	binaryDigest  = "322527c0260e25f0e9a2595bd0d71a52294fe2397a7af76165190fd98de8920d"
-	builderName   = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"
-	builderDigest = "9e2ba52487d945504d250de186cb4fe2e3ba023ed2921dd6ac8b97ed43e76af9"
-	repoURI       = "https://github.com/project-oak/transparent-release"
-	otherRepoURI  = "git+https://github.com/project-oak/oak@refs/heads/main"
+	binaryName       = "test.txt-9b5f98310dbbad675834474fa68c37d880687cb9"
+	binaryDigest     = "322527c0260e25f0e9a2595bd0d71a52294fe2397a7af76165190fd98de8920d"
+	builderName      = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"
+	builderDigest    = "9e2ba52487d945504d250de186cb4fe2e3ba023ed2921dd6ac8b97ed43e76af9"
+	repoURI          = "https://github.com/project-oak/transparent-release"
+	otherRepoURI     = "git+https://github.com/project-oak/oak@refs/heads/main"
+	commitDigest     = "9b5f98310dbbad675834474fa68c37d880687cb9"
+	otherCommit      = "0f2189703c57845e09d8ab89164a4f6ba6d2e1b1"
+	genericGenerator = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml"
 )
 
 func TestVerify_ProvenancesNilPanics(t *testing.T) {
@@ -42,15 +62,12 @@ func TestVerify_ProvenancesNilPanics(t *testing.T) {
 	t.Fatalf("verify ran through with err=%#v", err)
 }
 
-func TestVerify_VerificationOptionsNilPanics(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Fatalf("expected panic")
-		}
-	}()
-
+func TestVerify_VerificationOptionsNilRejected(t *testing.T) {
 	err := Verify([]model.ProvenanceIR{}, nil)
-	t.Fatalf("verify ran through with err=%#v", err)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], InvalidVerificationOptions)
 }
 
 func TestVerify_EmptyVerificationNoProvenancesPasses(t *testing.T) {
@@ -153,6 +170,58 @@ func TestVerify_SameBinaryDigestFails(t *testing.T) {
 	}
 }
 
+func TestVerify_SameBinaryDigestOtherAlgorithms(t *testing.T) {
+	sha512 := func(digest string) func(p *model.ProvenanceIR) {
+		return model.WithBinaryDigests(map[string]string{"sha512": digest})
+	}
+	tests := []struct {
+		name    string
+		first   *model.ProvenanceIR
+		second  *model.ProvenanceIR
+		wantErr bool
+	}{
+		{
+			name:   "same SHA2-512 digests",
+			first:  model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName, sha512("aa")),
+			second: model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName, sha512("aa")),
+		},
+		{
+			name:    "different SHA2-512 digests",
+			first:   model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName, sha512("aa")),
+			second:  model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName, sha512("bb")),
+			wantErr: true,
+		},
+		{
+			name:    "same SHA2-256 but different SHA2-512 digests",
+			first:   model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, sha512("aa")),
+			second:  model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, sha512("bb")),
+			wantErr: true,
+		},
+		{
+			name:    "no algorithm in common",
+			first:   model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName),
+			second:  model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName, sha512("aa")),
+			wantErr: true,
+		},
+	}
+	verOpts := pb.VerificationOptions{
+		AllSameBinaryDigest: &pb.VerifyAllSameBinaryDigest{},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify([]model.ProvenanceIR{*tt.first, *tt.second}, &verOpts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected failure")
+				}
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], DigestInconsistent)
+			} else if err != nil {
+				t.Fatalf("verify failed: %v", err)
+			}
+		})
+	}
+}
+
 func TestVerify_BuildCommandMatchSucceeds(t *testing.T) {
 	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithBuildCmd([]string{"the build cmd"}))
 	provenances := []model.ProvenanceIR{*provenance}
@@ -393,3 +462,2107 @@ func TestVerify_RepoURIEmptyMismatchDetected(t *testing.T) {
 		t.Fatalf("expected failure")
 	}
 }
+
+func TestVerify_BinaryNamePatternMatchSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, "app-1.2.3-linux-amd64")
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryNamePattern: &pb.VerifyAllWithBinaryNamePattern{Pattern: `app-[0-9.]+-linux-amd64`},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_BinaryNamePatternMismatchDetected(t *testing.T) {
+	// NB: The pattern must match the whole binary name, not just a prefix.
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, "app-1.2.3-linux-amd64.sig")
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryNamePattern: &pb.VerifyAllWithBinaryNamePattern{Pattern: `app-[0-9.]+-linux-amd64`},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_BinaryNamePatternInvalidDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryNamePattern: &pb.VerifyAllWithBinaryNamePattern{Pattern: `app-[0-9`},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestParseVerificationOptions_InvalidBinaryNamePatternRejected(t *testing.T) {
+	if _, err := ParseVerificationOptions(`all_with_binary_name_pattern { pattern: "app-(" }`); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_InvocationParametersMatchSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithInvocationParameters(map[string]string{"arch": "x86_64", "debug": "false"}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithInvocationParameters: &pb.VerifyAllWithInvocationParameters{
+			Parameters: map[string]string{"arch": "x86_64"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_InvocationParametersMissingDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithInvocationParameters(map[string]string{"debug": "false"}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithInvocationParameters: &pb.VerifyAllWithInvocationParameters{
+			Parameters: map[string]string{"arch": "x86_64"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_InvocationParametersAbsentDetected(t *testing.T) {
+	// NB: No invocation parameters in the provenance, this counts as missing.
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithInvocationParameters: &pb.VerifyAllWithInvocationParameters{
+			Parameters: map[string]string{"arch": "x86_64"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_InvocationParametersMismatchDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithInvocationParameters(map[string]string{"arch": "aarch64"}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithInvocationParameters: &pb.VerifyAllWithInvocationParameters{
+			Parameters: map[string]string{"arch": "x86_64"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_SignedWithinDaysOfBuildSucceeds(t *testing.T) {
+	builtOn := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuildFinishedOn(builtOn),
+		model.WithSignedOn(builtOn.Add(2*time.Hour)))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllSignedWithinDaysOfBuild: &pb.VerifyAllSignedWithinDaysOfBuild{Days: 1},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_SignedLongAfterBuildDetected(t *testing.T) {
+	builtOn := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuildStartedOn(builtOn),
+		model.WithSignedOn(builtOn.AddDate(0, 0, 30)))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllSignedWithinDaysOfBuild: &pb.VerifyAllSignedWithinDaysOfBuild{Days: 7},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_SignedWithinDaysOfBuildNoSigningTimeDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuildFinishedOn(time.Now()))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllSignedWithinDaysOfBuild: &pb.VerifyAllSignedWithinDaysOfBuild{Days: 7},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_TrustedGeneratorAtMinimumSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithTrustedBuilder(builderName))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		TrustedGenerator: &pb.VerifyTrustedGenerator{
+			MinimumVersions: map[string]string{genericGenerator: "v1.2.0"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_TrustedGeneratorAboveMinimumSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithTrustedBuilder(builderName))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		TrustedGenerator: &pb.VerifyTrustedGenerator{
+			MinimumVersions: map[string]string{genericGenerator: "v1.1.10"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_TrustedGeneratorBelowMinimumDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithTrustedBuilder(builderName))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		TrustedGenerator: &pb.VerifyTrustedGenerator{
+			MinimumVersions: map[string]string{genericGenerator: "v1.2.1-rc.0"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_TrustedGeneratorUnknownDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithTrustedBuilder(builderName))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		TrustedGenerator: &pb.VerifyTrustedGenerator{
+			MinimumVersions: map[string]string{"https://example.com/other-generator": "v1.0.0"},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_SourceIdentityMatchSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithRepoURI(repoURI), model.WithCommitSHA1Digest(commitDigest))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		SourceIdentity: &pb.VerifySourceIdentity{
+			Identities: []*pb.SourceIdentity{
+				{RepositoryUri: otherRepoURI, CommitSha1Digest: otherCommit},
+				{RepositoryUri: repoURI, CommitSha1Digest: commitDigest},
+			},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_SourceIdentityPartialMatchDetected(t *testing.T) {
+	// The repository matches the first identity, and the commit matches the
+	// second one, but neither identity matches fully.
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithRepoURI(repoURI), model.WithCommitSHA1Digest(commitDigest))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		SourceIdentity: &pb.VerifySourceIdentity{
+			Identities: []*pb.SourceIdentity{
+				{RepositoryUri: repoURI, CommitSha1Digest: otherCommit},
+				{RepositoryUri: otherRepoURI, CommitSha1Digest: commitDigest},
+			},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_SourceIdentityMissingCommitDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithRepoURI(repoURI))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		SourceIdentity: &pb.VerifySourceIdentity{
+			Identities: []*pb.SourceIdentity{{RepositoryUri: repoURI, CommitSha1Digest: commitDigest}},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_SourceIdentityIncompleteRejected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithRepoURI(repoURI))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		SourceIdentity: &pb.VerifySourceIdentity{
+			Identities: []*pb.SourceIdentity{{RepositoryUri: repoURI}},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_BinaryDigestUnavailableAlgorithmDetected(t *testing.T) {
+	// Simulate a build in which SHA3-256 is not linked in.
+	defer func(original func(crypto.Hash) bool) { hashAvailable = original }(hashAvailable)
+	hashAvailable = func(h crypto.Hash) bool { return h != crypto.SHA3_256 }
+
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA3_256): binaryDigest}},
+			},
+		},
+	}
+
+	err := Verify(provenances, &verOpts)
+	want := "algorithm SHA3_256 not available in this build"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %q, want error message containing %q", err, want)
+	}
+}
+
+func TestVerify_BuilderDigestUnknownAlgorithmDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithBuilderImageSHA256Digest(builderDigest))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBuilderDigests: &pb.VerifyAllWithBuilderDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{0x99: builderDigest}},
+			},
+		},
+	}
+
+	err := Verify(provenances, &verOpts)
+	want := "algorithm 153 not available in this build"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %q, want error message containing %q", err, want)
+	}
+}
+
+func TestVerify_AllReproducibleSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithReproducible(true))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllReproducible: &pb.VerifyAllReproducible{},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_AllReproducibleMismatchDetected(t *testing.T) {
+	reproducible := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithReproducible(true))
+	notReproducible := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithReproducible(false))
+	provenances := []model.ProvenanceIR{*reproducible, *notReproducible}
+	verOpts := pb.VerificationOptions{
+		AllReproducible: &pb.VerifyAllReproducible{},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_AllReproducibleAbsentDetected(t *testing.T) {
+	// NB: No reproducibility claim in the provenance, this counts as mismatch.
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllReproducible: &pb.VerifyAllReproducible{},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_DocumentSizeWithinLimitSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithDocumentSize(1024))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithinDocumentSize: &pb.VerifyAllWithinDocumentSize{MaxBytes: 1024},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_DocumentSizeExceededDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithDocumentSize(1025))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithinDocumentSize: &pb.VerifyAllWithinDocumentSize{MaxBytes: 1024},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_DocumentSizeUnknownOk(t *testing.T) {
+	// NB: No document size in the provenance, so there is nothing to check.
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithinDocumentSize: &pb.VerifyAllWithinDocumentSize{MaxBytes: 1024},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+// newBuilderKey returns a fresh Ed25519 private key, and the corresponding
+// public key in PEM format.
+func newBuilderKey(t *testing.T) (ed25519.PrivateKey, string) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatalf("could not marshal public key: %v", err)
+	}
+	return private, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerify_BuilderSignatureValidSucceeds(t *testing.T) {
+	private, publicKeyPEM := newBuilderKey(t)
+	data := []byte(`{"buildType":"test"}`)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuilderSignature(model.BuilderSignature{SignedData: data, Signature: ed25519.Sign(private, data)}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		BuilderSignature: &pb.VerifyBuilderSignature{PublicKeyPem: publicKeyPEM, Required: true},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_BuilderSignatureInvalidDetected(t *testing.T) {
+	_, publicKeyPEM := newBuilderKey(t)
+	otherPrivate, _ := newBuilderKey(t)
+	data := []byte(`{"buildType":"test"}`)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuilderSignature(model.BuilderSignature{SignedData: data, Signature: ed25519.Sign(otherPrivate, data)}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		BuilderSignature: &pb.VerifyBuilderSignature{PublicKeyPem: publicKeyPEM},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_BuilderSignatureAbsentOk(t *testing.T) {
+	_, publicKeyPEM := newBuilderKey(t)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		BuilderSignature: &pb.VerifyBuilderSignature{PublicKeyPem: publicKeyPEM},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_BuilderSignatureRequiredAbsentDetected(t *testing.T) {
+	_, publicKeyPEM := newBuilderKey(t)
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		BuilderSignature: &pb.VerifyBuilderSignature{PublicKeyPem: publicKeyPEM, Required: true},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_BuilderSignatureInvalidKeyRejected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		BuilderSignature: &pb.VerifyBuilderSignature{PublicKeyPem: "not a key"},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_BinaryDigestAnywhereDifferentNameSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName+"-linux-amd64")
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+			},
+			SubjectMatching: pb.VerifyAllWithBinaryDigests_DIGEST_ANYWHERE,
+			BinaryName:      binaryName,
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+}
+
+func TestVerify_BinaryDigestNameAndDigestDifferentNameDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName+"-linux-amd64")
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+			},
+			SubjectMatching: pb.VerifyAllWithBinaryDigests_NAME_AND_DIGEST,
+			BinaryName:      binaryName,
+		},
+	}
+
+	err := Verify(provenances, &verOpts)
+	want := "unexpected subject"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %q, want error message containing %q", err, want)
+	}
+}
+
+func TestVerify_BinaryDigestNameAndDigestMatchSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+			},
+			SubjectMatching: pb.VerifyAllWithBinaryDigests_NAME_AND_DIGEST,
+			BinaryName:      binaryName,
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+}
+
+func TestVerify_BinaryDigestNameAndDigestWithoutNameRejected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+			},
+			SubjectMatching: pb.VerifyAllWithBinaryDigests_NAME_AND_DIGEST,
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_DistinctSignersSucceeds(t *testing.T) {
+	first := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithSigners([]string{"sha256:aaaa"}))
+	second := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithSigners([]string{"sha256:bbbb", "sha256:aaaa"}))
+	provenances := []model.ProvenanceIR{*first, *second}
+	verOpts := pb.VerificationOptions{
+		DistinctSigners: &pb.VerifyDistinctSigners{Count: 2},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_DistinctSignersAllSameDetected(t *testing.T) {
+	first := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithSigners([]string{"sha256:aaaa"}))
+	second := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithSigners([]string{"sha256:aaaa"}))
+	// NB: No signers known for this one, so it does not contribute.
+	third := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*first, *second, *third}
+	verOpts := pb.VerificationOptions{
+		DistinctSigners: &pb.VerifyDistinctSigners{Count: 2},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_OCIAnnotationsMatchSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithOCIAnnotations(map[string]string{
+			"org.opencontainers.image.source":  repoURI,
+			"org.opencontainers.image.version": "1.2.3",
+		}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithOciAnnotations: &pb.VerifyAllWithOCIAnnotations{
+			Annotations: map[string]string{"org.opencontainers.image.source": repoURI},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_OCIAnnotationsMissingDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithOCIAnnotations(map[string]string{"org.opencontainers.image.version": "1.2.3"}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithOciAnnotations: &pb.VerifyAllWithOCIAnnotations{
+			Annotations: map[string]string{"org.opencontainers.image.source": repoURI},
+		},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	if codes := ReasonCodes(err); len(codes) != 1 || codes[0] != OCIAnnotationMissing {
+		t.Fatalf("got reason codes %v, want [%v]", codes, OCIAnnotationMissing)
+	}
+}
+
+func TestVerify_OCIAnnotationsMismatchDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithOCIAnnotations(map[string]string{"org.opencontainers.image.source": otherRepoURI}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithOciAnnotations: &pb.VerifyAllWithOCIAnnotations{
+			Annotations: map[string]string{"org.opencontainers.image.source": repoURI},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_BuiltWithinDaysSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuildFinishedOn(time.Now().AddDate(0, 0, -1)))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 7},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_BuiltWithinDaysStaleDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuildFinishedOn(time.Now().AddDate(0, 0, -8)))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 7},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerifyWithWarnings_WarnSeverityIsNotFatal(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuildFinishedOn(time.Now().AddDate(0, 0, -8)))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 7},
+		AllWithBinaryName:  &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+		Severities:         map[string]pb.Severity{"all_built_within_days": pb.Severity_WARN},
+	}
+
+	warnings, err := VerifyWithWarnings(provenances, &verOpts)
+	if err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+	testutil.AssertEq(t, "number of warnings", len(warnings), 1)
+	testutil.AssertEq(t, "warning reason code", ReasonCodes(warnings[0])[0], ProvenanceNotFresh)
+}
+
+func TestVerifyWithWarnings_ErrorSeverityIsFatal(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: "other"},
+		Severities:        map[string]pb.Severity{"all_with_binary_name": pb.Severity_ERROR},
+	}
+
+	if _, err := VerifyWithWarnings(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_SeverityForUnknownStepRejected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		Severities: map[string]pb.Severity{"no_such_step": pb.Severity_WARN},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_NotInDigestBlocklistSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllNotInDigestBlocklist: &pb.VerifyAllNotInDigestBlocklist{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): builderDigest}},
+			},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_BlocklistedDigestDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllNotInDigestBlocklist: &pb.VerifyAllNotInDigestBlocklist{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): builderDigest}},
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+			},
+		},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], DigestBlocklisted)
+}
+
+func TestVerify_CISystemGitHubActions(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithTrustedBuilder("https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.2.0"))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithCiSystem: &pb.VerifyAllWithCISystem{CiSystem: pb.VerifyAllWithCISystem_GITHUB_ACTIONS},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_CISystemGitLabCI(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, "https://gitlab.example.com/buildtypes/generic", binaryName,
+		model.WithTrustedBuilder("https://gitlab.com/gitlab-org/gitlab-runner"))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithCiSystem: &pb.VerifyAllWithCISystem{CiSystem: pb.VerifyAllWithCISystem_GITLAB_CI},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_CISystemMismatchDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithTrustedBuilder("https://gitlab.com/gitlab-org/gitlab-runner"))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithCiSystem: &pb.VerifyAllWithCISystem{CiSystem: pb.VerifyAllWithCISystem_GITHUB_ACTIONS},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], CISystemMismatch)
+}
+
+func TestVerify_EmptyArtifactAllowed(t *testing.T) {
+	provenance := model.NewProvenanceIR(emptySHA256Digest, slsav02.GenericSLSABuildType, "metadata-only")
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): emptySHA256Digest}},
+			},
+			SubjectMatching: pb.VerifyAllWithBinaryDigests_NAME_AND_DIGEST,
+			BinaryName:      binaryName,
+		},
+		EmptyArtifacts: &pb.VerifyEmptyArtifacts{Allow: true},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_EmptyArtifactAllowedNotPinned(t *testing.T) {
+	provenance := model.NewProvenanceIR(emptySHA256Digest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+			},
+		},
+		EmptyArtifacts: &pb.VerifyEmptyArtifacts{Allow: true},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], DigestMismatch)
+}
+
+func TestVerify_EmptyArtifactDisallowed(t *testing.T) {
+	provenance := model.NewProvenanceIR(emptySHA256Digest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): emptySHA256Digest}},
+			},
+		},
+		EmptyArtifacts: &pb.VerifyEmptyArtifacts{Allow: false},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason codes", len(ReasonCodes(err)), 1)
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], EmptyArtifact)
+}
+
+func TestVerify_CanonicalDependenciesSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithResolvedDependencies([]model.Dependency{
+			{URI: "docker://builder", Digest: map[string]string{"sha256": builderDigest}},
+			{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": commitDigest}},
+		}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithCanonicalDependencies: &pb.VerifyAllWithCanonicalDependencies{},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_NonCanonicalDependenciesDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithResolvedDependencies([]model.Dependency{
+			{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": commitDigest}},
+			{URI: "docker://builder", Digest: map[string]string{"sha256": builderDigest}},
+		}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithCanonicalDependencies: &pb.VerifyAllWithCanonicalDependencies{},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], DependenciesNotCanonical)
+}
+
+func TestVerify_DirectoryTreeHashMatchSucceeds(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app"), []byte("app"), 0o600); err != nil {
+		t.Fatalf("Could not write file: %v", err)
+	}
+	dirHash, err := model.ComputeDirectoryTreeHash(dir)
+	if err != nil {
+		t.Fatalf("Could not compute the tree hash: %v", err)
+	}
+	provenance := model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName,
+		model.WithBinaryDigests(map[string]string{model.DirHashAlgorithm: dirHash}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}},
+				{Hexadecimal: map[int32]string{int32(pb.Digest_DIRHASH_H1): dirHash}},
+			},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_DirectoryTreeHashMismatchDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR("", slsav02.GenericSLSABuildType, binaryName,
+		model.WithBinaryDigests(map[string]string{model.DirHashAlgorithm: builderDigest}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{
+				// The tree hash is not matched against SHA2-256 digests.
+				{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): builderDigest}},
+				{Hexadecimal: map[int32]string{int32(pb.Digest_DIRHASH_H1): binaryDigest}},
+			},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestValidateVerificationOptions_MalformedOptionsRejected(t *testing.T) {
+	sha256Bytes, err := hex.DecodeString(binaryDigest)
+	if err != nil {
+		t.Fatalf("Could not decode digest: %v", err)
+	}
+	tests := []struct {
+		name    string
+		verOpts *pb.VerificationOptions
+		errors  int
+	}{
+		{
+			name: "contradictory provenance counts",
+			verOpts: &pb.VerificationOptions{
+				ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 3},
+				ProvenanceCountAtMost:  &pb.VerifyProvenanceCountAtMost{Count: 2},
+			},
+			errors: 1,
+		},
+		{
+			name: "missing required fields",
+			verOpts: &pb.VerificationOptions{
+				AllWithBinaryName:   &pb.VerifyAllWithBinaryName{},
+				AllWithRepository:   &pb.VerifyAllWithRepository{},
+				AllWithBuilderNames: &pb.VerifyAllWithBuilderNames{},
+			},
+			errors: 3,
+		},
+		{
+			name: "no digests",
+			verOpts: &pb.VerificationOptions{
+				AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{},
+			},
+			errors: 1,
+		},
+		{
+			name: "empty digest",
+			verOpts: &pb.VerificationOptions{
+				AllWithBuilderDigests: &pb.VerifyAllWithBuilderDigests{
+					Digests: []*pb.Digest{{}},
+				},
+			},
+			errors: 1,
+		},
+		{
+			name: "disagreeing and truncated encodings",
+			verOpts: &pb.VerificationOptions{
+				AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+					Digests: []*pb.Digest{
+						{
+							Binary:      map[int32][]byte{int32(pb.Digest_SHA2_256): sha256Bytes},
+							Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): builderDigest},
+						},
+						{Binary: map[int32][]byte{int32(pb.Digest_SHA2_256): sha256Bytes[:16]}},
+					},
+				},
+			},
+			errors: 2,
+		},
+		{
+			name: "unknown algorithm",
+			verOpts: &pb.VerificationOptions{
+				AllNotInDigestBlocklist: &pb.VerifyAllNotInDigestBlocklist{
+					Digests: []*pb.Digest{{Hexadecimal: map[int32]string{12345: binaryDigest}}},
+				},
+			},
+			errors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVerificationOptions(tt.verOpts)
+			testutil.AssertEq(t, "number of errors", len(multierr.Errors(err)), tt.errors)
+		})
+	}
+}
+
+func TestValidateVerificationOptions_WellFormedOptionsAccepted(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 1},
+		ProvenanceCountAtMost:  &pb.VerifyProvenanceCountAtMost{Count: 1},
+		AllWithBinaryName:      &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): binaryDigest}}},
+		},
+	}
+
+	if err := ValidateVerificationOptions(&verOpts); err != nil {
+		t.Fatalf("got %v, want no error", err)
+	}
+}
+
+func TestVerify_MalformedOptionsRejected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 2},
+		ProvenanceCountAtMost:  &pb.VerifyProvenanceCountAtMost{Count: 1},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], InvalidVerificationOptions)
+}
+
+func TestVerifyWithCallback_VerificationOptionsNilRejected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+
+	if _, err := VerifyWithWarnings(provenances, nil); err == nil || ReasonCodes(err)[0] != InvalidVerificationOptions {
+		t.Errorf("got %v, want an error with reason code %s", err, InvalidVerificationOptions)
+	}
+	called := false
+	err := VerifyWithCallback(provenances, nil, func(int, error) { called = true })
+	if err == nil || ReasonCodes(err)[0] != InvalidVerificationOptions {
+		t.Errorf("got %v, want an error with reason code %s", err, InvalidVerificationOptions)
+	}
+	testutil.AssertEq(t, "callback called", called, false)
+}
+
+func TestVerify_PlatformMatchSucceeds(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithPlatform(model.Platform{OS: "linux", Architecture: "amd64"}))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithPlatform: &pb.VerifyAllWithPlatform{Os: "Linux", Architecture: "x86_64"},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed, got %v", err)
+	}
+}
+
+func TestVerify_PlatformMismatchDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithPlatform(model.Platform{OS: "linux", Architecture: "arm64"}))
+	withoutPlatform := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance, *withoutPlatform}
+	verOpts := pb.VerificationOptions{
+		AllWithPlatform: &pb.VerifyAllWithPlatform{Architecture: "amd64"},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	codes := ReasonCodes(err)
+	testutil.AssertEq(t, "number of reason codes", len(codes), 2)
+	testutil.AssertEq(t, "first reason code", codes[0], PlatformMismatch)
+	testutil.AssertEq(t, "second reason code", codes[1], PlatformMissing)
+}
+
+func TestVerify_BinaryDigestBase64AndHexMatch(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	digestBytes, err := hex.DecodeString(binaryDigest)
+	if err != nil {
+		t.Fatalf("Could not decode digest: %v", err)
+	}
+
+	for _, encoded := range []string{
+		binaryDigest,
+		strings.ToUpper(binaryDigest),
+		base64.StdEncoding.EncodeToString(digestBytes),
+		base64.RawURLEncoding.EncodeToString(digestBytes),
+	} {
+		verOpts := pb.VerificationOptions{
+			AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+				Digests: []*pb.Digest{{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): encoded}}},
+			},
+		}
+		if err := Verify(provenances, &verOpts); err != nil {
+			t.Errorf("verify failed for %q: %v", encoded, err)
+		}
+	}
+}
+
+func TestVerify_BinaryDigestBase64MismatchDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	digestBytes, err := hex.DecodeString(builderDigest)
+	if err != nil {
+		t.Fatalf("Could not decode digest: %v", err)
+	}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): base64.StdEncoding.EncodeToString(digestBytes)}}},
+		},
+	}
+
+	if err := Verify(provenances, &verOpts); err == nil {
+		t.Fatalf("expected failure")
+	}
+}
+
+func TestVerify_MinDependencies(t *testing.T) {
+	dependencies := []model.Dependency{
+		{URI: "docker://builder", Digest: map[string]string{"sha256": builderDigest}},
+		{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": commitDigest}},
+	}
+	verOpts := pb.VerificationOptions{
+		AllWithMinDependencies: &pb.VerifyAllWithMinDependencies{Count: 2},
+	}
+
+	tests := []struct {
+		name    string
+		count   int
+		wantErr bool
+	}{
+		{name: "below the minimum", count: 1, wantErr: true},
+		{name: "at the minimum", count: 2, wantErr: false},
+		{name: "above the minimum", count: 3, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := make([]model.Dependency, 0, tt.count)
+			for i := 0; i < tt.count; i++ {
+				deps = append(deps, dependencies[i%len(dependencies)])
+			}
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+				model.WithResolvedDependencies(deps))
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+		})
+	}
+}
+
+func TestVerify_MinDependenciesWithoutDependenciesDetected(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithMinDependencies: &pb.VerifyAllWithMinDependencies{Count: 1},
+	}
+
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], TooFewDependencies)
+}
+
+func TestVerify_IndependentReproduction(t *testing.T) {
+	provenances := []model.ProvenanceIR{
+		*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+			model.WithCommitSHA1Digest(commitDigest), model.WithInvocationID("run-1")),
+		*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+			model.WithCommitSHA1Digest(commitDigest), model.WithInvocationID("run-2")),
+	}
+	verOpts := pb.VerificationOptions{
+		IndependentReproduction: &pb.VerifyIndependentReproduction{Count: 2},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+}
+
+func TestVerify_IndependentReproductionDetected(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		IndependentReproduction: &pb.VerifyIndependentReproduction{Count: 2},
+	}
+
+	tests := []struct {
+		name        string
+		provenances []model.ProvenanceIR
+	}{
+		{
+			name: "same invocation ID",
+			provenances: []model.ProvenanceIR{
+				*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+					model.WithCommitSHA1Digest(commitDigest), model.WithInvocationID("run-1")),
+				*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+					model.WithCommitSHA1Digest(commitDigest), model.WithInvocationID("run-1")),
+			},
+		},
+		{
+			name: "different commits",
+			provenances: []model.ProvenanceIR{
+				*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+					model.WithCommitSHA1Digest(commitDigest), model.WithInvocationID("run-1")),
+				*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+					model.WithCommitSHA1Digest("another_commit"), model.WithInvocationID("run-2")),
+			},
+		},
+		{
+			name: "no invocation IDs",
+			provenances: []model.ProvenanceIR{
+				*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+					model.WithCommitSHA1Digest(commitDigest)),
+				*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+					model.WithCommitSHA1Digest(commitDigest)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.provenances, &verOpts)
+			if err == nil {
+				t.Fatalf("expected failure")
+			}
+			testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], NotIndependentlyReproduced)
+		})
+	}
+}
+
+func TestVerifyWithCallback(t *testing.T) {
+	provenances := []model.ProvenanceIR{
+		*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName),
+		*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, "other_binary"),
+		*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName),
+	}
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryName:      &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 3},
+	}
+
+	var indices []int
+	var failed []bool
+	err := VerifyWithCallback(provenances, &verOpts, func(index int, err error) {
+		indices = append(indices, index)
+		failed = append(failed, err != nil)
+	})
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "callback count", len(indices), len(provenances))
+	for i := range provenances {
+		testutil.AssertEq(t, "index", indices[i], i)
+	}
+	testutil.AssertEq(t, "#0 failed", failed[0], false)
+	testutil.AssertEq(t, "#1 failed", failed[1], true)
+	testutil.AssertEq(t, "#2 failed", failed[2], false)
+}
+
+func TestVerify_BinaryDigestsAnyOf(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*provenance}
+
+	tests := []struct {
+		name    string
+		digests []string
+		wantErr bool
+	}{
+		{name: "matching one", digests: []string{builderDigest, binaryDigest}, wantErr: false},
+		{name: "matching none", digests: []string{builderDigest, emptySHA256Digest}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verOpts := pb.VerificationOptions{
+				AllWithBinaryDigestsAnyOf: &pb.VerifyAllWithBinaryDigestsAnyOf{
+					Digests: map[int32]*pb.VerifyAllWithBinaryDigestsAnyOf_Digests{
+						int32(pb.Digest_SHA2_256): {Digests: tt.digests},
+					},
+				},
+			}
+			err := Verify(provenances, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], DigestMismatch)
+			}
+		})
+	}
+}
+
+func TestVerify_EnvironmentDigest(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		AllWithEnvironmentDigest: &pb.VerifyAllWithEnvironmentDigest{
+			Digest: &pb.Digest{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): builderDigest}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		options  []func(p *model.ProvenanceIR)
+		wantCode ReasonCode
+	}{
+		{name: "matching", options: []func(p *model.ProvenanceIR){model.WithEnvironmentSHA256Digest(builderDigest)}},
+		{name: "mismatched", options: []func(p *model.ProvenanceIR){model.WithEnvironmentSHA256Digest(binaryDigest)}, wantCode: EnvironmentDigestMismatch},
+		{name: "missing", wantCode: EnvironmentDigestMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, tt.options...)
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("verify failed: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected failure")
+			}
+			testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+		})
+	}
+}
+
+func TestVerify_ExternalParametersDigest(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		AllWithExternalParametersDigest: &pb.VerifyAllWithExternalParametersDigest{
+			Digest: &pb.Digest{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_256): builderDigest}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		options  []func(p *model.ProvenanceIR)
+		wantCode ReasonCode
+	}{
+		{name: "matching", options: []func(p *model.ProvenanceIR){model.WithExternalParametersSHA256Digest(builderDigest)}},
+		{name: "mismatched", options: []func(p *model.ProvenanceIR){model.WithExternalParametersSHA256Digest(binaryDigest)}, wantCode: ExternalParametersDigestMismatch},
+		{name: "missing", wantCode: ExternalParametersDigestMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, tt.options...)
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("verify failed: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected failure")
+			}
+			testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+		})
+	}
+}
+
+func TestVerify_ImageReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		subject  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "same reference", subject: "ghcr.io/project-oak/oak:v1", expected: "ghcr.io/project-oak/oak:v1"},
+		{name: "digest instead of tag", subject: "ghcr.io/project-oak/oak@sha256:" + binaryDigest, expected: "ghcr.io/project-oak/oak:v1"},
+		{name: "no tag expected", subject: "ghcr.io/project-oak/oak:v1", expected: "ghcr.io/project-oak/oak"},
+		{name: "default registry", subject: "docker.io/library/debian:12", expected: "debian:12"},
+		{name: "registry with port", subject: "localhost:5000/oak:v1", expected: "localhost:5000/oak"},
+		{name: "different tag", subject: "ghcr.io/project-oak/oak:v2", expected: "ghcr.io/project-oak/oak:v1", wantErr: true},
+		{name: "different repository", subject: "ghcr.io/project-oak/other:v1", expected: "ghcr.io/project-oak/oak:v1", wantErr: true},
+		{name: "different registry", subject: "docker.io/project-oak/oak:v1", expected: "ghcr.io/project-oak/oak:v1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, tt.subject)
+			verOpts := pb.VerificationOptions{
+				AllWithImageReference: &pb.VerifyAllWithImageReference{ImageReference: tt.expected},
+			}
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], ImageReferenceMismatch)
+			}
+		})
+	}
+}
+
+func TestVerify_SourceMaterial(t *testing.T) {
+	repoURI := "git+https://github.com/project-oak/oak"
+	verOpts := pb.VerificationOptions{
+		AllWithSourceMaterial: &pb.VerifyAllWithSourceMaterial{},
+	}
+
+	tests := []struct {
+		name         string
+		dependencies []model.Dependency
+		wantErr      bool
+	}{
+		{
+			name: "source material",
+			dependencies: []model.Dependency{
+				{URI: "docker://builder", Digest: map[string]string{"sha256": builderDigest}},
+				{URI: repoURI + "@refs/heads/main", Digest: map[string]string{"sha1": commitDigest}},
+			},
+		},
+		{
+			name:         "source material with another commit",
+			dependencies: []model.Dependency{{URI: repoURI, Digest: map[string]string{"sha1": "another_commit"}}},
+			wantErr:      true,
+		},
+		{
+			name:         "no source material",
+			dependencies: []model.Dependency{{URI: "docker://builder", Digest: map[string]string{"sha256": builderDigest}}},
+			wantErr:      true,
+		},
+		{
+			name:    "no materials",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := []func(p *model.ProvenanceIR){model.WithRepoURI(repoURI), model.WithCommitSHA1Digest(commitDigest)}
+			if tt.dependencies != nil {
+				options = append(options, model.WithResolvedDependencies(tt.dependencies))
+			}
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, options...)
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], SourceMaterialMissing)
+			}
+		})
+	}
+}
+
+func TestVerify_BinaryDigestsConflictingAlgorithmsDetected(t *testing.T) {
+	sum512 := sha512.Sum512([]byte("binary"))
+	sha512Digest := hex.EncodeToString(sum512[:])
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBinaryDigests(map[string]string{"sha256": binaryDigest, "sha512": sha512Digest}))
+	provenances := []model.ProvenanceIR{*provenance}
+
+	// Matching on SHA2-512 alone succeeds.
+	verOpts := pb.VerificationOptions{
+		AllWithBinaryDigests: &pb.VerifyAllWithBinaryDigests{
+			Digests: []*pb.Digest{{Hexadecimal: map[int32]string{int32(pb.Digest_SHA2_512): sha512Digest}}},
+		},
+	}
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+
+	// A matching SHA2-512 digest does not make up for a mismatched SHA2-256 one.
+	verOpts.AllWithBinaryDigests.Digests[0].Hexadecimal[int32(pb.Digest_SHA2_256)] = builderDigest
+	err := Verify(provenances, &verOpts)
+	if err == nil {
+		t.Fatalf("expected failure")
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], DigestMismatch)
+}
+
+func TestVerify_BuildDuration(t *testing.T) {
+	startedOn := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	verOpts := pb.VerificationOptions{
+		AllWithBuildDuration: &pb.VerifyAllWithBuildDuration{MinSeconds: 60, MaxSeconds: 3600},
+	}
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		wantErr  bool
+	}{
+		{name: "within the range", duration: 10 * time.Minute},
+		{name: "at the maximum", duration: time.Hour},
+		{name: "too fast", duration: 30 * time.Second, wantErr: true},
+		{name: "too slow", duration: 2 * time.Hour, wantErr: true},
+		{name: "finished before started", duration: -10 * time.Minute, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+				model.WithBuildStartedOn(startedOn), model.WithBuildFinishedOn(startedOn.Add(tt.duration)))
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], BuildDurationOutOfRange)
+			}
+		})
+	}
+}
+
+func TestVerify_BuildDurationWithoutTimestampsSkipped(t *testing.T) {
+	provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithBuildStartedOn(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)))
+	provenances := []model.ProvenanceIR{*provenance}
+	verOpts := pb.VerificationOptions{
+		AllWithBuildDuration: &pb.VerifyAllWithBuildDuration{MinSeconds: 60},
+	}
+
+	if err := Verify(provenances, &verOpts); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+}
+
+func TestVerify_AllHaveInvocationID(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		AllHaveInvocationId: &pb.VerifyAllHaveInvocationID{},
+	}
+
+	tests := []struct {
+		name    string
+		options []func(p *model.ProvenanceIR)
+		wantErr bool
+	}{
+		{name: "with invocation ID", options: []func(p *model.ProvenanceIR){model.WithInvocationID("run-1")}},
+		{name: "with empty invocation ID", options: []func(p *model.ProvenanceIR){model.WithInvocationID("")}, wantErr: true},
+		{name: "without invocation ID", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, tt.options...)
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], InvocationIDMissing)
+			}
+		})
+	}
+}
+
+func TestVerify_MaterialsComplete(t *testing.T) {
+	v02Bytes, err := os.ReadFile("../../testdata/slsa_v02_provenance.json")
+	if err != nil {
+		t.Fatalf("could not read provenance: %v", err)
+	}
+	v1Bytes, err := os.ReadFile("../../testdata/slsa_v1_provenance.json")
+	if err != nil {
+		t.Fatalf("could not read provenance: %v", err)
+	}
+	completeBytes := []byte(strings.Replace(string(v02Bytes), `"materials": false`, `"materials": true`, 1))
+	verOpts := pb.VerificationOptions{
+		MaterialsComplete: &pb.VerifyMaterialsComplete{},
+	}
+
+	tests := []struct {
+		name            string
+		provenanceBytes []byte
+		wantErr         bool
+	}{
+		{name: "SLSA v0.2 with complete materials", provenanceBytes: completeBytes},
+		{name: "SLSA v0.2 with incomplete materials", provenanceBytes: v02Bytes, wantErr: true},
+		{name: "SLSA v1", provenanceBytes: v1Bytes, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validated, err := model.ParseStatementData(tt.provenanceBytes)
+			if err != nil {
+				t.Fatalf("could not parse provenance: %v", err)
+			}
+			provenance, err := model.FromValidatedProvenance(validated)
+			if err != nil {
+				t.Fatalf("could not convert provenance: %v", err)
+			}
+			err = Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], MaterialsIncomplete)
+			}
+		})
+	}
+}
+
+func TestVerify_AllDistinctFromMaterials(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		AllDistinctFromMaterials: &pb.VerifyAllDistinctFromMaterials{},
+	}
+	source := model.Dependency{URI: "git+https://github.com/project-oak/oak", Digest: map[string]string{"sha1": "6bac02b6b0442ed944f57b7cba9a5f1119863ca4"}}
+
+	tests := []struct {
+		name         string
+		dependencies []model.Dependency
+		wantErr      bool
+	}{
+		{
+			name: "normal build",
+			dependencies: []model.Dependency{source, {
+				URI:    "https://example.com/toolchain.tar.gz",
+				Digest: map[string]string{"sha256": "51532c757d1008bbff696d053a1d05226f6387cf232aa80b6f9c13b0759ccea0"},
+			}},
+		},
+		{
+			name: "passthrough build",
+			dependencies: []model.Dependency{source, {
+				URI:    "https://example.com/prebuilt.bin",
+				Digest: map[string]string{"sha256": strings.ToUpper(binaryDigest)},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+				model.WithResolvedDependencies(tt.dependencies))
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], PassthroughBuild)
+			}
+		})
+	}
+}
+
+func TestVerify_NotOlderThan(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		NotOlderThan: &pb.VerifyNotOlderThan{PriorTimestamp: "2023-06-01T12:00:00Z"},
+	}
+
+	tests := []struct {
+		name     string
+		options  []func(p *model.ProvenanceIR)
+		wantCode ReasonCode
+	}{
+		{name: "newer", options: []func(p *model.ProvenanceIR){model.WithBuildFinishedOn(time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC))}},
+		{name: "same time", options: []func(p *model.ProvenanceIR){model.WithBuildFinishedOn(time.Date(2023, 6, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))}},
+		{name: "older", options: []func(p *model.ProvenanceIR){model.WithBuildFinishedOn(time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC))}, wantCode: ProvenanceTooOld},
+		{name: "without build time", wantCode: TimestampMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, tt.options...)
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantCode != "")
+			if tt.wantCode != "" {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestValidateVerificationOptions_InvalidPriorTimestamp(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		NotOlderThan: &pb.VerifyNotOlderThan{PriorTimestamp: "yesterday"},
+	}
+	if err := ValidateVerificationOptions(&verOpts); err == nil {
+		t.Errorf("expected failure")
+	}
+}
+
+func TestVerifyDetailed(t *testing.T) {
+	withBuildCmd := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithBuildCmd([]string{"make"}))
+	withoutBuildCmd := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenances := []model.ProvenanceIR{*withBuildCmd, *withoutBuildCmd, *withBuildCmd}
+	verOpts := pb.VerificationOptions{
+		ProvenanceCountAtLeast: &pb.VerifyProvenanceCountAtLeast{Count: 2},
+		AllWithBinaryName:      &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+		AllWithBuildCommand:    &pb.VerifyAllWithBuildCommand{},
+		Severities:             map[string]pb.Severity{"all_with_build_command": pb.Severity_WARN},
+	}
+
+	result, err := VerifyDetailed(provenances, &verOpts)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	want := map[string][]int{
+		"provenance_count_at_least": {0, 1, 2},
+		"all_with_binary_name":      {0, 1, 2},
+		"all_with_build_command":    {0, 2},
+	}
+	if diff := cmp.Diff(result.SatisfiedBy, want); diff != "" {
+		t.Errorf("unexpected satisfied steps: %s", diff)
+	}
+	testutil.AssertEq(t, "warnings", len(result.Warnings), 1)
+
+	// The result is also returned if the verification fails.
+	verOpts.Severities = nil
+	result, err = VerifyDetailed(provenances, &verOpts)
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], BuildCommandMissing)
+	if diff := cmp.Diff(result.SatisfiedBy["all_with_build_command"], []int{0, 2}); diff != "" {
+		t.Errorf("unexpected satisfied steps: %s", diff)
+	}
+}
+
+func TestVerify_BuilderOrg(t *testing.T) {
+	withIdentity := func(san, issuer string) model.ProvenanceIR {
+		identity := model.SignerIdentity{SubjectAlternativeName: san, OIDCIssuer: issuer}
+		return *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithSignerIdentity(identity))
+	}
+	const workflow = "/oak/.github/workflows/provenance.yaml@refs/heads/main"
+	tests := map[string]struct {
+		provenance model.ProvenanceIR
+		wantCode   ReasonCode
+	}{
+		"in org":                    {withIdentity("https://github.com/project-oak"+workflow, githubActionsOIDCIssuer), ""},
+		"in org, differently cased": {withIdentity("https://github.com/Project-Oak"+workflow, githubActionsOIDCIssuer), ""},
+		"out of org":                {withIdentity("https://github.com/attacker"+workflow, githubActionsOIDCIssuer), BuilderOrgNotAllowed},
+		"other host":                {withIdentity("https://gitlab.com/project-oak"+workflow, githubActionsOIDCIssuer), BuilderOrgNotAllowed},
+		"other OIDC issuer":         {withIdentity("https://github.com/project-oak"+workflow, "https://accounts.google.com"), BuilderOrgNotAllowed},
+		"no identity":               {*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName), BuilderIdentityMissing},
+	}
+	verOpts := pb.VerificationOptions{
+		BuilderOrg: &pb.VerifyBuilderOrg{Orgs: []string{"google", "project-oak"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Verify([]model.ProvenanceIR{tt.provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantCode != "")
+			if tt.wantCode != "" {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestValidateVerificationOptions_InvalidBuilderOrg(t *testing.T) {
+	for _, orgs := range [][]string{nil, {""}, {"project-oak/oak"}} {
+		verOpts := pb.VerificationOptions{BuilderOrg: &pb.VerifyBuilderOrg{Orgs: orgs}}
+		if err := ValidateVerificationOptions(&verOpts); err == nil {
+			t.Errorf("expected failure for orgs %q", orgs)
+		}
+	}
+}
+
+func TestVerify_AllWithinOwnValidity(t *testing.T) {
+	notBefore := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	withValidity := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithValidity(model.Validity{NotBefore: &notBefore, NotAfter: &notAfter}))
+	withoutValidity := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	tests := map[string]struct {
+		at       string
+		wantCode ReasonCode
+	}{
+		"within validity": {"2023-06-15T00:00:00Z", ""},
+		"expired":         {"2023-07-01T00:00:00Z", ProvenanceExpired},
+		"not yet valid":   {"2023-05-31T23:59:59Z", ProvenanceNotYetValid},
+		// The validity lapsed long before the current time.
+		"now": {"", ProvenanceExpired},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			verOpts := pb.VerificationOptions{
+				AllWithinOwnValidity: &pb.VerifyAllWithinOwnValidity{AtTimestamp: tt.at},
+			}
+			err := Verify([]model.ProvenanceIR{*withoutValidity, *withValidity}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantCode != "")
+			if tt.wantCode != "" {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+			}
+		})
+	}
+
+	verOpts := pb.VerificationOptions{
+		AllWithinOwnValidity: &pb.VerifyAllWithinOwnValidity{AtTimestamp: "2023-07-02T00:00:00Z"},
+		Severities:           map[string]pb.Severity{"all_within_own_validity": pb.Severity_WARN},
+	}
+	warnings, err := VerifyWithWarnings([]model.ProvenanceIR{*withValidity}, &verOpts)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	testutil.AssertEq(t, "warnings", len(warnings), 1)
+
+	verOpts = pb.VerificationOptions{
+		AllWithinOwnValidity: &pb.VerifyAllWithinOwnValidity{AtTimestamp: "now"},
+	}
+	if err := ValidateVerificationOptions(&verOpts); err == nil {
+		t.Errorf("expected failure for an invalid timestamp")
+	}
+}
+
+func TestVerify_AllWithBuildType(t *testing.T) {
+	const currentBuildType = "https://example.com/build@v2"
+	const deprecatedBuildType = "https://example.com/build@v1"
+	current := model.NewProvenanceIR(binaryDigest, currentBuildType, binaryName)
+	deprecated := model.NewProvenanceIR(binaryDigest, deprecatedBuildType, binaryName)
+	unknown := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	verOpts := pb.VerificationOptions{
+		AllWithBuildType: &pb.VerifyAllWithBuildType{
+			Allowed:    []string{currentBuildType},
+			Deprecated: []string{deprecatedBuildType},
+		},
+	}
+
+	warnings, err := VerifyWithWarnings([]model.ProvenanceIR{*current, *deprecated}, &verOpts)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1: %v", len(warnings), warnings)
+	}
+	testutil.AssertEq(t, "reason code", ReasonCodes(warnings[0])[0], BuildTypeDeprecated)
+
+	warnings, err = VerifyWithWarnings([]model.ProvenanceIR{*current}, &verOpts)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	testutil.AssertEq(t, "warnings", len(warnings), 0)
+
+	err = Verify([]model.ProvenanceIR{*unknown}, &verOpts)
+	testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], BuildTypeNotAllowed)
+
+	if err := ValidateVerificationOptions(&pb.VerificationOptions{AllWithBuildType: &pb.VerifyAllWithBuildType{}}); err == nil {
+		t.Errorf("expected failure without any build types")
+	}
+}
+
+func TestVerify_CommitSignature(t *testing.T) {
+	const trustedFingerprint = "3AA5 C343 7156 7BD2"
+	signed := func(format, signer string) model.ProvenanceIR {
+		return *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+			model.WithCommitSignature(model.CommitSignature{Format: format, Signer: signer}))
+	}
+	verOpts := pb.VerificationOptions{
+		CommitSignature: &pb.VerifyCommitSignature{
+			TrustedSigners: []string{trustedFingerprint, "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"},
+		},
+	}
+	tests := map[string]struct {
+		provenance model.ProvenanceIR
+		wantCode   ReasonCode
+	}{
+		"signed by trusted GPG key": {signed(model.CommitSignatureFormatGPG, "3aa5c34371567bd2"), ""},
+		"signed by trusted SSH key": {signed(model.CommitSignatureFormatSSH, "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"), ""},
+		"signed by unknown GPG key": {signed(model.CommitSignatureFormatGPG, "4BB6D45482678CE3"), CommitSignerNotTrusted},
+		// SSH fingerprints are case-sensitive.
+		"signed by unknown SSH key": {signed(model.CommitSignatureFormatSSH, "SHA256:NTHBG6KXUPJWGL7E1IGOCSPROMTXDCARLVIKW6E5SY8"), CommitSignerNotTrusted},
+		"unsigned":                  {*model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName), CommitSignatureMissing},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Verify([]model.ProvenanceIR{tt.provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantCode != "")
+			if tt.wantCode != "" {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+			}
+		})
+	}
+
+	invalid := []*pb.VerifyCommitSignature{{}, {TrustedSigners: []string{trustedFingerprint, " "}}}
+	for _, opt := range invalid {
+		if err := ValidateVerificationOptions(&pb.VerificationOptions{CommitSignature: opt}); err == nil {
+			t.Errorf("expected failure for %v", opt)
+		}
+	}
+}
+
+func TestVerify_AllWithBuilderID(t *testing.T) {
+	const workflow = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml"
+	builtBy := func(builderID string) model.ProvenanceIR {
+		return *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, model.WithTrustedBuilder(builderID))
+	}
+	tests := map[string]struct {
+		allowed    []string
+		provenance model.ProvenanceIR
+		wantCode   ReasonCode
+	}{
+		"exact ref":      {[]string{workflow + "@refs/tags/v1.9.0"}, builtBy(workflow + "@refs/tags/v1.9.0"), ""},
+		"any ref":        {[]string{workflow}, builtBy(workflow + "@refs/tags/v1.9.0"), ""},
+		"other ref":      {[]string{workflow + "@refs/tags/v1.9.0"}, builtBy(workflow + "@refs/heads/main"), BuilderIDNotAllowed},
+		"other workflow": {[]string{workflow}, builtBy(workflow + "-fork@refs/tags/v1.9.0"), BuilderIDNotAllowed},
+		"no builder":     {[]string{workflow}, *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName), BuilderIDMissing},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			verOpts := pb.VerificationOptions{
+				AllWithBuilderId: &pb.VerifyAllWithBuilderID{BuilderIds: tt.allowed},
+			}
+			err := Verify([]model.ProvenanceIR{tt.provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantCode != "")
+			if tt.wantCode != "" {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+			}
+		})
+	}
+
+	invalid := []*pb.VerifyAllWithBuilderID{{}, {BuilderIds: []string{workflow, ""}}}
+	for _, opt := range invalid {
+		if err := ValidateVerificationOptions(&pb.VerificationOptions{AllWithBuilderId: opt}); err == nil {
+			t.Errorf("expected failure for %v", opt)
+		}
+	}
+}
+
+func TestVerify_AllWithSourceRepo(t *testing.T) {
+	const commit = "6bac02b6b0442ed944f57b7cba9a5f1119863ca4"
+	fromMaterial := func(uri string) model.ProvenanceIR {
+		return *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+			model.WithResolvedDependencies([]model.Dependency{
+				{URI: "https://example.com/toolchain.tar.gz", Digest: map[string]string{"sha256": binaryDigest}},
+				{URI: uri, Digest: map[string]string{"sha1": commit}},
+			}))
+	}
+	oak := fromMaterial("git+https://github.com/project-oak/oak@refs/heads/main")
+	fork := fromMaterial("git+https://github.com/project-oak-fork/oak.git@refs/heads/main")
+	fromConfig := *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName,
+		model.WithRepoURI("git+https://github.com/project-oak/oak"))
+	tests := map[string]struct {
+		opt        *pb.VerifyAllWithSourceRepo
+		provenance model.ProvenanceIR
+		wantCode   ReasonCode
+	}{
+		"exact":                {&pb.VerifyAllWithSourceRepo{Uri: "https://github.com/project-oak/oak"}, oak, ""},
+		"exact from config":    {&pb.VerifyAllWithSourceRepo{Uri: "https://github.com/project-oak/oak.git"}, fromConfig, ""},
+		"exact fork":           {&pb.VerifyAllWithSourceRepo{Uri: "https://github.com/project-oak/oak"}, fork, SourceRepoMismatch},
+		"prefix":               {&pb.VerifyAllWithSourceRepo{UriPrefix: "https://github.com/project-oak/"}, oak, ""},
+		"prefix fork":          {&pb.VerifyAllWithSourceRepo{UriPrefix: "https://github.com/project-oak"}, fork, SourceRepoMismatch},
+		"host":                 {&pb.VerifyAllWithSourceRepo{Host: "GitHub.com"}, fork, ""},
+		"host mismatch":        {&pb.VerifyAllWithSourceRepo{Host: "gitlab.com"}, oak, SourceRepoMismatch},
+		"prefix and host fork": {&pb.VerifyAllWithSourceRepo{UriPrefix: "https://github.com/project-oak", Host: "github.com"}, fork, SourceRepoMismatch},
+		"no source repo":       {&pb.VerifyAllWithSourceRepo{Host: "github.com"}, *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName), SourceRepoMissing},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			verOpts := pb.VerificationOptions{AllWithSourceRepo: tt.opt}
+			err := Verify([]model.ProvenanceIR{tt.provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantCode != "")
+			if tt.wantCode != "" {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+			}
+		})
+	}
+
+	if err := ValidateVerificationOptions(&pb.VerificationOptions{AllWithSourceRepo: &pb.VerifyAllWithSourceRepo{}}); err == nil {
+		t.Errorf("expected failure without constraints")
+	}
+}
+
+func TestVerify_OnMissing(t *testing.T) {
+	// The provenance has neither a build time nor a validity.
+	provenance := *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	tests := []struct {
+		name         string
+		verOpts      *pb.VerificationOptions
+		wantCode     ReasonCode
+		wantWarnings int
+	}{
+		{
+			name:     "default fail",
+			verOpts:  &pb.VerificationOptions{AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 1}},
+			wantCode: TimestampMissing,
+		},
+		{
+			name: "pass",
+			verOpts: &pb.VerificationOptions{
+				AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 1},
+				OnMissing:          map[string]pb.MissingPolicy{"all_built_within_days": pb.MissingPolicy_MISSING_PASS},
+			},
+		},
+		{
+			name: "warn",
+			verOpts: &pb.VerificationOptions{
+				AllBuiltWithinDays: &pb.VerifyAllBuiltWithinDays{Days: 1},
+				OnMissing:          map[string]pb.MissingPolicy{"all_built_within_days": pb.MissingPolicy_MISSING_WARN},
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:    "default pass",
+			verOpts: &pb.VerificationOptions{AllWithinOwnValidity: &pb.VerifyAllWithinOwnValidity{}},
+		},
+		{
+			name: "fail",
+			verOpts: &pb.VerificationOptions{
+				AllWithinOwnValidity: &pb.VerifyAllWithinOwnValidity{},
+				OnMissing:            map[string]pb.MissingPolicy{"all_within_own_validity": pb.MissingPolicy_MISSING_FAIL},
+			},
+			wantCode: ValidityMissing,
+		},
+		{
+			name: "warn with severity WARN",
+			verOpts: &pb.VerificationOptions{
+				AllWithinOwnValidity: &pb.VerifyAllWithinOwnValidity{},
+				OnMissing:            map[string]pb.MissingPolicy{"all_within_own_validity": pb.MissingPolicy_MISSING_WARN},
+				Severities:           map[string]pb.Severity{"all_within_own_validity": pb.Severity_WARN},
+			},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := VerifyWithWarnings([]model.ProvenanceIR{provenance}, tt.verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantCode != "")
+			if tt.wantCode != "" {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.wantCode)
+			}
+			testutil.AssertEq(t, "warnings", len(warnings), tt.wantWarnings)
+		})
+	}
+
+	verOpts := pb.VerificationOptions{
+		AllWithMinDependencies: &pb.VerifyAllWithMinDependencies{Count: 1},
+		OnMissing:              map[string]pb.MissingPolicy{"all_with_min_dependencies": pb.MissingPolicy_MISSING_PASS},
+	}
+	if err := ValidateVerificationOptions(&verOpts); err == nil {
+		t.Errorf("expected failure for an unsupported step")
+	}
+}
+
+func TestVerifyDetailed_OnMissing(t *testing.T) {
+	provenance := *model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	verOpts := pb.VerificationOptions{
+		AllWithPlatform: &pb.VerifyAllWithPlatform{Os: "linux"},
+		OnMissing:       map[string]pb.MissingPolicy{"all_with_platform": pb.MissingPolicy_MISSING_WARN},
+	}
+	result, err := VerifyDetailed([]model.ProvenanceIR{provenance}, &verOpts)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if diff := cmp.Diff(result.SatisfiedBy, map[string][]int{"all_with_platform": {0}}); diff != "" {
+		t.Errorf("unexpected satisfied steps: %s", diff)
+	}
+	testutil.AssertEq(t, "warnings", len(result.Warnings), 1)
+}
+
+func TestVerify_ReportsAllFailures(t *testing.T) {
+	provenance1 := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName)
+	provenance2 := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName+"other")
+	provenances := []model.ProvenanceIR{*provenance1, *provenance2}
+	verOpts := pb.VerificationOptions{
+		AllSameBinaryName: &pb.VerifyAllSameBinaryName{},
+		AllWithBinaryName: &pb.VerifyAllWithBinaryName{BinaryName: binaryName},
+		AllWithRepository: &pb.VerifyAllWithRepository{RepositoryUri: "https://github.com/project-oak/oak"},
+	}
+
+	err := Verify(provenances, &verOpts)
+	want := []ReasonCode{BinaryNameInconsistent, BinaryNameMismatch, RepositoryMismatch, RepositoryMismatch}
+	if diff := cmp.Diff(ReasonCodes(err), want); diff != "" {
+		t.Errorf("unexpected reason codes: %s", diff)
+	}
+	for _, want := range []string{"#1 has", "in #0", "in #1"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("got %q, want error message containing %q", err, want)
+		}
+	}
+}
+
+func TestVerify_AllWithImageManifestDigest(t *testing.T) {
+	configDigest := strings.Repeat("c", 64)
+	layerDigest := strings.Repeat("1", 64)
+	manifest := []byte(fmt.Sprintf(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {"mediaType": "application/vnd.oci.image.config.v1+json", "digest": "sha256:%s", "size": 1234},
+  "layers": [{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": "sha256:%s", "size": 5678}]
+}`, configDigest, layerDigest))
+	sum := sha256.Sum256(manifest)
+	manifestDigest := hex.EncodeToString(sum[:])
+	imageName := "ghcr.io/project-oak/oak"
+	reference := imageName + "@sha256:" + manifestDigest
+
+	tests := []struct {
+		name    string
+		digest  string
+		opt     *pb.VerifyAllWithImageManifestDigest
+		want    ReasonCode
+		message string
+	}{
+		{name: "manifest digest", digest: manifestDigest, opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: reference}},
+		{name: "manifest digest with manifest", digest: strings.ToUpper(manifestDigest), opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: reference, Manifest: manifest}},
+		{name: "config digest", digest: configDigest, opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: reference, Manifest: manifest}, want: ImageManifestDigestMismatch, message: "config digest"},
+		{name: "layer digest", digest: layerDigest, opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: reference, Manifest: manifest}, want: ImageManifestDigestMismatch, message: "layer #0"},
+		{name: "config digest without manifest", digest: configDigest, opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: reference}, want: ImageManifestDigestMismatch, message: configDigest},
+		{name: "reference by tag", digest: manifestDigest, opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: imageName + ":v1.0.0"}, want: InvalidVerificationOptions, message: "by SHA2-256 digest"},
+		{name: "manifest of another image", digest: manifestDigest, opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: imageName + "@sha256:" + configDigest, Manifest: manifest}, want: InvalidVerificationOptions, message: "has digest"},
+		{name: "config instead of manifest", digest: manifestDigest, opt: &pb.VerifyAllWithImageManifestDigest{ImageReference: reference, Manifest: []byte(`{"architecture": "amd64"}`)}, want: InvalidVerificationOptions, message: "neither an image manifest nor an index"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(tt.digest, slsav02.GenericSLSABuildType, imageName)
+			err := Verify([]model.ProvenanceIR{*provenance}, &pb.VerificationOptions{AllWithImageManifestDigest: tt.opt})
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("verify failed: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected failure")
+			}
+			testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], tt.want)
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("got %q, want error message containing %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestVerify_MinByproducts(t *testing.T) {
+	verOpts := pb.VerificationOptions{
+		AllWithMinByproducts: &pb.VerifyAllWithMinByproducts{Count: 2},
+	}
+
+	tests := []struct {
+		name    string
+		options []func(p *model.ProvenanceIR)
+		wantErr bool
+	}{
+		{name: "without byproducts", wantErr: true},
+		{name: "below the minimum", options: []func(p *model.ProvenanceIR){
+			model.WithByproducts([]model.Byproduct{{Name: "build.log"}}),
+		}, wantErr: true},
+		{name: "at the minimum", options: []func(p *model.ProvenanceIR){
+			model.WithByproducts([]model.Byproduct{{Name: "build.log"}, {Name: "test-report.xml"}}),
+		}},
+		{name: "above the minimum", options: []func(p *model.ProvenanceIR){
+			model.WithByproducts([]model.Byproduct{{Name: "build.log"}, {Name: "test-report.xml"}, {URI: "https://example.com/coverage"}}),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provenance := model.NewProvenanceIR(binaryDigest, slsav02.GenericSLSABuildType, binaryName, tt.options...)
+			err := Verify([]model.ProvenanceIR{*provenance}, &verOpts)
+			testutil.AssertEq(t, "failed", err != nil, tt.wantErr)
+			if tt.wantErr {
+				testutil.AssertEq(t, "reason code", ReasonCodes(err)[0], TooFewByproducts)
+			}
+		})
+	}
+
+	invalid := pb.VerificationOptions{AllWithMinByproducts: &pb.VerifyAllWithMinByproducts{Count: -1}}
+	if err := ValidateVerificationOptions(&invalid); err == nil {
+		t.Errorf("expected failure for a negative count")
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claims
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/project-oak/transparent-release/pkg/intoto"
+)
+
+// CBORSerializer is a StatementSerializer encoding statements as CBOR (RFC
+// 8949). Statements are first mapped to JSON, so that the CBOR encoding has
+// the same structure and field names as the JSON encoding. The encoding is
+// deterministic, as described in Section 4.2.1 of RFC 8949: integers and
+// lengths use the shortest form, and map keys are sorted. Floating-point
+// numbers use the shortest of single and double precision.
+type CBORSerializer struct{}
+
+// CBOR major types, see Section 3.1 of RFC 8949.
+const (
+	cborUnsignedInt = 0
+	cborNegativeInt = 1
+	cborByteString  = 2
+	cborTextString  = 3
+	cborArray       = 4
+	cborMap         = 5
+	cborTag         = 6
+	cborSimple      = 7
+)
+
+// Simple values and floating-point numbers of major type 7, see Section 3.3 of
+// RFC 8949.
+const (
+	cborFalse   = 0xf4
+	cborTrue    = 0xf5
+	cborNull    = 0xf6
+	cborFloat16 = 0xf9
+	cborFloat32 = 0xfa
+	cborFloat64 = 0xfb
+)
+
+// maxCBORDepth is the maximum nesting depth of arrays and maps accepted by
+// Deserialize, which protects against exhausting the stack.
+const maxCBORDepth = 512
+
+// Serialize implements StatementSerializer.
+func (CBORSerializer) Serialize(statement *intoto.Statement) ([]byte, error) {
+	statementJSON, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the statement into JSON: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(statementJSON))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the JSON statement: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := encodeCBOR(&buf, value); err != nil {
+		return nil, fmt.Errorf("could not encode the statement as CBOR: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Deserialize implements StatementSerializer. Only the subset of CBOR that
+// corresponds to JSON is supported, i.e., no byte strings, tags, or
+// indefinite lengths, and map keys must be text strings.
+func (CBORSerializer) Deserialize(data []byte) (*intoto.Statement, error) {
+	value, rest, err := decodeCBOR(data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the CBOR statement: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("could not decode the CBOR statement: %d trailing bytes", len(rest))
+	}
+	statementJSON, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the CBOR statement into JSON: %v", err)
+	}
+	return ParseEndorsementV2Bytes(statementJSON)
+}
+
+// encodeCBOR appends the CBOR encoding of the given JSON value, as decoded
+// with json.Decoder.UseNumber, to the given buffer.
+func encodeCBOR(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(cborNull)
+	case bool:
+		if v {
+			buf.WriteByte(cborTrue)
+		} else {
+			buf.WriteByte(cborFalse)
+		}
+	case string:
+		writeCBORHead(buf, cborTextString, uint64(len(v)))
+		buf.WriteString(v)
+	case json.Number:
+		return encodeCBORNumber(buf, v)
+	case []interface{}:
+		writeCBORHead(buf, cborArray, uint64(len(v)))
+		for _, item := range v {
+			if err := encodeCBOR(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		// Sorting the encoded keys bytewise amounts to sorting shorter keys
+		// first, and keys of the same length bytewise.
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) < len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+		writeCBORHead(buf, cborMap, uint64(len(v)))
+		for _, key := range keys {
+			writeCBORHead(buf, cborTextString, uint64(len(key)))
+			buf.WriteString(key)
+			if err := encodeCBOR(buf, v[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported value of type %T", value)
+	}
+	return nil
+}
+
+// encodeCBORNumber appends the CBOR encoding of the given number, as an
+// integer if possible, and as a floating-point number otherwise.
+func encodeCBORNumber(buf *bytes.Buffer, number json.Number) error {
+	if n, err := strconv.ParseUint(number.String(), 10, 64); err == nil {
+		writeCBORHead(buf, cborUnsignedInt, n)
+		return nil
+	}
+	if n, err := strconv.ParseInt(number.String(), 10, 64); err == nil && n < 0 {
+		writeCBORHead(buf, cborNegativeInt, uint64(-(n + 1)))
+		return nil
+	}
+	f, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return fmt.Errorf("unsupported number %q: %v", number, err)
+	}
+	if float64(float32(f)) == f {
+		buf.WriteByte(cborFloat32)
+		_ = binary.Write(buf, binary.BigEndian, math.Float32bits(float32(f)))
+	} else {
+		buf.WriteByte(cborFloat64)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+	}
+	return nil
+}
+
+// writeCBORHead appends the head of a data item with the given major type and
+// argument, in the shortest form.
+func writeCBORHead(buf *bytes.Buffer, majorType byte, argument uint64) {
+	head := majorType << 5
+	switch {
+	case argument < 24:
+		buf.WriteByte(head | byte(argument))
+	case argument <= math.MaxUint8:
+		buf.WriteByte(head | 24)
+		buf.WriteByte(byte(argument))
+	case argument <= math.MaxUint16:
+		buf.WriteByte(head | 25)
+		_ = binary.Write(buf, binary.BigEndian, uint16(argument))
+	case argument <= math.MaxUint32:
+		buf.WriteByte(head | 26)
+		_ = binary.Write(buf, binary.BigEndian, uint32(argument))
+	default:
+		buf.WriteByte(head | 27)
+		_ = binary.Write(buf, binary.BigEndian, argument)
+	}
+}
+
+// decodeCBORHead decodes the head of the data item at the start of the given
+// data, returning the initial byte, the argument, and the remaining data. For
+// data items of major type 7, the argument is the raw value of the simple
+// value or floating-point number.
+func decodeCBORHead(data []byte) (byte, uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, 0, nil, fmt.Errorf("unexpected end of data")
+	}
+	initial, data := data[0], data[1:]
+	info := initial & 0x1f
+	var size int
+	switch {
+	case info < 24:
+		return initial, uint64(info), data, nil
+	case info <= 27:
+		size = 1 << (info - 24)
+	case info == 31:
+		return 0, 0, nil, fmt.Errorf("indefinite lengths are not supported")
+	default:
+		return 0, 0, nil, fmt.Errorf("reserved additional information %d", info)
+	}
+	if len(data) < size {
+		return 0, 0, nil, fmt.Errorf("unexpected end of data")
+	}
+	var argument uint64
+	for _, b := range data[:size] {
+		argument = argument<<8 | uint64(b)
+	}
+	return initial, argument, data[size:], nil
+}
+
+// decodeCBOR decodes the data item at the start of the given data into a JSON
+// value, with numbers as json.Number, and returns the remaining data.
+func decodeCBOR(data []byte, depth int) (interface{}, []byte, error) {
+	if depth > maxCBORDepth {
+		return nil, nil, fmt.Errorf("nesting exceeds the maximum depth of %d", maxCBORDepth)
+	}
+	initial, argument, rest, err := decodeCBORHead(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch initial >> 5 {
+	case cborUnsignedInt:
+		return json.Number(strconv.FormatUint(argument, 10)), rest, nil
+	case cborNegativeInt:
+		n := new(big.Int).SetUint64(argument)
+		return json.Number(n.Add(n, big.NewInt(1)).Neg(n).String()), rest, nil
+	case cborTextString:
+		if argument > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("unexpected end of data")
+		}
+		text := rest[:argument]
+		if !utf8.Valid(text) {
+			return nil, nil, fmt.Errorf("invalid UTF-8 in text string")
+		}
+		return string(text), rest[argument:], nil
+	case cborArray:
+		// Every item takes at least one byte.
+		if argument > uint64(len(rest)) {
+			return nil, nil, fmt.Errorf("unexpected end of data")
+		}
+		items := make([]interface{}, 0, argument)
+		for i := uint64(0); i < argument; i++ {
+			var item interface{}
+			if item, rest, err = decodeCBOR(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, rest, nil
+	case cborMap:
+		// Every entry takes at least two bytes.
+		if argument > uint64(len(rest))/2 {
+			return nil, nil, fmt.Errorf("unexpected end of data")
+		}
+		entries := make(map[string]interface{}, argument)
+		for i := uint64(0); i < argument; i++ {
+			if len(rest) > 0 && rest[0]>>5 != cborTextString {
+				return nil, nil, fmt.Errorf("map keys must be text strings")
+			}
+			var key, value interface{}
+			if key, rest, err = decodeCBOR(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+			if _, found := entries[key.(string)]; found {
+				return nil, nil, fmt.Errorf("duplicate map key %q", key)
+			}
+			if value, rest, err = decodeCBOR(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+			entries[key.(string)] = value
+		}
+		return entries, rest, nil
+	case cborSimple:
+		return decodeCBORSimple(initial, argument, rest)
+	case cborByteString:
+		return nil, nil, fmt.Errorf("byte strings are not supported")
+	default: // cborTag
+		return nil, nil, fmt.Errorf("tags are not supported")
+	}
+}
+
+// decodeCBORSimple decodes a simple value or floating-point number with the
+// given initial byte and argument.
+func decodeCBORSimple(initial byte, argument uint64, rest []byte) (interface{}, []byte, error) {
+	var f float64
+	switch initial {
+	case cborFalse:
+		return false, rest, nil
+	case cborTrue:
+		return true, rest, nil
+	case cborNull:
+		return nil, rest, nil
+	case cborFloat16:
+		f = float16ToFloat64(uint16(argument))
+	case cborFloat32:
+		f = float64(math.Float32frombits(uint32(argument)))
+	case cborFloat64:
+		f = math.Float64frombits(argument)
+	default:
+		return nil, nil, fmt.Errorf("unsupported simple value 0x%x", initial)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, nil, fmt.Errorf("unsupported floating-point number %v", f)
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), rest, nil
+}
+
+// float16ToFloat64 converts an IEEE 754 half-precision number, see Appendix D
+// of RFC 8949.
+func float16ToFloat64(half uint16) float64 {
+	exponent := int(half>>10) & 0x1f
+	mantissa := float64(half & 0x3ff)
+	var value float64
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 0x1f:
+		if mantissa == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if half&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
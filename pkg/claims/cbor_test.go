@@ -0,0 +1,164 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claims
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCBORSerializer_RoundTrip(t *testing.T) {
+	endorsement, err := ParseEndorsementV2File("../../schema/claim/v1/example.json")
+	if err != nil {
+		t.Fatalf("Failed to parse the example endorsement file: %v", err)
+	}
+
+	serializer, err := StatementSerializerFor("cbor")
+	if err != nil {
+		t.Fatalf("Failed to get the CBOR serializer: %v", err)
+	}
+	encoded, err := serializer.Serialize(endorsement)
+	if err != nil {
+		t.Fatalf("Failed to serialize the endorsement: %v", err)
+	}
+	decoded, err := serializer.Deserialize(encoded)
+	if err != nil {
+		t.Fatalf("Failed to deserialize the endorsement: %v", err)
+	}
+	if diff := cmp.Diff(decoded, endorsement); diff != "" {
+		t.Errorf("the round-tripped endorsement differs: %s", diff)
+	}
+
+	// The encoding is deterministic.
+	reencoded, err := serializer.Serialize(decoded)
+	if err != nil {
+		t.Fatalf("Failed to serialize the round-tripped endorsement: %v", err)
+	}
+	if !bytes.Equal(reencoded, encoded) {
+		t.Errorf("got different encodings of the same endorsement")
+	}
+
+	// The JSON serializer round-trips likewise.
+	jsonEncoded, err := JSONSerializer{}.Serialize(endorsement)
+	if err != nil {
+		t.Fatalf("Failed to serialize the endorsement as JSON: %v", err)
+	}
+	jsonDecoded, err := JSONSerializer{}.Deserialize(jsonEncoded)
+	if err != nil {
+		t.Fatalf("Failed to deserialize the JSON endorsement: %v", err)
+	}
+	if diff := cmp.Diff(jsonDecoded, decoded); diff != "" {
+		t.Errorf("the JSON and CBOR endorsements differ: %s", diff)
+	}
+}
+
+func TestEncodeCBOR(t *testing.T) {
+	// Examples from Appendix A of RFC 8949, except for floating-point numbers,
+	// which are never encoded in half precision.
+	tests := []struct {
+		json string
+		cbor string
+	}{
+		{json: `0`, cbor: "00"},
+		{json: `23`, cbor: "17"},
+		{json: `24`, cbor: "1818"},
+		{json: `1000`, cbor: "1903e8"},
+		{json: `1000000000000`, cbor: "1b000000e8d4a51000"},
+		{json: `18446744073709551615`, cbor: "1bffffffffffffffff"},
+		{json: `-1`, cbor: "20"},
+		{json: `-1000`, cbor: "3903e7"},
+		{json: `1.5`, cbor: "fa3fc00000"},
+		{json: `1.1`, cbor: "fb3ff199999999999a"},
+		{json: `false`, cbor: "f4"},
+		{json: `true`, cbor: "f5"},
+		{json: `null`, cbor: "f6"},
+		{json: `""`, cbor: "60"},
+		{json: `"IETF"`, cbor: "6449455446"},
+		{json: `"ü"`, cbor: "62c3bc"},
+		{json: `[1, [2, 3], [4, 5]]`, cbor: "8301820203820405"},
+		{json: `{"a": 1, "b": [2, 3]}`, cbor: "a26161016162820203"},
+		// Shorter keys are sorted first.
+		{json: `{"bb": 1, "a": 2, "c": 3}`, cbor: "a361610261630362626201"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			decoder := json.NewDecoder(bytes.NewReader([]byte(tt.json)))
+			decoder.UseNumber()
+			var value interface{}
+			if err := decoder.Decode(&value); err != nil {
+				t.Fatalf("Could not decode the JSON: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := encodeCBOR(&buf, value); err != nil {
+				t.Fatalf("Could not encode %s: %v", tt.json, err)
+			}
+			if got := hex.EncodeToString(buf.Bytes()); got != tt.cbor {
+				t.Errorf("got %s, want %s", got, tt.cbor)
+			}
+
+			decoded, rest, err := decodeCBOR(buf.Bytes(), 0)
+			if err != nil || len(rest) != 0 {
+				t.Fatalf("Could not decode %s: %v", tt.cbor, err)
+			}
+			if diff := cmp.Diff(decoded, value); diff != "" {
+				t.Errorf("the decoded value differs: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeCBOR_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":              "",
+		"truncated argument": "19ff",
+		"truncated string":   "6449",
+		"truncated array":    "830102",
+		"indefinite length":  "9f01ff",
+		"byte string":        "4161",
+		"tag":                "c074",
+		"non-string key":     "a10102",
+		"duplicate key":      "a2616101616102",
+		"NaN":                "f97e00",
+		"invalid UTF-8":      "61ff",
+		"huge array":         "9bffffffffffffffff",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := hex.DecodeString(input)
+			if err != nil {
+				t.Fatalf("Invalid test input: %v", err)
+			}
+			if _, _, err := decodeCBOR(data, 0); err == nil {
+				t.Errorf("expected failure decoding %s", input)
+			}
+		})
+	}
+
+	// Half precision is decoded, although never encoded.
+	value, _, err := decodeCBOR([]byte{0xf9, 0x3e, 0x00}, 0)
+	if err != nil || value != json.Number("1.5") {
+		t.Errorf("got %v, %v, want 1.5", value, err)
+	}
+	if _, err := (CBORSerializer{}).Deserialize([]byte{0xf6, 0xf6}); err == nil {
+		t.Errorf("expected failure with trailing bytes")
+	}
+	if _, err := StatementSerializerFor("yaml"); err == nil {
+		t.Errorf("expected failure for an unsupported format")
+	}
+}
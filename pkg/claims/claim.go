@@ -25,6 +25,7 @@ package claims
 // specification.
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"time"
@@ -50,6 +51,20 @@ type ClaimPredicate struct {
 	Validity *ClaimValidity `json:"validity"`
 	// A collection of artifacts that support the truth of the claim.
 	Evidence []ClaimEvidence `json:"evidence,omitempty"`
+	// Optional identity of the party that issued the claim.
+	Issuer *ClaimIssuer `json:"issuer,omitempty"`
+	// Optional record of the verification options that were applied to the
+	// evidence before issuing the claim, as canonical protojson.
+	VerificationOptions json.RawMessage `json:"verificationOptions,omitempty"`
+}
+
+// ClaimIssuer identifies the party that issued a claim, so that consumers can
+// attribute the claim.
+type ClaimIssuer struct {
+	// Human-readable name of the issuer.
+	Name string `json:"name,omitempty"`
+	// Optional URI uniquely identifying the issuer.
+	URI string `json:"uri,omitempty"`
 }
 
 // ClaimValidity contains validity time range of an issued claim.
@@ -71,6 +86,9 @@ type ClaimEvidence struct {
 	URI string `json:"uri"`
 	// Collection of cryptographic digests for the contents of this artifact.
 	Digest intoto.DigestSet `json:"digest"`
+	// Optional field specifying the ID of the builder that produced this
+	// evidence, if it is a provenance.
+	BuilderID string `json:"builderId,omitempty"`
 }
 
 // ValidateClaim validates that an in-toto statement is a Claim with a valid
@@ -105,6 +123,14 @@ func validateClaimPredicate(predicate ClaimPredicate) (*ClaimPredicate, error) {
 		}
 	}
 
+	// Verify the URI of the issuer, if any, is valid.
+	if predicate.Issuer != nil && predicate.Issuer.URI != "" {
+		parsedURI, err := url.Parse(predicate.Issuer.URI)
+		if err != nil || parsedURI.Scheme == "" {
+			return nil, fmt.Errorf("the issuer URI (%s) is not a valid URI", predicate.Issuer.URI)
+		}
+	}
+
 	// Verify that NotBefore is after than IssuedOn (inclusive).
 	if predicate.Validity.NotBefore.Before(*predicate.IssuedOn) {
 		return nil, fmt.Errorf("notBefore (%v) is before issuedOn (%v)",
@@ -0,0 +1,115 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claims
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/project-oak/transparent-release/pkg/cyclonedx"
+	"github.com/project-oak/transparent-release/pkg/intoto"
+)
+
+// CycloneDXSpecVersion is the version of the CycloneDX specification that
+// ExportEndorsementCycloneDX conforms to.
+const CycloneDXSpecVersion = cyclonedx.SpecVersion
+
+// cycloneDXPropertyPrefix namespaces the names of the CycloneDX properties
+// recording the claim, as recommended by the CycloneDX property taxonomy.
+const cycloneDXPropertyPrefix = "transparent-release:"
+
+// ExportEndorsementCycloneDX maps the given endorsement to a CycloneDX BOM,
+// for consumers that ingest attestations in CycloneDX format. Each subject of
+// the endorsement becomes a component with the subject's digests as hashes,
+// and the evidence of the claim as external references of type
+// "attestation". The claim type, issuer, and validity are recorded as
+// properties of the BOM metadata, named with the prefix
+// "transparent-release:", and the issuance time as the timestamp of the BOM.
+// Digests with algorithms not supported by CycloneDX are omitted.
+func ExportEndorsementCycloneDX(statement *intoto.Statement) ([]byte, error) {
+	if err := validateClaim(*statement); err != nil {
+		return nil, fmt.Errorf("invalid endorsement: %v", err)
+	}
+	predicate := statement.Predicate.(ClaimPredicate)
+
+	var evidence []cyclonedx.ExternalReference
+	for _, e := range predicate.Evidence {
+		evidence = append(evidence, cyclonedx.ExternalReference{
+			Type:    cyclonedx.AttestationReferenceType,
+			URL:     e.URI,
+			Comment: e.Role,
+			Hashes:  cycloneDXHashes(e.Digest),
+		})
+	}
+
+	components := make([]cyclonedx.Component, 0, len(statement.Subject))
+	for i, subject := range statement.Subject {
+		components = append(components, cyclonedx.Component{
+			Type:               "application",
+			BOMRef:             fmt.Sprintf("subject-%d", i),
+			Name:               subject.Name,
+			Hashes:             cycloneDXHashes(subject.Digest),
+			ExternalReferences: evidence,
+		})
+	}
+
+	properties := []cyclonedx.Property{
+		{Name: cycloneDXPropertyPrefix + "claimType", Value: predicate.ClaimType},
+		{Name: cycloneDXPropertyPrefix + "notBefore", Value: predicate.Validity.NotBefore.UTC().Format(time.RFC3339)},
+		{Name: cycloneDXPropertyPrefix + "notAfter", Value: predicate.Validity.NotAfter.UTC().Format(time.RFC3339)},
+	}
+	if predicate.Issuer != nil && predicate.Issuer.Name != "" {
+		properties = append(properties, cyclonedx.Property{Name: cycloneDXPropertyPrefix + "issuerName", Value: predicate.Issuer.Name})
+	}
+	if predicate.Issuer != nil && predicate.Issuer.URI != "" {
+		properties = append(properties, cyclonedx.Property{Name: cycloneDXPropertyPrefix + "issuerURI", Value: predicate.Issuer.URI})
+	}
+
+	bom := cyclonedx.BOM{
+		BOMFormat:   cyclonedx.BOMFormat,
+		SpecVersion: CycloneDXSpecVersion,
+		Version:     1,
+		Metadata: &cyclonedx.Metadata{
+			Timestamp:  predicate.IssuedOn.UTC().Format(time.RFC3339),
+			Properties: properties,
+		},
+		Components: components,
+	}
+	bytes, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the CycloneDX BOM: %v", err)
+	}
+	return bytes, nil
+}
+
+// cycloneDXHashes converts the given digests to CycloneDX hashes, sorted by
+// algorithm, omitting algorithms not supported by CycloneDX. Aliases such as
+// "sha256" and "sha2-256" are expected to have the same digest.
+func cycloneDXHashes(digests intoto.DigestSet) []cyclonedx.Hash {
+	byAlg := make(map[string]string, len(digests))
+	for alg, digest := range digests {
+		if hashAlg, found := cyclonedx.HashAlgorithm(alg); found {
+			byAlg[hashAlg] = digest
+		}
+	}
+	hashes := make([]cyclonedx.Hash, 0, len(byAlg))
+	for alg, digest := range byAlg {
+		hashes = append(hashes, cyclonedx.Hash{Alg: alg, Content: digest})
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Alg < hashes[j].Alg })
+	return hashes
+}
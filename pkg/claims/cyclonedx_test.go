@@ -0,0 +1,100 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claims
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/project-oak/transparent-release/internal/testutil"
+	"github.com/project-oak/transparent-release/pkg/cyclonedx"
+	"github.com/project-oak/transparent-release/pkg/intoto"
+)
+
+// cycloneDXSchemaPath is the path of the vendored CycloneDX 1.5 JSON schema.
+const cycloneDXSchemaPath = "../../testdata/bom-1.5.schema.json"
+
+func validateCycloneDX(t *testing.T, document []byte) error {
+	schema, err := os.ReadFile(cycloneDXSchemaPath)
+	if err != nil {
+		t.Fatalf("Could not read the CycloneDX schema: %v", err)
+	}
+	return testutil.ValidateJSONSchema(schema, document)
+}
+
+func TestExportEndorsementCycloneDX(t *testing.T) {
+	notBefore := time.Now().AddDate(0, 0, 1)
+	notAfter := time.Now().AddDate(0, 0, 3)
+	validity := ClaimValidity{NotBefore: &notBefore, NotAfter: &notAfter}
+	const digest = "813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"
+	subjects := []intoto.Subject{
+		{Name: "SomeBinary", Digest: intoto.DigestSet{"sha256": digest, "sha2-256": digest, "sha3-224": "unsupported"}},
+		{Name: "OtherBinary", Digest: intoto.DigestSet{"sha2-512": digest + digest}},
+	}
+	provenances := []ProvenanceData{{URI: "https://example.com/provenance.json", SHA256Digest: digest}}
+	endorsement := GenerateEndorsementStatementForSubjects(validity, subjects, provenances, WithIssuer(ClaimIssuer{Name: "Some issuer"}))
+
+	document, err := ExportEndorsementCycloneDX(endorsement)
+	if err != nil {
+		t.Fatalf("Could not export the endorsement: %v", err)
+	}
+	if err := validateCycloneDX(t, document); err != nil {
+		t.Fatalf("Invalid CycloneDX document: %v\n%s", err, document)
+	}
+	// An algorithm that the schema does not allow must be rejected.
+	if err := validateCycloneDX(t, []byte(strings.Replace(string(document), `"SHA-256"`, `"SHA-224"`, 1))); err == nil {
+		t.Errorf("Expected the schema to reject the SHA-224 hash algorithm")
+	}
+
+	var bom cyclonedx.BOM
+	if err := json.Unmarshal(document, &bom); err != nil {
+		t.Fatalf("Could not unmarshal the CycloneDX document: %v", err)
+	}
+	if len(bom.Components) != 2 {
+		t.Fatalf("got %d components, want 2", len(bom.Components))
+	}
+	if got := bom.Components[0]; got.Name != "SomeBinary" || len(got.Hashes) != 1 || got.Hashes[0] != (cyclonedx.Hash{Alg: "SHA-256", Content: digest}) {
+		t.Errorf("unexpected component: %+v", got)
+	}
+	if got := bom.Components[1].Hashes; len(got) != 1 || got[0].Alg != "SHA-512" {
+		t.Errorf("unexpected hashes: %+v", got)
+	}
+	if got := bom.Components[0].ExternalReferences; len(got) != 1 || got[0].URL != provenances[0].URI {
+		t.Errorf("unexpected external references: %+v", got)
+	}
+	properties := make(map[string]string)
+	for _, p := range bom.Metadata.Properties {
+		properties[p.Name] = p.Value
+	}
+	if got, want := properties["transparent-release:notAfter"], notAfter.UTC().Format(time.RFC3339); got != want {
+		t.Errorf("got notAfter %q, want %q", got, want)
+	}
+	if got := properties["transparent-release:issuerName"]; got != "Some issuer" {
+		t.Errorf("got issuer %q, want %q", got, "Some issuer")
+	}
+}
+
+func TestExportEndorsementCycloneDX_InvalidEndorsement(t *testing.T) {
+	notBefore := time.Now().AddDate(0, 0, 3)
+	notAfter := time.Now().AddDate(0, 0, 1)
+	validity := ClaimValidity{NotBefore: &notBefore, NotAfter: &notAfter}
+	endorsement := GenerateEndorsementStatement(validity, VerifiedProvenanceSet{BinaryName: "SomeBinary"})
+	if _, err := ExportEndorsementCycloneDX(endorsement); err == nil {
+		t.Errorf("expected failure")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/project-oak/transparent-release/pkg/intoto"
@@ -49,6 +50,14 @@ type VerifiedProvenanceSet struct {
 type ProvenanceData struct {
 	URI          string
 	SHA256Digest string
+	// Line is the 1-based line number of the provenance within the file at
+	// URI, for files containing several provenances, such as JSONL files. Zero
+	// if the file contains a single provenance. The SHA256 digest is the
+	// digest of the line in that case. Not recorded in endorsements.
+	Line int
+	// BuilderID is the ID of the builder that produced the provenance, if
+	// known. Recorded in the evidence of endorsements.
+	BuilderID string
 }
 
 // ParseEndorsementV2File reads a JSON file from the given path, and parses it
@@ -107,15 +116,44 @@ func validateClaim(statement intoto.Statement) error {
 	return nil
 }
 
+// WithIssuer sets the issuer of the claim when generating an endorsement.
+func WithIssuer(issuer ClaimIssuer) func(p *ClaimPredicate) {
+	return func(p *ClaimPredicate) {
+		p.Issuer = &issuer
+	}
+}
+
+// WithVerificationOptions records the given verification options, encoded as
+// JSON, in the claim when generating an endorsement.
+func WithVerificationOptions(verOptsJSON json.RawMessage) func(p *ClaimPredicate) {
+	return func(p *ClaimPredicate) {
+		p.VerificationOptions = verOptsJSON
+	}
+}
+
 // GenerateEndorsementStatement generates an endorsement object with the given subject, and
-// validity duration.
-func GenerateEndorsementStatement(validity ClaimValidity, provenances VerifiedProvenanceSet) *intoto.Statement {
-	evidence := make([]ClaimEvidence, 0, len(provenances.Provenances))
-	for _, provenance := range provenances.Provenances {
+// validity duration. Optional fields of the predicate, such as the issuer, can
+// be set using the given options.
+func GenerateEndorsementStatement(validity ClaimValidity, provenances VerifiedProvenanceSet, options ...func(p *ClaimPredicate)) *intoto.Statement {
+	subject := intoto.Subject{
+		Name:   provenances.BinaryName,
+		Digest: provenances.Digests,
+	}
+	return GenerateEndorsementStatementForSubjects(validity, []intoto.Subject{subject}, provenances.Provenances, options...)
+}
+
+// GenerateEndorsementStatementForSubjects works like
+// GenerateEndorsementStatement, but generates a single endorsement object for
+// all the given subjects, e.g., the artifacts of a release bundle, with the
+// given provenances as the shared evidence.
+func GenerateEndorsementStatementForSubjects(validity ClaimValidity, subjects []intoto.Subject, provenances []ProvenanceData, options ...func(p *ClaimPredicate)) *intoto.Statement {
+	evidence := make([]ClaimEvidence, 0, len(provenances))
+	for _, provenance := range provenances {
 		evidence = append(evidence, ClaimEvidence{
-			Role:   "Provenance",
-			URI:    provenance.URI,
-			Digest: intoto.DigestSet{"sha256": provenance.SHA256Digest},
+			Role:      "Provenance",
+			URI:       provenance.URI,
+			Digest:    intoto.DigestSet{"sha256": provenance.SHA256Digest},
+			BuilderID: provenance.BuilderID,
 		})
 	}
 
@@ -126,16 +164,14 @@ func GenerateEndorsementStatement(validity ClaimValidity, provenances VerifiedPr
 		Validity:  &validity,
 		Evidence:  evidence,
 	}
-
-	subject := intoto.Subject{
-		Name:   provenances.BinaryName,
-		Digest: provenances.Digests,
+	for _, option := range options {
+		option(&predicate)
 	}
 
 	statementHeader := intoto.StatementHeader{
 		Type:          intoto.StatementInTotoV01,
 		PredicateType: ClaimV1,
-		Subject:       []intoto.Subject{subject},
+		Subject:       subjects,
 	}
 
 	return &intoto.Statement{
@@ -143,3 +179,159 @@ func GenerateEndorsementStatement(validity ClaimValidity, provenances VerifiedPr
 		Predicate:       predicate,
 	}
 }
+
+// digestStrength ranks digest algorithms by strength, used for choosing a
+// canonical digest for a subject. Algorithms not listed here rank lowest.
+//
+//nolint:gochecknoglobals
+var digestStrength = map[string]int{
+	"sha3-512": 8,
+	"sha2-512": 7,
+	"sha3-384": 6,
+	"sha2-384": 5,
+	"sha3-256": 4,
+	"sha2-256": 3,
+	"sha3-224": 2,
+	"sha1":     1,
+}
+
+// SubjectID returns a canonical identifier for the first subject of the given
+// statement, as computed by SubjectIDOf. Returns an empty string if the
+// statement has no subject.
+func SubjectID(statement intoto.Statement) string {
+	if len(statement.Subject) == 0 {
+		return ""
+	}
+	return SubjectIDOf(statement.Subject[0])
+}
+
+// SubjectIDOf returns a canonical identifier for the given subject, formatted
+// as `<name>@<algorithm>:<hex digest>`. The strongest digest in the subject's
+// DigestSet is used, with ties between unknown algorithms broken by name, so
+// the result is deterministic. "sha256" is reported as "sha2-256".
+func SubjectIDOf(subject intoto.Subject) string {
+	algs := make([]string, 0, len(subject.Digest))
+	for alg := range subject.Digest {
+		algs = append(algs, alg)
+	}
+	if len(algs) == 0 {
+		return subject.Name
+	}
+	sort.Slice(algs, func(i, j int) bool {
+		si, sj := digestStrength[NormalizeDigestAlgorithm(algs[i])], digestStrength[NormalizeDigestAlgorithm(algs[j])]
+		if si != sj {
+			return si > sj
+		}
+		return algs[i] < algs[j]
+	})
+
+	return fmt.Sprintf("%s@%s:%s", subject.Name, NormalizeDigestAlgorithm(algs[0]), subject.Digest[algs[0]])
+}
+
+// NormalizeDigestAlgorithm maps the in-toto names of SHA2 digest algorithms,
+// e.g., "sha256", to the names used in endorsements, e.g., "sha2-256". Other
+// names are returned unchanged.
+func NormalizeDigestAlgorithm(alg string) string {
+	switch alg {
+	case "sha256":
+		return "sha2-256"
+	case "sha384":
+		return "sha2-384"
+	case "sha512":
+		return "sha2-512"
+	default:
+		return alg
+	}
+}
+
+// ChainBreakError is returned by VerifyEndorsementChain for the first
+// endorsement that does not continue the chain of custody.
+type ChainBreakError struct {
+	// Index of the offending endorsement in the chain.
+	Index int
+	// Err describes why the endorsement does not continue the chain.
+	Err error
+}
+
+func (e *ChainBreakError) Error() string {
+	return fmt.Sprintf("the chain of endorsements breaks at endorsement %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ChainBreakError) Unwrap() error {
+	return e.Err
+}
+
+// VerifyEndorsementChain checks that the given endorsements, in order, form a
+// valid chain of custody, e.g., from building an artifact, to signing it, to
+// deploying it. Each endorsement must be a valid endorsement, and each
+// endorsement after the first must continue the chain from the previous one:
+//   - Each of its subjects must match one of the subjects asserted by the
+//     previous endorsement, that is, their digests agree on all algorithms
+//     they have in common, of which there must be at least one. Names are not
+//     compared.
+//   - Its validity must overlap with, or immediately follow, the validity of
+//     the previous endorsement, so that there is no gap in the custody.
+//
+// Returns a ChainBreakError for the first endorsement that breaks the chain.
+func VerifyEndorsementChain(endorsements []*intoto.Statement) error {
+	predicates := make([]*ClaimPredicate, 0, len(endorsements))
+	for i, endorsement := range endorsements {
+		if err := validateClaim(*endorsement); err != nil {
+			return &ChainBreakError{Index: i, Err: fmt.Errorf("invalid endorsement: %v", err)}
+		}
+		predicate := endorsement.Predicate.(ClaimPredicate)
+		predicates = append(predicates, &predicate)
+		if i == 0 {
+			continue
+		}
+
+		prior := endorsements[i-1]
+		for _, subject := range endorsement.Subject {
+			if !matchesAnySubject(subject, prior.Subject) {
+				return &ChainBreakError{Index: i, Err: fmt.Errorf("subject %s does not match any subject of the prior endorsement", SubjectIDOf(subject))}
+			}
+		}
+
+		priorValidity, validity := predicates[i-1].Validity, predicate.Validity
+		if validity.NotBefore.After(*priorValidity.NotAfter) {
+			return &ChainBreakError{Index: i, Err: fmt.Errorf("validity starts at %v, after the validity of the prior endorsement ends at %v", *validity.NotBefore, *priorValidity.NotAfter)}
+		}
+		if validity.NotAfter.Before(*priorValidity.NotBefore) {
+			return &ChainBreakError{Index: i, Err: fmt.Errorf("validity ends at %v, before the validity of the prior endorsement starts at %v", *validity.NotAfter, *priorValidity.NotBefore)}
+		}
+	}
+	return nil
+}
+
+// matchesAnySubject reports whether the digests of the given subject match
+// those of any of the given subjects, see VerifyEndorsementChain.
+func matchesAnySubject(subject intoto.Subject, subjects []intoto.Subject) bool {
+	for _, other := range subjects {
+		if digestsMatch(subject.Digest, other.Digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// digestsMatch reports whether the given digest sets agree on all algorithms
+// they have in common, of which there must be at least one.
+func digestsMatch(a, b intoto.DigestSet) bool {
+	normalized := make(map[string]string, len(b))
+	for alg, digest := range b {
+		normalized[NormalizeDigestAlgorithm(alg)] = digest
+	}
+	common := 0
+	for alg, digest := range a {
+		other, found := normalized[NormalizeDigestAlgorithm(alg)]
+		if !found {
+			continue
+		}
+		if other != digest {
+			return false
+		}
+		common++
+	}
+	return common > 0
+}
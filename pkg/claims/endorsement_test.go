@@ -16,6 +16,7 @@ package claims
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"testing"
 	"time"
@@ -89,6 +90,65 @@ func TestGenerateProvenanceLessEndorsement(t *testing.T) {
 	}
 }
 
+func TestGenerateEndorsement_InvalidIssuerURI(t *testing.T) {
+	newNotBefore := time.Now().AddDate(0, 0, 1)
+	newNotAfter := time.Now().AddDate(0, 0, 3)
+
+	validity := ClaimValidity{
+		NotBefore: &newNotBefore,
+		NotAfter:  &newNotAfter,
+	}
+
+	provenances := VerifiedProvenanceSet{
+		BinaryName: "SomeBinary",
+		Digests:    intoto.DigestSet{"sha2-256": "813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"},
+	}
+	endorsement := GenerateEndorsementStatement(validity, provenances, WithIssuer(ClaimIssuer{Name: "Some issuer", URI: "not a URI"}))
+	if err := validateClaim(*endorsement); err == nil {
+		t.Fatalf("Expected an invalid issuer URI to be rejected")
+	}
+}
+
+func TestSubjectID_SingleDigest(t *testing.T) {
+	statement := intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			Subject: []intoto.Subject{{
+				Name:   "SomeBinary",
+				Digest: intoto.DigestSet{"sha256": "813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"},
+			}},
+		},
+	}
+
+	want := "SomeBinary@sha2-256:813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"
+	if got := SubjectID(statement); got != want {
+		t.Errorf("Unexpected SubjectID: got %s, want %s", got, want)
+	}
+}
+
+func TestSubjectID_MultipleDigestsPicksStrongest(t *testing.T) {
+	statement := intoto.Statement{
+		StatementHeader: intoto.StatementHeader{
+			Subject: []intoto.Subject{{
+				Name: "SomeBinary",
+				Digest: intoto.DigestSet{
+					"sha2-256": "aa",
+					"sha2-512": "bb",
+					"sha2-384": "cc",
+					"md5":      "dd",
+				},
+			}},
+		},
+	}
+
+	want := "SomeBinary@sha2-512:bb"
+	// Map iteration order is random, so check a few times.
+	for i := 0; i < 10; i++ {
+		if got := SubjectID(statement); got != want {
+			t.Fatalf("Unexpected SubjectID: got %s, want %s", got, want)
+		}
+	}
+}
+
 // Helper function for creating new test cases from the hard-coded one.
 func tweakValidity(t *testing.T, daysAddedToNotBefore, daysAddedToNotAfter int) []byte {
 	examplePath := "../../schema/claim/v1/example.json"
@@ -115,3 +175,51 @@ func tweakValidity(t *testing.T, daysAddedToNotBefore, daysAddedToNotAfter int)
 
 	return bytes
 }
+
+func TestVerifyEndorsementChain(t *testing.T) {
+	now := time.Now()
+	day := func(days int) *time.Time {
+		d := now.AddDate(0, 0, days)
+		return &d
+	}
+	endorsement := func(name, digest string, notBefore, notAfter int) *intoto.Statement {
+		validity := ClaimValidity{NotBefore: day(notBefore), NotAfter: day(notAfter)}
+		provenances := VerifiedProvenanceSet{BinaryName: name, Digests: intoto.DigestSet{"sha2-256": digest}}
+		return GenerateEndorsementStatement(validity, provenances)
+	}
+	const digest = "813841dda3818d616aa3e706e49d0286dc825c5dbad4a75cfb37b91ba412238b"
+	const otherDigest = "d059c38cea82047ad316a1c6c6fbd13ecf7a0abdcc375463920bd25bf5c142cc"
+
+	build := endorsement("SomeBinary", digest, 1, 10)
+	sign := endorsement("SomeBinary.signed", digest, 10, 20)
+	deploy := endorsement("SomeBinary", digest, 15, 30)
+	// Older endorsements use "sha256" rather than "sha2-256".
+	deploy.Subject[0].Digest = intoto.DigestSet{"sha256": digest}
+	if err := VerifyEndorsementChain([]*intoto.Statement{build, sign, deploy}); err != nil {
+		t.Errorf("Failed to verify a valid chain: %v", err)
+	}
+
+	invalid := endorsement("SomeBinary", digest, 1, 10)
+	*invalid.Predicate.(ClaimPredicate).Validity.NotAfter = *day(0)
+	tests := map[string]struct {
+		chain     []*intoto.Statement
+		wantIndex int
+	}{
+		"subject mismatch":        {[]*intoto.Statement{build, sign, endorsement("SomeBinary", otherDigest, 15, 30)}, 2},
+		"gap in validity":         {[]*intoto.Statement{build, endorsement("SomeBinary", digest, 11, 20), deploy}, 1},
+		"validity ends too early": {[]*intoto.Statement{sign, endorsement("SomeBinary", digest, 1, 5)}, 1},
+		"invalid endorsement":     {[]*intoto.Statement{invalid, sign}, 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyEndorsementChain(tt.chain)
+			var chainErr *ChainBreakError
+			if !errors.As(err, &chainErr) {
+				t.Fatalf("got %v, want a ChainBreakError", err)
+			}
+			if chainErr.Index != tt.wantIndex {
+				t.Errorf("got break at %d, want %d: %v", chainErr.Index, tt.wantIndex, err)
+			}
+		})
+	}
+}
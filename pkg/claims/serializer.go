@@ -0,0 +1,79 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package claims
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/project-oak/transparent-release/pkg/intoto"
+)
+
+// StatementSerializer serializes endorsement statements, e.g., for writing
+// them to a file, and parses serialized endorsement statements.
+type StatementSerializer interface {
+	// Serialize encodes the given statement.
+	Serialize(statement *intoto.Statement) ([]byte, error)
+	// Deserialize decodes and validates an endorsement statement encoded with
+	// Serialize, as ParseEndorsementV2Bytes does for JSON.
+	Deserialize(data []byte) (*intoto.Statement, error)
+}
+
+// JSONSerializer is the default StatementSerializer. It encodes statements as
+// indented JSON, with a trailing newline.
+type JSONSerializer struct{}
+
+// Serialize implements StatementSerializer.
+func (JSONSerializer) Serialize(statement *intoto.Statement) ([]byte, error) {
+	bytes, err := json.MarshalIndent(statement, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal the statement into JSON: %v", err)
+	}
+	return append(bytes, '\n'), nil
+}
+
+// Deserialize implements StatementSerializer.
+func (JSONSerializer) Deserialize(data []byte) (*intoto.Statement, error) {
+	return ParseEndorsementV2Bytes(data)
+}
+
+// statementSerializers maps the names of the supported formats to their
+// serializers, see StatementSerializerFor.
+//
+//nolint:gochecknoglobals
+var statementSerializers = map[string]StatementSerializer{
+	"json": JSONSerializer{},
+	"cbor": CBORSerializer{},
+}
+
+// StatementSerializerFor returns the serializer for the given format, either
+// "json" or "cbor". The empty format selects JSONSerializer.
+func StatementSerializerFor(format string) (StatementSerializer, error) {
+	if format == "" {
+		return JSONSerializer{}, nil
+	}
+	serializer, found := statementSerializers[strings.ToLower(format)]
+	if !found {
+		formats := make([]string, 0, len(statementSerializers))
+		for name := range statementSerializers {
+			formats = append(formats, name)
+		}
+		sort.Strings(formats)
+		return nil, fmt.Errorf("unsupported format %q, want one of %q", format, formats)
+	}
+	return serializer, nil
+}
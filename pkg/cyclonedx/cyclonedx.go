@@ -0,0 +1,123 @@
+// Copyright 2023 The Project Oak Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cyclonedx provides a partial representation of CycloneDX JSON BOMs,
+// containing only the information relevant to transparent release. It is
+// used both for parsing SBOMs and for exporting endorsements.
+// See https://cyclonedx.org/docs/1.5/json/.
+package cyclonedx
+
+import "strings"
+
+const (
+	// BOMFormat is the value of the bomFormat property of CycloneDX BOMs.
+	BOMFormat = "CycloneDX"
+	// SpecVersion is the version of the CycloneDX specification that the
+	// types in this package conform to.
+	SpecVersion = "1.5"
+	// AttestationReferenceType is the type of external references that denote
+	// a provenance, or any other attestation.
+	AttestationReferenceType = "attestation"
+)
+
+// BOM is a partial representation of a CycloneDX BOM.
+type BOM struct {
+	BOMFormat          string              `json:"bomFormat"`
+	SpecVersion        string              `json:"specVersion"`
+	Version            int                 `json:"version,omitempty"`
+	Metadata           *Metadata           `json:"metadata,omitempty"`
+	Components         []Component         `json:"components,omitempty"`
+	ExternalReferences []ExternalReference `json:"externalReferences,omitempty"`
+}
+
+// Metadata is a partial representation of the metadata of a CycloneDX BOM.
+type Metadata struct {
+	// Timestamp is the creation time of the BOM, in RFC 3339 format.
+	Timestamp string `json:"timestamp,omitempty"`
+	// Component is the component described by the BOM.
+	Component  *Component `json:"component,omitempty"`
+	Properties []Property `json:"properties,omitempty"`
+}
+
+// Component is a partial representation of a CycloneDX component.
+type Component struct {
+	Type               string              `json:"type"`
+	BOMRef             string              `json:"bom-ref,omitempty"`
+	Name               string              `json:"name"`
+	Hashes             []Hash              `json:"hashes,omitempty"`
+	ExternalReferences []ExternalReference `json:"externalReferences,omitempty"`
+	// Components lists the subcomponents of the component.
+	Components []Component `json:"components,omitempty"`
+}
+
+// Hash is a digest of a component or of an external reference.
+type Hash struct {
+	// Alg is the CycloneDX name of the hash algorithm, e.g., "SHA-256".
+	Alg string `json:"alg"`
+	// Content is the hex-encoded digest.
+	Content string `json:"content"`
+}
+
+// ExternalReference is a partial representation of a CycloneDX external
+// reference.
+type ExternalReference struct {
+	Type    string `json:"type"`
+	URL     string `json:"url"`
+	Comment string `json:"comment,omitempty"`
+	Hashes  []Hash `json:"hashes,omitempty"`
+}
+
+// Property is a name-value pair.
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// hashAlgorithms maps the CycloneDX hash algorithms to the in-toto names of
+// digest algorithms. Other CycloneDX hash algorithms have no in-toto name.
+//
+//nolint:gochecknoglobals
+var hashAlgorithms = map[string]string{
+	"MD5":      "md5",
+	"SHA-1":    "sha1",
+	"SHA-256":  "sha256",
+	"SHA-384":  "sha384",
+	"SHA-512":  "sha512",
+	"SHA3-256": "sha3-256",
+	"SHA3-384": "sha3-384",
+	"SHA3-512": "sha3-512",
+}
+
+// DigestAlgorithm returns the in-toto name of the given CycloneDX hash
+// algorithm, e.g., "sha256" for "SHA-256", and false if there is none.
+func DigestAlgorithm(hashAlg string) (string, bool) {
+	alg, found := hashAlgorithms[hashAlg]
+	return alg, found
+}
+
+// HashAlgorithm returns the CycloneDX hash algorithm for the given in-toto
+// name of a digest algorithm, e.g., "SHA-256" for "sha256", and false if
+// CycloneDX does not support the algorithm. The names of SHA2 algorithms used
+// in endorsements, e.g., "sha2-256", are accepted too.
+func HashAlgorithm(digestAlg string) (string, bool) {
+	if strings.HasPrefix(digestAlg, "sha2-") {
+		digestAlg = "sha" + strings.TrimPrefix(digestAlg, "sha2-")
+	}
+	for hashAlg, alg := range hashAlgorithms {
+		if alg == digestAlg {
+			return hashAlg, true
+		}
+	}
+	return "", false
+}
@@ -25,6 +25,9 @@ const StatementInTotoV01 = "https://in-toto.io/Statement/v0.1"
 // SLSAV02PredicateType is the predicate type for all SLSA v02 provenances.
 const SLSAV02PredicateType = "https://slsa.dev/provenance/v0.2"
 
+// PayloadType is the DSSE payload type of in-toto statements.
+const PayloadType = "application/vnd.in-toto+json"
+
 // DigestSet contains a set of digests. It is represented as a map from
 // algorithm name to lowercase hex-encoded value.
 type DigestSet map[string]string
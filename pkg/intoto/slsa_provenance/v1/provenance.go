@@ -40,6 +40,10 @@ const (
 	// The `draft` in the URI signals that the format might need to change.
 	// See https://github.com/slsa-framework/github-actions-buildtypes/issues/4.
 	DockerBasedBuildType = "https://slsa.dev/container-based-build/v0.1?draft"
+
+	// OCIMediaTypePrefix is the common prefix of the media types of OCI image
+	// manifests, indexes, and configs.
+	OCIMediaTypePrefix = "application/vnd.oci.image."
 )
 
 // ProvenancePredicate defines the structure of a SLSA v1 provenance predicate.
@@ -169,6 +173,22 @@ type BuildConfig struct {
 	Command []string `toml:"command"`
 }
 
+// ParseSLSAv1Provenance parses the given object as a ProvenancePredicate of any
+// build type, leaving BuildDefinition.ExternalParameters unparsed. Returns an
+// error if the conversion is unsuccessful.
+func ParseSLSAv1Provenance(predicate interface{}) (*ProvenancePredicate, error) {
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling Predicate map into JSON bytes: %v", err)
+	}
+
+	var pred ProvenancePredicate
+	if err = json.Unmarshal(predicateBytes, &pred); err != nil {
+		return nil, fmt.Errorf("unmarshaling JSON bytes into a SLSA v1 ProvenancePredicate: %v", err)
+	}
+	return &pred, nil
+}
+
 // ParseContainerBasedSLSAv1Provenance parses the given object as a
 // ProvenancePredicate, with its BuildDefinition.ExternalParameters parsed into
 // an instance of DockerBasedExternalParameters. Returns an error if any of the
@@ -224,7 +244,55 @@ func (p *ProvenancePredicate) RepoURIAndDigest() (*string, *string) {
 	return nil, nil
 }
 
+// SourceFromResolvedDependencies returns the URI of the Git repo and the SHA1
+// commit hash of the first resolved dependency that is a Git repo, i.e., has a
+// URI starting with "git+" and a "sha1" or "gitCommit" digest, as recorded by
+// builders that do not use the container-based build type. Returns nil if
+// there is no such dependency.
+func (p *ProvenancePredicate) SourceFromResolvedDependencies() (*string, *string) {
+	for _, dependency := range p.BuildDefinition.ResolvedDependencies {
+		if !strings.HasPrefix(dependency.URI, "git+") {
+			continue
+		}
+		digest, found := dependency.Digest["sha1"]
+		if !found {
+			digest, found = dependency.Digest["gitCommit"]
+		}
+		if found {
+			uri := dependency.URI
+			return &uri, &digest
+		}
+	}
+	return nil, nil
+}
+
 // BuilderID extracts and returns the builder ID from the given ProvenancePredicate.
 func (p *ProvenancePredicate) BuilderID() string {
 	return p.RunDetails.Builder.ID
 }
+
+// OCIAnnotations returns the annotations recorded for OCI resources in the
+// given ProvenancePredicate, i.e., all resolved dependencies and byproducts
+// with an OCI media type and, for the container-based build type, the builder
+// image. If several resources have an annotation with the same key, the value
+// of the last one is used.
+func (p *ProvenancePredicate) OCIAnnotations() map[string]interface{} {
+	annotations := make(map[string]interface{})
+	// The builder image is an OCI image regardless of its media type.
+	if params, ok := p.BuildDefinition.ExternalParameters.(DockerBasedExternalParameters); ok {
+		for key, value := range params.BuilderImage.Annotations {
+			annotations[key] = value
+		}
+	}
+	resources := append([]ResourceDescriptor{}, p.BuildDefinition.ResolvedDependencies...)
+	resources = append(resources, p.RunDetails.Byproducts...)
+	for _, r := range resources {
+		if !strings.HasPrefix(r.MediaType, OCIMediaTypePrefix) {
+			continue
+		}
+		for key, value := range r.Annotations {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
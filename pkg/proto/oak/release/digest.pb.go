@@ -49,31 +49,39 @@ const (
 	Digest_SHA3_256 Digest_Type = 22
 	Digest_SHA3_224 Digest_Type = 23
 	Digest_SHA2_384 Digest_Type = 32
+	// Not a multicodec: a code from the private use range for directory tree
+	// hashes in the "h1" scheme of golang.org/x/mod/sumdb/dirhash, i.e., the
+	// SHA2-256 digest of the sorted list of SHA2-256 digests and relative
+	// paths of all files in the tree. Corresponds to the in-toto "dirHash"
+	// algorithm.
+	Digest_DIRHASH_H1 Digest_Type = 3145729
 )
 
 // Enum value maps for Digest_Type.
 var (
 	Digest_Type_name = map[int32]string{
-		0:  "IDENTITY",
-		17: "SHA1",
-		18: "SHA2_256",
-		19: "SHA2_512",
-		20: "SHA3_512",
-		21: "SHA3_384",
-		22: "SHA3_256",
-		23: "SHA3_224",
-		32: "SHA2_384",
+		0:       "IDENTITY",
+		17:      "SHA1",
+		18:      "SHA2_256",
+		19:      "SHA2_512",
+		20:      "SHA3_512",
+		21:      "SHA3_384",
+		22:      "SHA3_256",
+		23:      "SHA3_224",
+		32:      "SHA2_384",
+		3145729: "DIRHASH_H1",
 	}
 	Digest_Type_value = map[string]int32{
-		"IDENTITY": 0,
-		"SHA1":     17,
-		"SHA2_256": 18,
-		"SHA2_512": 19,
-		"SHA3_512": 20,
-		"SHA3_384": 21,
-		"SHA3_256": 22,
-		"SHA3_224": 23,
-		"SHA2_384": 32,
+		"IDENTITY":   0,
+		"SHA1":       17,
+		"SHA2_256":   18,
+		"SHA2_512":   19,
+		"SHA3_512":   20,
+		"SHA3_384":   21,
+		"SHA3_256":   22,
+		"SHA3_224":   23,
+		"SHA2_384":   32,
+		"DIRHASH_H1": 3145729,
 	}
 )
 
@@ -170,7 +178,7 @@ var File_proto_digest_proto protoreflect.FileDescriptor
 var file_proto_digest_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
-	0x65, 0x22, 0x87, 0x03, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06,
+	0x65, 0x22, 0x9a, 0x03, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06,
 	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f,
 	0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73,
 	0x74, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x62,
@@ -186,7 +194,7 @@ var file_proto_digest_proto_rawDesc = []byte{
 	0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
 	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
 	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x80, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x93, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70,
 	0x65, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x10, 0x00, 0x12,
 	0x08, 0x0a, 0x04, 0x53, 0x48, 0x41, 0x31, 0x10, 0x11, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41,
 	0x32, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x12, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x32, 0x5f,
@@ -194,9 +202,10 @@ var file_proto_digest_proto_rawDesc = []byte{
 	0x32, 0x10, 0x14, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x33, 0x38, 0x34, 0x10,
 	0x15, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x16, 0x12,
 	0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x32, 0x32, 0x34, 0x10, 0x17, 0x12, 0x0c, 0x0a,
-	0x08, 0x53, 0x48, 0x41, 0x32, 0x5f, 0x33, 0x38, 0x34, 0x10, 0x20, 0x42, 0x13, 0x5a, 0x11, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x61, 0x6b, 0x2f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x08, 0x53, 0x48, 0x41, 0x32, 0x5f, 0x33, 0x38, 0x34, 0x10, 0x20, 0x12, 0x11, 0x0a, 0x0a, 0x44,
+	0x49, 0x52, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x48, 0x31, 0x10, 0x81, 0x80, 0xc0, 0x01, 0x42, 0x13,
+	0x5a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x61, 0x6b, 0x2f, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -34,6 +34,219 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// The severity of a verification step.
+type Severity int32
+
+const (
+	// Failures of the step fail the verification.
+	Severity_ERROR Severity = 0
+	// Failures of the step are reported as warnings, but do not fail the
+	// verification.
+	Severity_WARN Severity = 1
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "ERROR",
+		1: "WARN",
+	}
+	Severity_value = map[string]int32{
+		"ERROR": 0,
+		"WARN":  1,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_verification_options_proto_enumTypes[0].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_proto_verification_options_proto_enumTypes[0]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{0}
+}
+
+// How a verification step treats provenances that lack the field checked by
+// the step.
+type MissingPolicy int32
+
+const (
+	// As documented for the step.
+	MissingPolicy_MISSING_DEFAULT MissingPolicy = 0
+	// The provenance passes the step.
+	MissingPolicy_MISSING_PASS MissingPolicy = 1
+	// The provenance fails the step.
+	MissingPolicy_MISSING_FAIL MissingPolicy = 2
+	// The provenance passes the step, but a warning is reported, regardless of
+	// the severity of the step.
+	MissingPolicy_MISSING_WARN MissingPolicy = 3
+)
+
+// Enum value maps for MissingPolicy.
+var (
+	MissingPolicy_name = map[int32]string{
+		0: "MISSING_DEFAULT",
+		1: "MISSING_PASS",
+		2: "MISSING_FAIL",
+		3: "MISSING_WARN",
+	}
+	MissingPolicy_value = map[string]int32{
+		"MISSING_DEFAULT": 0,
+		"MISSING_PASS":    1,
+		"MISSING_FAIL":    2,
+		"MISSING_WARN":    3,
+	}
+)
+
+func (x MissingPolicy) Enum() *MissingPolicy {
+	p := new(MissingPolicy)
+	*p = x
+	return p
+}
+
+func (x MissingPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MissingPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_verification_options_proto_enumTypes[1].Descriptor()
+}
+
+func (MissingPolicy) Type() protoreflect.EnumType {
+	return &file_proto_verification_options_proto_enumTypes[1]
+}
+
+func (x MissingPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MissingPolicy.Descriptor instead.
+func (MissingPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{1}
+}
+
+// Determines how the subject of a provenance is matched.
+type VerifyAllWithBinaryDigests_SubjectMatching int32
+
+const (
+	// Only the digest is matched, regardless of the name of the subject it is
+	// listed under.
+	VerifyAllWithBinaryDigests_DIGEST_ANYWHERE VerifyAllWithBinaryDigests_SubjectMatching = 0
+	// The digest must be listed under a subject with the name given in
+	// `binary_name`.
+	VerifyAllWithBinaryDigests_NAME_AND_DIGEST VerifyAllWithBinaryDigests_SubjectMatching = 1
+)
+
+// Enum value maps for VerifyAllWithBinaryDigests_SubjectMatching.
+var (
+	VerifyAllWithBinaryDigests_SubjectMatching_name = map[int32]string{
+		0: "DIGEST_ANYWHERE",
+		1: "NAME_AND_DIGEST",
+	}
+	VerifyAllWithBinaryDigests_SubjectMatching_value = map[string]int32{
+		"DIGEST_ANYWHERE": 0,
+		"NAME_AND_DIGEST": 1,
+	}
+)
+
+func (x VerifyAllWithBinaryDigests_SubjectMatching) Enum() *VerifyAllWithBinaryDigests_SubjectMatching {
+	p := new(VerifyAllWithBinaryDigests_SubjectMatching)
+	*p = x
+	return p
+}
+
+func (x VerifyAllWithBinaryDigests_SubjectMatching) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VerifyAllWithBinaryDigests_SubjectMatching) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_verification_options_proto_enumTypes[2].Descriptor()
+}
+
+func (VerifyAllWithBinaryDigests_SubjectMatching) Type() protoreflect.EnumType {
+	return &file_proto_verification_options_proto_enumTypes[2]
+}
+
+func (x VerifyAllWithBinaryDigests_SubjectMatching) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VerifyAllWithBinaryDigests_SubjectMatching.Descriptor instead.
+func (VerifyAllWithBinaryDigests_SubjectMatching) EnumDescriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type VerifyAllWithCISystem_CISystem int32
+
+const (
+	// The CI system could not be determined.
+	VerifyAllWithCISystem_UNKNOWN            VerifyAllWithCISystem_CISystem = 0
+	VerifyAllWithCISystem_GITHUB_ACTIONS     VerifyAllWithCISystem_CISystem = 1
+	VerifyAllWithCISystem_GITLAB_CI          VerifyAllWithCISystem_CISystem = 2
+	VerifyAllWithCISystem_GOOGLE_CLOUD_BUILD VerifyAllWithCISystem_CISystem = 3
+)
+
+// Enum value maps for VerifyAllWithCISystem_CISystem.
+var (
+	VerifyAllWithCISystem_CISystem_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "GITHUB_ACTIONS",
+		2: "GITLAB_CI",
+		3: "GOOGLE_CLOUD_BUILD",
+	}
+	VerifyAllWithCISystem_CISystem_value = map[string]int32{
+		"UNKNOWN":            0,
+		"GITHUB_ACTIONS":     1,
+		"GITLAB_CI":          2,
+		"GOOGLE_CLOUD_BUILD": 3,
+	}
+)
+
+func (x VerifyAllWithCISystem_CISystem) Enum() *VerifyAllWithCISystem_CISystem {
+	p := new(VerifyAllWithCISystem_CISystem)
+	*p = x
+	return p
+}
+
+func (x VerifyAllWithCISystem_CISystem) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VerifyAllWithCISystem_CISystem) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_verification_options_proto_enumTypes[3].Descriptor()
+}
+
+func (VerifyAllWithCISystem_CISystem) Type() protoreflect.EnumType {
+	return &file_proto_verification_options_proto_enumTypes[3]
+}
+
+func (x VerifyAllWithCISystem_CISystem) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VerifyAllWithCISystem_CISystem.Descriptor instead.
+func (VerifyAllWithCISystem_CISystem) EnumDescriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{24, 0}
+}
+
 // Defines a verification done on an array of provenances. Each field defines a
 // certain verification step. All steps are joined by a logical AND to form the
 // final verification result (which is a boolean). Since every option can occur
@@ -47,16 +260,68 @@ type VerificationOptions struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProvenanceCountAtLeast *VerifyProvenanceCountAtLeast `protobuf:"bytes,1,opt,name=provenance_count_at_least,json=provenanceCountAtLeast,proto3,oneof" json:"provenance_count_at_least,omitempty"`
-	ProvenanceCountAtMost  *VerifyProvenanceCountAtMost  `protobuf:"bytes,2,opt,name=provenance_count_at_most,json=provenanceCountAtMost,proto3,oneof" json:"provenance_count_at_most,omitempty"`
-	AllSameBinaryName      *VerifyAllSameBinaryName      `protobuf:"bytes,3,opt,name=all_same_binary_name,json=allSameBinaryName,proto3,oneof" json:"all_same_binary_name,omitempty"`
-	AllSameBinaryDigest    *VerifyAllSameBinaryDigest    `protobuf:"bytes,4,opt,name=all_same_binary_digest,json=allSameBinaryDigest,proto3,oneof" json:"all_same_binary_digest,omitempty"`
-	AllWithBuildCommand    *VerifyAllWithBuildCommand    `protobuf:"bytes,5,opt,name=all_with_build_command,json=allWithBuildCommand,proto3,oneof" json:"all_with_build_command,omitempty"`
-	AllWithBinaryName      *VerifyAllWithBinaryName      `protobuf:"bytes,6,opt,name=all_with_binary_name,json=allWithBinaryName,proto3,oneof" json:"all_with_binary_name,omitempty"`
-	AllWithBinaryDigests   *VerifyAllWithBinaryDigests   `protobuf:"bytes,7,opt,name=all_with_binary_digests,json=allWithBinaryDigests,proto3,oneof" json:"all_with_binary_digests,omitempty"`
-	AllWithBuilderNames    *VerifyAllWithBuilderNames    `protobuf:"bytes,8,opt,name=all_with_builder_names,json=allWithBuilderNames,proto3,oneof" json:"all_with_builder_names,omitempty"`
-	AllWithBuilderDigests  *VerifyAllWithBuilderDigests  `protobuf:"bytes,9,opt,name=all_with_builder_digests,json=allWithBuilderDigests,proto3,oneof" json:"all_with_builder_digests,omitempty"`
-	AllWithRepository      *VerifyAllWithRepository      `protobuf:"bytes,10,opt,name=all_with_repository,json=allWithRepository,proto3,oneof" json:"all_with_repository,omitempty"`
+	ProvenanceCountAtLeast          *VerifyProvenanceCountAtLeast          `protobuf:"bytes,1,opt,name=provenance_count_at_least,json=provenanceCountAtLeast,proto3,oneof" json:"provenance_count_at_least,omitempty"`
+	ProvenanceCountAtMost           *VerifyProvenanceCountAtMost           `protobuf:"bytes,2,opt,name=provenance_count_at_most,json=provenanceCountAtMost,proto3,oneof" json:"provenance_count_at_most,omitempty"`
+	AllSameBinaryName               *VerifyAllSameBinaryName               `protobuf:"bytes,3,opt,name=all_same_binary_name,json=allSameBinaryName,proto3,oneof" json:"all_same_binary_name,omitempty"`
+	AllSameBinaryDigest             *VerifyAllSameBinaryDigest             `protobuf:"bytes,4,opt,name=all_same_binary_digest,json=allSameBinaryDigest,proto3,oneof" json:"all_same_binary_digest,omitempty"`
+	AllWithBuildCommand             *VerifyAllWithBuildCommand             `protobuf:"bytes,5,opt,name=all_with_build_command,json=allWithBuildCommand,proto3,oneof" json:"all_with_build_command,omitempty"`
+	AllWithBinaryName               *VerifyAllWithBinaryName               `protobuf:"bytes,6,opt,name=all_with_binary_name,json=allWithBinaryName,proto3,oneof" json:"all_with_binary_name,omitempty"`
+	AllWithBinaryDigests            *VerifyAllWithBinaryDigests            `protobuf:"bytes,7,opt,name=all_with_binary_digests,json=allWithBinaryDigests,proto3,oneof" json:"all_with_binary_digests,omitempty"`
+	AllWithBuilderNames             *VerifyAllWithBuilderNames             `protobuf:"bytes,8,opt,name=all_with_builder_names,json=allWithBuilderNames,proto3,oneof" json:"all_with_builder_names,omitempty"`
+	AllWithBuilderDigests           *VerifyAllWithBuilderDigests           `protobuf:"bytes,9,opt,name=all_with_builder_digests,json=allWithBuilderDigests,proto3,oneof" json:"all_with_builder_digests,omitempty"`
+	AllWithRepository               *VerifyAllWithRepository               `protobuf:"bytes,10,opt,name=all_with_repository,json=allWithRepository,proto3,oneof" json:"all_with_repository,omitempty"`
+	AllWithBinaryNamePattern        *VerifyAllWithBinaryNamePattern        `protobuf:"bytes,11,opt,name=all_with_binary_name_pattern,json=allWithBinaryNamePattern,proto3,oneof" json:"all_with_binary_name_pattern,omitempty"`
+	AllWithInvocationParameters     *VerifyAllWithInvocationParameters     `protobuf:"bytes,12,opt,name=all_with_invocation_parameters,json=allWithInvocationParameters,proto3,oneof" json:"all_with_invocation_parameters,omitempty"`
+	AllSignedWithinDaysOfBuild      *VerifyAllSignedWithinDaysOfBuild      `protobuf:"bytes,13,opt,name=all_signed_within_days_of_build,json=allSignedWithinDaysOfBuild,proto3,oneof" json:"all_signed_within_days_of_build,omitempty"`
+	TrustedGenerator                *VerifyTrustedGenerator                `protobuf:"bytes,14,opt,name=trusted_generator,json=trustedGenerator,proto3,oneof" json:"trusted_generator,omitempty"`
+	SourceIdentity                  *VerifySourceIdentity                  `protobuf:"bytes,15,opt,name=source_identity,json=sourceIdentity,proto3,oneof" json:"source_identity,omitempty"`
+	AllReproducible                 *VerifyAllReproducible                 `protobuf:"bytes,16,opt,name=all_reproducible,json=allReproducible,proto3,oneof" json:"all_reproducible,omitempty"`
+	AllWithinDocumentSize           *VerifyAllWithinDocumentSize           `protobuf:"bytes,17,opt,name=all_within_document_size,json=allWithinDocumentSize,proto3,oneof" json:"all_within_document_size,omitempty"`
+	BuilderSignature                *VerifyBuilderSignature                `protobuf:"bytes,18,opt,name=builder_signature,json=builderSignature,proto3,oneof" json:"builder_signature,omitempty"`
+	DistinctSigners                 *VerifyDistinctSigners                 `protobuf:"bytes,19,opt,name=distinct_signers,json=distinctSigners,proto3,oneof" json:"distinct_signers,omitempty"`
+	AllWithOciAnnotations           *VerifyAllWithOCIAnnotations           `protobuf:"bytes,20,opt,name=all_with_oci_annotations,json=allWithOciAnnotations,proto3,oneof" json:"all_with_oci_annotations,omitempty"`
+	AllBuiltWithinDays              *VerifyAllBuiltWithinDays              `protobuf:"bytes,21,opt,name=all_built_within_days,json=allBuiltWithinDays,proto3,oneof" json:"all_built_within_days,omitempty"`
+	AllNotInDigestBlocklist         *VerifyAllNotInDigestBlocklist         `protobuf:"bytes,23,opt,name=all_not_in_digest_blocklist,json=allNotInDigestBlocklist,proto3,oneof" json:"all_not_in_digest_blocklist,omitempty"`
+	AllWithCiSystem                 *VerifyAllWithCISystem                 `protobuf:"bytes,24,opt,name=all_with_ci_system,json=allWithCiSystem,proto3,oneof" json:"all_with_ci_system,omitempty"`
+	EmptyArtifacts                  *VerifyEmptyArtifacts                  `protobuf:"bytes,25,opt,name=empty_artifacts,json=emptyArtifacts,proto3,oneof" json:"empty_artifacts,omitempty"`
+	AllWithCanonicalDependencies    *VerifyAllWithCanonicalDependencies    `protobuf:"bytes,26,opt,name=all_with_canonical_dependencies,json=allWithCanonicalDependencies,proto3,oneof" json:"all_with_canonical_dependencies,omitempty"`
+	MaxValidity                     *VerifyMaxValidity                     `protobuf:"bytes,27,opt,name=max_validity,json=maxValidity,proto3,oneof" json:"max_validity,omitempty"`
+	AllWithPlatform                 *VerifyAllWithPlatform                 `protobuf:"bytes,28,opt,name=all_with_platform,json=allWithPlatform,proto3,oneof" json:"all_with_platform,omitempty"`
+	AllWithMinDependencies          *VerifyAllWithMinDependencies          `protobuf:"bytes,29,opt,name=all_with_min_dependencies,json=allWithMinDependencies,proto3,oneof" json:"all_with_min_dependencies,omitempty"`
+	IndependentReproduction         *VerifyIndependentReproduction         `protobuf:"bytes,30,opt,name=independent_reproduction,json=independentReproduction,proto3,oneof" json:"independent_reproduction,omitempty"`
+	AllWithBinaryDigestsAnyOf       *VerifyAllWithBinaryDigestsAnyOf       `protobuf:"bytes,31,opt,name=all_with_binary_digests_any_of,json=allWithBinaryDigestsAnyOf,proto3,oneof" json:"all_with_binary_digests_any_of,omitempty"`
+	AllWithEnvironmentDigest        *VerifyAllWithEnvironmentDigest        `protobuf:"bytes,32,opt,name=all_with_environment_digest,json=allWithEnvironmentDigest,proto3,oneof" json:"all_with_environment_digest,omitempty"`
+	AllWithImageReference           *VerifyAllWithImageReference           `protobuf:"bytes,33,opt,name=all_with_image_reference,json=allWithImageReference,proto3,oneof" json:"all_with_image_reference,omitempty"`
+	AllWithSourceMaterial           *VerifyAllWithSourceMaterial           `protobuf:"bytes,34,opt,name=all_with_source_material,json=allWithSourceMaterial,proto3,oneof" json:"all_with_source_material,omitempty"`
+	AllWithBuildDuration            *VerifyAllWithBuildDuration            `protobuf:"bytes,35,opt,name=all_with_build_duration,json=allWithBuildDuration,proto3,oneof" json:"all_with_build_duration,omitempty"`
+	AllHaveInvocationId             *VerifyAllHaveInvocationID             `protobuf:"bytes,36,opt,name=all_have_invocation_id,json=allHaveInvocationId,proto3,oneof" json:"all_have_invocation_id,omitempty"`
+	MaterialsComplete               *VerifyMaterialsComplete               `protobuf:"bytes,37,opt,name=materials_complete,json=materialsComplete,proto3,oneof" json:"materials_complete,omitempty"`
+	AllDistinctFromMaterials        *VerifyAllDistinctFromMaterials        `protobuf:"bytes,38,opt,name=all_distinct_from_materials,json=allDistinctFromMaterials,proto3,oneof" json:"all_distinct_from_materials,omitempty"`
+	NotOlderThan                    *VerifyNotOlderThan                    `protobuf:"bytes,39,opt,name=not_older_than,json=notOlderThan,proto3,oneof" json:"not_older_than,omitempty"`
+	BuilderOrg                      *VerifyBuilderOrg                      `protobuf:"bytes,40,opt,name=builder_org,json=builderOrg,proto3,oneof" json:"builder_org,omitempty"`
+	AllWithinOwnValidity            *VerifyAllWithinOwnValidity            `protobuf:"bytes,41,opt,name=all_within_own_validity,json=allWithinOwnValidity,proto3,oneof" json:"all_within_own_validity,omitempty"`
+	AllWithBuildType                *VerifyAllWithBuildType                `protobuf:"bytes,42,opt,name=all_with_build_type,json=allWithBuildType,proto3,oneof" json:"all_with_build_type,omitempty"`
+	CommitSignature                 *VerifyCommitSignature                 `protobuf:"bytes,43,opt,name=commit_signature,json=commitSignature,proto3,oneof" json:"commit_signature,omitempty"`
+	AllWithBuilderId                *VerifyAllWithBuilderID                `protobuf:"bytes,44,opt,name=all_with_builder_id,json=allWithBuilderId,proto3,oneof" json:"all_with_builder_id,omitempty"`
+	AllWithSourceRepo               *VerifyAllWithSourceRepo               `protobuf:"bytes,45,opt,name=all_with_source_repo,json=allWithSourceRepo,proto3,oneof" json:"all_with_source_repo,omitempty"`
+	AllWithImageManifestDigest      *VerifyAllWithImageManifestDigest      `protobuf:"bytes,47,opt,name=all_with_image_manifest_digest,json=allWithImageManifestDigest,proto3,oneof" json:"all_with_image_manifest_digest,omitempty"`
+	AllWithMinByproducts            *VerifyAllWithMinByproducts            `protobuf:"bytes,48,opt,name=all_with_min_byproducts,json=allWithMinByproducts,proto3,oneof" json:"all_with_min_byproducts,omitempty"`
+	AllWithExternalParametersDigest *VerifyAllWithExternalParametersDigest `protobuf:"bytes,49,opt,name=all_with_external_parameters_digest,json=allWithExternalParametersDigest,proto3,oneof" json:"all_with_external_parameters_digest,omitempty"`
+	// Overrides the severity of individual verification steps, keyed by the
+	// name of the field of the step in this message, e.g.,
+	// "all_built_within_days". Steps not listed here have severity ERROR.
+	Severities map[string]Severity `protobuf:"bytes,22,rep,name=severities,proto3" json:"severities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=oak.release.Severity"`
+	// Overrides how individual verification steps treat provenances that lack
+	// the field checked by the step, e.g., the build time for
+	// "all_built_within_days", keyed by the name of the field of the step in
+	// this message. Supported by all_with_environment_digest,
+	// all_signed_within_days_of_build, trusted_generator (the generator
+	// version), all_with_platform, all_with_build_duration,
+	// all_built_within_days, not_older_than, all_within_own_validity,
+	// commit_signature, builder_org, all_with_builder_id,
+	// all_with_source_repo, and all_with_external_parameters_digest. Steps not
+	// listed here treat missing fields as documented for the step.
+	OnMissing map[string]MissingPolicy `protobuf:"bytes,46,rep,name=on_missing,json=onMissing,proto3" json:"on_missing,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=oak.release.MissingPolicy"`
 }
 
 func (x *VerificationOptions) Reset() {
@@ -161,250 +426,305 @@ func (x *VerificationOptions) GetAllWithRepository() *VerifyAllWithRepository {
 	return nil
 }
 
-// Verifies that the number of provenances is at least the specified count.
-type VerifyProvenanceCountAtLeast struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+func (x *VerificationOptions) GetAllWithBinaryNamePattern() *VerifyAllWithBinaryNamePattern {
+	if x != nil {
+		return x.AllWithBinaryNamePattern
+	}
+	return nil
 }
 
-func (x *VerifyProvenanceCountAtLeast) Reset() {
-	*x = VerifyProvenanceCountAtLeast{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VerificationOptions) GetAllWithInvocationParameters() *VerifyAllWithInvocationParameters {
+	if x != nil {
+		return x.AllWithInvocationParameters
 	}
+	return nil
 }
 
-func (x *VerifyProvenanceCountAtLeast) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VerificationOptions) GetAllSignedWithinDaysOfBuild() *VerifyAllSignedWithinDaysOfBuild {
+	if x != nil {
+		return x.AllSignedWithinDaysOfBuild
+	}
+	return nil
 }
 
-func (*VerifyProvenanceCountAtLeast) ProtoMessage() {}
+func (x *VerificationOptions) GetTrustedGenerator() *VerifyTrustedGenerator {
+	if x != nil {
+		return x.TrustedGenerator
+	}
+	return nil
+}
 
-func (x *VerifyProvenanceCountAtLeast) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VerificationOptions) GetSourceIdentity() *VerifySourceIdentity {
+	if x != nil {
+		return x.SourceIdentity
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use VerifyProvenanceCountAtLeast.ProtoReflect.Descriptor instead.
-func (*VerifyProvenanceCountAtLeast) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{1}
+func (x *VerificationOptions) GetAllReproducible() *VerifyAllReproducible {
+	if x != nil {
+		return x.AllReproducible
+	}
+	return nil
 }
 
-func (x *VerifyProvenanceCountAtLeast) GetCount() int32 {
+func (x *VerificationOptions) GetAllWithinDocumentSize() *VerifyAllWithinDocumentSize {
 	if x != nil {
-		return x.Count
+		return x.AllWithinDocumentSize
 	}
-	return 0
+	return nil
 }
 
-// Verifies that the number of provenances is at most the specified count.
-type VerifyProvenanceCountAtMost struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *VerificationOptions) GetBuilderSignature() *VerifyBuilderSignature {
+	if x != nil {
+		return x.BuilderSignature
+	}
+	return nil
+}
 
-	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+func (x *VerificationOptions) GetDistinctSigners() *VerifyDistinctSigners {
+	if x != nil {
+		return x.DistinctSigners
+	}
+	return nil
 }
 
-func (x *VerifyProvenanceCountAtMost) Reset() {
-	*x = VerifyProvenanceCountAtMost{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VerificationOptions) GetAllWithOciAnnotations() *VerifyAllWithOCIAnnotations {
+	if x != nil {
+		return x.AllWithOciAnnotations
 	}
+	return nil
 }
 
-func (x *VerifyProvenanceCountAtMost) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VerificationOptions) GetAllBuiltWithinDays() *VerifyAllBuiltWithinDays {
+	if x != nil {
+		return x.AllBuiltWithinDays
+	}
+	return nil
 }
 
-func (*VerifyProvenanceCountAtMost) ProtoMessage() {}
+func (x *VerificationOptions) GetAllNotInDigestBlocklist() *VerifyAllNotInDigestBlocklist {
+	if x != nil {
+		return x.AllNotInDigestBlocklist
+	}
+	return nil
+}
 
-func (x *VerifyProvenanceCountAtMost) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VerificationOptions) GetAllWithCiSystem() *VerifyAllWithCISystem {
+	if x != nil {
+		return x.AllWithCiSystem
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use VerifyProvenanceCountAtMost.ProtoReflect.Descriptor instead.
-func (*VerifyProvenanceCountAtMost) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{2}
+func (x *VerificationOptions) GetEmptyArtifacts() *VerifyEmptyArtifacts {
+	if x != nil {
+		return x.EmptyArtifacts
+	}
+	return nil
 }
 
-func (x *VerifyProvenanceCountAtMost) GetCount() int32 {
+func (x *VerificationOptions) GetAllWithCanonicalDependencies() *VerifyAllWithCanonicalDependencies {
 	if x != nil {
-		return x.Count
+		return x.AllWithCanonicalDependencies
 	}
-	return 0
+	return nil
 }
 
-// Requires that all provenances have the same underlying binary name.
-// Verification step will pass if there are <= 1 provenances.
-type VerifyAllSameBinaryName struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *VerificationOptions) GetMaxValidity() *VerifyMaxValidity {
+	if x != nil {
+		return x.MaxValidity
+	}
+	return nil
 }
 
-func (x *VerifyAllSameBinaryName) Reset() {
-	*x = VerifyAllSameBinaryName{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VerificationOptions) GetAllWithPlatform() *VerifyAllWithPlatform {
+	if x != nil {
+		return x.AllWithPlatform
 	}
+	return nil
 }
 
-func (x *VerifyAllSameBinaryName) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VerificationOptions) GetAllWithMinDependencies() *VerifyAllWithMinDependencies {
+	if x != nil {
+		return x.AllWithMinDependencies
+	}
+	return nil
 }
 
-func (*VerifyAllSameBinaryName) ProtoMessage() {}
+func (x *VerificationOptions) GetIndependentReproduction() *VerifyIndependentReproduction {
+	if x != nil {
+		return x.IndependentReproduction
+	}
+	return nil
+}
 
-func (x *VerifyAllSameBinaryName) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VerificationOptions) GetAllWithBinaryDigestsAnyOf() *VerifyAllWithBinaryDigestsAnyOf {
+	if x != nil {
+		return x.AllWithBinaryDigestsAnyOf
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use VerifyAllSameBinaryName.ProtoReflect.Descriptor instead.
-func (*VerifyAllSameBinaryName) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{3}
+func (x *VerificationOptions) GetAllWithEnvironmentDigest() *VerifyAllWithEnvironmentDigest {
+	if x != nil {
+		return x.AllWithEnvironmentDigest
+	}
+	return nil
 }
 
-// Requires that all provenances have the same binary digest.
-// Verification step will pass if there are <= 1 provenances, or if equality
-// cannot be determined since some digests are in a different format.
-type VerifyAllSameBinaryDigest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *VerificationOptions) GetAllWithImageReference() *VerifyAllWithImageReference {
+	if x != nil {
+		return x.AllWithImageReference
+	}
+	return nil
 }
 
-func (x *VerifyAllSameBinaryDigest) Reset() {
-	*x = VerifyAllSameBinaryDigest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VerificationOptions) GetAllWithSourceMaterial() *VerifyAllWithSourceMaterial {
+	if x != nil {
+		return x.AllWithSourceMaterial
 	}
+	return nil
 }
 
-func (x *VerifyAllSameBinaryDigest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VerificationOptions) GetAllWithBuildDuration() *VerifyAllWithBuildDuration {
+	if x != nil {
+		return x.AllWithBuildDuration
+	}
+	return nil
 }
 
-func (*VerifyAllSameBinaryDigest) ProtoMessage() {}
+func (x *VerificationOptions) GetAllHaveInvocationId() *VerifyAllHaveInvocationID {
+	if x != nil {
+		return x.AllHaveInvocationId
+	}
+	return nil
+}
 
-func (x *VerifyAllSameBinaryDigest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VerificationOptions) GetMaterialsComplete() *VerifyMaterialsComplete {
+	if x != nil {
+		return x.MaterialsComplete
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use VerifyAllSameBinaryDigest.ProtoReflect.Descriptor instead.
-func (*VerifyAllSameBinaryDigest) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{4}
+func (x *VerificationOptions) GetAllDistinctFromMaterials() *VerifyAllDistinctFromMaterials {
+	if x != nil {
+		return x.AllDistinctFromMaterials
+	}
+	return nil
 }
 
-// Requires that a build command is available on every single provenance.
-type VerifyAllWithBuildCommand struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *VerificationOptions) GetNotOlderThan() *VerifyNotOlderThan {
+	if x != nil {
+		return x.NotOlderThan
+	}
+	return nil
 }
 
-func (x *VerifyAllWithBuildCommand) Reset() {
-	*x = VerifyAllWithBuildCommand{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *VerificationOptions) GetBuilderOrg() *VerifyBuilderOrg {
+	if x != nil {
+		return x.BuilderOrg
 	}
+	return nil
 }
 
-func (x *VerifyAllWithBuildCommand) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *VerificationOptions) GetAllWithinOwnValidity() *VerifyAllWithinOwnValidity {
+	if x != nil {
+		return x.AllWithinOwnValidity
+	}
+	return nil
 }
 
-func (*VerifyAllWithBuildCommand) ProtoMessage() {}
+func (x *VerificationOptions) GetAllWithBuildType() *VerifyAllWithBuildType {
+	if x != nil {
+		return x.AllWithBuildType
+	}
+	return nil
+}
 
-func (x *VerifyAllWithBuildCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *VerificationOptions) GetCommitSignature() *VerifyCommitSignature {
+	if x != nil {
+		return x.CommitSignature
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use VerifyAllWithBuildCommand.ProtoReflect.Descriptor instead.
-func (*VerifyAllWithBuildCommand) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{5}
+func (x *VerificationOptions) GetAllWithBuilderId() *VerifyAllWithBuilderID {
+	if x != nil {
+		return x.AllWithBuilderId
+	}
+	return nil
 }
 
-// Verifies that the binary name coincides with the specified one, for all
-// available provenances. The binary name must be set, so an empty string is not
-// permitted.
-type VerifyAllWithBinaryName struct {
+func (x *VerificationOptions) GetAllWithSourceRepo() *VerifyAllWithSourceRepo {
+	if x != nil {
+		return x.AllWithSourceRepo
+	}
+	return nil
+}
+
+func (x *VerificationOptions) GetAllWithImageManifestDigest() *VerifyAllWithImageManifestDigest {
+	if x != nil {
+		return x.AllWithImageManifestDigest
+	}
+	return nil
+}
+
+func (x *VerificationOptions) GetAllWithMinByproducts() *VerifyAllWithMinByproducts {
+	if x != nil {
+		return x.AllWithMinByproducts
+	}
+	return nil
+}
+
+func (x *VerificationOptions) GetAllWithExternalParametersDigest() *VerifyAllWithExternalParametersDigest {
+	if x != nil {
+		return x.AllWithExternalParametersDigest
+	}
+	return nil
+}
+
+func (x *VerificationOptions) GetSeverities() map[string]Severity {
+	if x != nil {
+		return x.Severities
+	}
+	return nil
+}
+
+func (x *VerificationOptions) GetOnMissing() map[string]MissingPolicy {
+	if x != nil {
+		return x.OnMissing
+	}
+	return nil
+}
+
+// Verifies that the number of provenances is at least the specified count.
+type VerifyProvenanceCountAtLeast struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BinaryName string `protobuf:"bytes,1,opt,name=binary_name,json=binaryName,proto3" json:"binary_name,omitempty"`
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *VerifyAllWithBinaryName) Reset() {
-	*x = VerifyAllWithBinaryName{}
+func (x *VerifyProvenanceCountAtLeast) Reset() {
+	*x = VerifyProvenanceCountAtLeast{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[6]
+		mi := &file_proto_verification_options_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifyAllWithBinaryName) String() string {
+func (x *VerifyProvenanceCountAtLeast) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyAllWithBinaryName) ProtoMessage() {}
+func (*VerifyProvenanceCountAtLeast) ProtoMessage() {}
 
-func (x *VerifyAllWithBinaryName) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[6]
+func (x *VerifyProvenanceCountAtLeast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,46 +735,44 @@ func (x *VerifyAllWithBinaryName) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyAllWithBinaryName.ProtoReflect.Descriptor instead.
-func (*VerifyAllWithBinaryName) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use VerifyProvenanceCountAtLeast.ProtoReflect.Descriptor instead.
+func (*VerifyProvenanceCountAtLeast) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *VerifyAllWithBinaryName) GetBinaryName() string {
+func (x *VerifyProvenanceCountAtLeast) GetCount() int32 {
 	if x != nil {
-		return x.BinaryName
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-// Verifies that the binary digest specified in the provenance match ONE of the
-// specified ones. It is possible to specify more than one digest of the same
-// format.
-type VerifyAllWithBinaryDigests struct {
+// Verifies that the number of provenances is at most the specified count.
+type VerifyProvenanceCountAtMost struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Digests []*Digest `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *VerifyAllWithBinaryDigests) Reset() {
-	*x = VerifyAllWithBinaryDigests{}
+func (x *VerifyProvenanceCountAtMost) Reset() {
+	*x = VerifyProvenanceCountAtMost{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[7]
+		mi := &file_proto_verification_options_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifyAllWithBinaryDigests) String() string {
+func (x *VerifyProvenanceCountAtMost) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyAllWithBinaryDigests) ProtoMessage() {}
+func (*VerifyProvenanceCountAtMost) ProtoMessage() {}
 
-func (x *VerifyAllWithBinaryDigests) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[7]
+func (x *VerifyProvenanceCountAtMost) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,45 +783,43 @@ func (x *VerifyAllWithBinaryDigests) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyAllWithBinaryDigests.ProtoReflect.Descriptor instead.
-func (*VerifyAllWithBinaryDigests) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use VerifyProvenanceCountAtMost.ProtoReflect.Descriptor instead.
+func (*VerifyProvenanceCountAtMost) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *VerifyAllWithBinaryDigests) GetDigests() []*Digest {
+func (x *VerifyProvenanceCountAtMost) GetCount() int32 {
 	if x != nil {
-		return x.Digests
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-// Verifies that the repository coincides with the specified one, for all
-// available provenances.
-type VerifyAllWithRepository struct {
+// Requires that all provenances have the same underlying binary name.
+// Verification step will pass if there are <= 1 provenances.
+type VerifyAllSameBinaryName struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	RepositoryUri string `protobuf:"bytes,1,opt,name=repository_uri,json=repositoryUri,proto3" json:"repository_uri,omitempty"`
 }
 
-func (x *VerifyAllWithRepository) Reset() {
-	*x = VerifyAllWithRepository{}
+func (x *VerifyAllSameBinaryName) Reset() {
+	*x = VerifyAllSameBinaryName{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[8]
+		mi := &file_proto_verification_options_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifyAllWithRepository) String() string {
+func (x *VerifyAllSameBinaryName) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyAllWithRepository) ProtoMessage() {}
+func (*VerifyAllSameBinaryName) ProtoMessage() {}
 
-func (x *VerifyAllWithRepository) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[8]
+func (x *VerifyAllSameBinaryName) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,46 +830,39 @@ func (x *VerifyAllWithRepository) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyAllWithRepository.ProtoReflect.Descriptor instead.
-func (*VerifyAllWithRepository) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *VerifyAllWithRepository) GetRepositoryUri() string {
-	if x != nil {
-		return x.RepositoryUri
-	}
-	return ""
+// Deprecated: Use VerifyAllSameBinaryName.ProtoReflect.Descriptor instead.
+func (*VerifyAllSameBinaryName) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{3}
 }
 
-// Verifies that the trusted builder is among the specified ones, for all
-// available provenances. Can use the empty string to match provenances where
-// the builder URI is unavailable.
-type VerifyAllWithBuilderNames struct {
+// Requires that all provenances have the same binary digest. The digests of
+// every provenance are compared with those of the first one for all the
+// algorithms they have in common, e.g., SHA2-256 or SHA2-512, and must agree
+// for all of them. Provenances without an algorithm in common with the first
+// one fail. Verification step will pass if there are <= 1 provenances.
+type VerifyAllSameBinaryDigest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	BuilderNames []string `protobuf:"bytes,1,rep,name=builder_names,json=builderNames,proto3" json:"builder_names,omitempty"`
 }
 
-func (x *VerifyAllWithBuilderNames) Reset() {
-	*x = VerifyAllWithBuilderNames{}
+func (x *VerifyAllSameBinaryDigest) Reset() {
+	*x = VerifyAllSameBinaryDigest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[9]
+		mi := &file_proto_verification_options_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifyAllWithBuilderNames) String() string {
+func (x *VerifyAllSameBinaryDigest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyAllWithBuilderNames) ProtoMessage() {}
+func (*VerifyAllSameBinaryDigest) ProtoMessage() {}
 
-func (x *VerifyAllWithBuilderNames) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[9]
+func (x *VerifyAllSameBinaryDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,45 +873,35 @@ func (x *VerifyAllWithBuilderNames) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyAllWithBuilderNames.ProtoReflect.Descriptor instead.
-func (*VerifyAllWithBuilderNames) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *VerifyAllWithBuilderNames) GetBuilderNames() []string {
-	if x != nil {
-		return x.BuilderNames
-	}
-	return nil
+// Deprecated: Use VerifyAllSameBinaryDigest.ProtoReflect.Descriptor instead.
+func (*VerifyAllSameBinaryDigest) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{4}
 }
 
-// Verifies that the trusted builder is among the specified ones, for all
-// available provenances.
-type VerifyAllWithBuilderDigests struct {
+// Requires that a build command is available on every single provenance.
+type VerifyAllWithBuildCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Digests []*Digest `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
 }
 
-func (x *VerifyAllWithBuilderDigests) Reset() {
-	*x = VerifyAllWithBuilderDigests{}
+func (x *VerifyAllWithBuildCommand) Reset() {
+	*x = VerifyAllWithBuildCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_verification_options_proto_msgTypes[10]
+		mi := &file_proto_verification_options_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VerifyAllWithBuilderDigests) String() string {
+func (x *VerifyAllWithBuildCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyAllWithBuilderDigests) ProtoMessage() {}
+func (*VerifyAllWithBuildCommand) ProtoMessage() {}
 
-func (x *VerifyAllWithBuilderDigests) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_verification_options_proto_msgTypes[10]
+func (x *VerifyAllWithBuildCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -613,14 +912,2328 @@ func (x *VerifyAllWithBuilderDigests) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyAllWithBuilderDigests.ProtoReflect.Descriptor instead.
-func (*VerifyAllWithBuilderDigests) Descriptor() ([]byte, []int) {
-	return file_proto_verification_options_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use VerifyAllWithBuildCommand.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBuildCommand) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *VerifyAllWithBuilderDigests) GetDigests() []*Digest {
-	if x != nil {
-		return x.Digests
+// Verifies that the binary name coincides with the specified one, for all
+// available provenances. The binary name must be set, so an empty string is not
+// permitted.
+type VerifyAllWithBinaryName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BinaryName string `protobuf:"bytes,1,opt,name=binary_name,json=binaryName,proto3" json:"binary_name,omitempty"`
+}
+
+func (x *VerifyAllWithBinaryName) Reset() {
+	*x = VerifyAllWithBinaryName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBinaryName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBinaryName) ProtoMessage() {}
+
+func (x *VerifyAllWithBinaryName) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBinaryName.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBinaryName) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VerifyAllWithBinaryName) GetBinaryName() string {
+	if x != nil {
+		return x.BinaryName
+	}
+	return ""
+}
+
+// Verifies that the binary name matches the specified regular expression, for
+// all available provenances. The pattern uses RE2 syntax and must match the
+// whole binary name, e.g. "app-[0-9.]+-linux-amd64".
+type VerifyAllWithBinaryNamePattern struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+}
+
+func (x *VerifyAllWithBinaryNamePattern) Reset() {
+	*x = VerifyAllWithBinaryNamePattern{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBinaryNamePattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBinaryNamePattern) ProtoMessage() {}
+
+func (x *VerifyAllWithBinaryNamePattern) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBinaryNamePattern.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBinaryNamePattern) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VerifyAllWithBinaryNamePattern) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// Verifies that the binary digest specified in the provenance match ONE of the
+// specified ones. It is possible to specify more than one digest of the same
+// format. A digest matches if the provenance lists a digest of the binary with
+// at least one of its algorithms, and all digests listed with its algorithms
+// agree. Values in the `hexadecimal` maps of the digests may alternatively be
+// base64-encoded (standard or URL-safe alphabet, with or without padding);
+// they are converted to lowercase hex before comparison.
+type VerifyAllWithBinaryDigests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digests         []*Digest                                  `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+	SubjectMatching VerifyAllWithBinaryDigests_SubjectMatching `protobuf:"varint,2,opt,name=subject_matching,json=subjectMatching,proto3,enum=oak.release.VerifyAllWithBinaryDigests_SubjectMatching" json:"subject_matching,omitempty"`
+	// The expected subject name. Required if `subject_matching` is
+	// NAME_AND_DIGEST, and ignored otherwise.
+	BinaryName string `protobuf:"bytes,3,opt,name=binary_name,json=binaryName,proto3" json:"binary_name,omitempty"`
+}
+
+func (x *VerifyAllWithBinaryDigests) Reset() {
+	*x = VerifyAllWithBinaryDigests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBinaryDigests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBinaryDigests) ProtoMessage() {}
+
+func (x *VerifyAllWithBinaryDigests) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBinaryDigests.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBinaryDigests) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VerifyAllWithBinaryDigests) GetDigests() []*Digest {
+	if x != nil {
+		return x.Digests
+	}
+	return nil
+}
+
+func (x *VerifyAllWithBinaryDigests) GetSubjectMatching() VerifyAllWithBinaryDigests_SubjectMatching {
+	if x != nil {
+		return x.SubjectMatching
+	}
+	return VerifyAllWithBinaryDigests_DIGEST_ANYWHERE
+}
+
+func (x *VerifyAllWithBinaryDigests) GetBinaryName() string {
+	if x != nil {
+		return x.BinaryName
+	}
+	return ""
+}
+
+// Verifies that the repository coincides with the specified one, for all
+// available provenances.
+type VerifyAllWithRepository struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RepositoryUri string `protobuf:"bytes,1,opt,name=repository_uri,json=repositoryUri,proto3" json:"repository_uri,omitempty"`
+}
+
+func (x *VerifyAllWithRepository) Reset() {
+	*x = VerifyAllWithRepository{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithRepository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithRepository) ProtoMessage() {}
+
+func (x *VerifyAllWithRepository) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithRepository.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithRepository) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyAllWithRepository) GetRepositoryUri() string {
+	if x != nil {
+		return x.RepositoryUri
+	}
+	return ""
+}
+
+// Verifies that the trusted builder is among the specified ones, for all
+// available provenances. Can use the empty string to match provenances where
+// the builder URI is unavailable.
+type VerifyAllWithBuilderNames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuilderNames []string `protobuf:"bytes,1,rep,name=builder_names,json=builderNames,proto3" json:"builder_names,omitempty"`
+}
+
+func (x *VerifyAllWithBuilderNames) Reset() {
+	*x = VerifyAllWithBuilderNames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBuilderNames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBuilderNames) ProtoMessage() {}
+
+func (x *VerifyAllWithBuilderNames) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBuilderNames.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBuilderNames) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyAllWithBuilderNames) GetBuilderNames() []string {
+	if x != nil {
+		return x.BuilderNames
+	}
+	return nil
+}
+
+// Verifies that the trusted builder is among the specified ones, for all
+// available provenances.
+type VerifyAllWithBuilderDigests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digests []*Digest `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+}
+
+func (x *VerifyAllWithBuilderDigests) Reset() {
+	*x = VerifyAllWithBuilderDigests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBuilderDigests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBuilderDigests) ProtoMessage() {}
+
+func (x *VerifyAllWithBuilderDigests) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBuilderDigests.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBuilderDigests) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyAllWithBuilderDigests) GetDigests() []*Digest {
+	if x != nil {
+		return x.Digests
+	}
+	return nil
+}
+
+// Verifies that the build invocation of every provenance has all the specified
+// parameters, with the specified values. Additional parameters in the
+// provenance are permitted. Non-string parameter values are compared by their
+// JSON encoding, e.g. "true" or "42".
+type VerifyAllWithInvocationParameters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parameters map[string]string `protobuf:"bytes,1,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VerifyAllWithInvocationParameters) Reset() {
+	*x = VerifyAllWithInvocationParameters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithInvocationParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithInvocationParameters) ProtoMessage() {}
+
+func (x *VerifyAllWithInvocationParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithInvocationParameters.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithInvocationParameters) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VerifyAllWithInvocationParameters) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// Verifies that every provenance was signed within the specified number of
+// days of the build, to detect late signing or backdating. The build time is
+// the time the build finished, or the time it started if the former is not
+// available. The signing time is the time the signature was integrated into
+// the transparency log, and is only known if the Sigstore bundle of the
+// provenance was verified against a Sigstore trust root when loading it.
+// Provenances without a verified signing time or a build time fail.
+type VerifyAllSignedWithinDaysOfBuild struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+}
+
+func (x *VerifyAllSignedWithinDaysOfBuild) Reset() {
+	*x = VerifyAllSignedWithinDaysOfBuild{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllSignedWithinDaysOfBuild) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllSignedWithinDaysOfBuild) ProtoMessage() {}
+
+func (x *VerifyAllSignedWithinDaysOfBuild) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllSignedWithinDaysOfBuild.ProtoReflect.Descriptor instead.
+func (*VerifyAllSignedWithinDaysOfBuild) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VerifyAllSignedWithinDaysOfBuild) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+// Verifies that every provenance was generated by one of the specified
+// generators, at or above the minimum version specified for it. The keys of
+// the map are builder IDs without the version suffix, e.g.,
+// "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml",
+// and the values are minimum versions in semantic versioning format, e.g.
+// "v1.2.0". The version of a provenance's builder is taken from the
+// "@refs/tags/<version>" suffix of its builder ID. Provenances from other
+// builders, or without a version, fail.
+type VerifyTrustedGenerator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinimumVersions map[string]string `protobuf:"bytes,1,rep,name=minimum_versions,json=minimumVersions,proto3" json:"minimum_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VerifyTrustedGenerator) Reset() {
+	*x = VerifyTrustedGenerator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyTrustedGenerator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTrustedGenerator) ProtoMessage() {}
+
+func (x *VerifyTrustedGenerator) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTrustedGenerator.ProtoReflect.Descriptor instead.
+func (*VerifyTrustedGenerator) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VerifyTrustedGenerator) GetMinimumVersions() map[string]string {
+	if x != nil {
+		return x.MinimumVersions
+	}
+	return nil
+}
+
+// Verifies that the repository and the commit of every provenance together
+// match ONE of the specified source identities. Unlike combining
+// all_with_repository with a separate commit check, the repository and the
+// commit must both come from the same provenance and match the same identity.
+type VerifySourceIdentity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identities []*SourceIdentity `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+}
+
+func (x *VerifySourceIdentity) Reset() {
+	*x = VerifySourceIdentity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifySourceIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySourceIdentity) ProtoMessage() {}
+
+func (x *VerifySourceIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySourceIdentity.ProtoReflect.Descriptor instead.
+func (*VerifySourceIdentity) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VerifySourceIdentity) GetIdentities() []*SourceIdentity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+// Identifies the source of a build as a repository at a specific commit.
+type SourceIdentity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RepositoryUri string `protobuf:"bytes,1,opt,name=repository_uri,json=repositoryUri,proto3" json:"repository_uri,omitempty"`
+	// The hex-encoded SHA1 digest of the commit.
+	CommitSha1Digest string `protobuf:"bytes,2,opt,name=commit_sha1_digest,json=commitSha1Digest,proto3" json:"commit_sha1_digest,omitempty"`
+}
+
+func (x *SourceIdentity) Reset() {
+	*x = SourceIdentity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourceIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceIdentity) ProtoMessage() {}
+
+func (x *SourceIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceIdentity.ProtoReflect.Descriptor instead.
+func (*SourceIdentity) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SourceIdentity) GetRepositoryUri() string {
+	if x != nil {
+		return x.RepositoryUri
+	}
+	return ""
+}
+
+func (x *SourceIdentity) GetCommitSha1Digest() string {
+	if x != nil {
+		return x.CommitSha1Digest
+	}
+	return ""
+}
+
+// Requires that every provenance asserts that the build is reproducible. For
+// SLSA v0.2 provenances this is the metadata.reproducible flag. SLSA v1
+// provenances have no such flag, and therefore fail.
+type VerifyAllReproducible struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VerifyAllReproducible) Reset() {
+	*x = VerifyAllReproducible{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllReproducible) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllReproducible) ProtoMessage() {}
+
+func (x *VerifyAllReproducible) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllReproducible.ProtoReflect.Descriptor instead.
+func (*VerifyAllReproducible) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{17}
+}
+
+// Verifies that the raw document of every provenance, i.e., the statement,
+// DSSE envelope, or Sigstore bundle it was parsed from, is at most the
+// specified number of bytes. Provenances for which the raw document size is
+// not known pass.
+type VerifyAllWithinDocumentSize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxBytes int64 `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+}
+
+func (x *VerifyAllWithinDocumentSize) Reset() {
+	*x = VerifyAllWithinDocumentSize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithinDocumentSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithinDocumentSize) ProtoMessage() {}
+
+func (x *VerifyAllWithinDocumentSize) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithinDocumentSize.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithinDocumentSize) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *VerifyAllWithinDocumentSize) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+// Verifies the signatures that builders which sign their own provenances
+// embed in the predicate, against a pinned public key of the builder. This is
+// independent of the keys used for signing DSSE envelopes. Provenances without
+// an embedded signature pass, unless `required` is set.
+type VerifyBuilderSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The PEM-encoded PKIX public key of the builder.
+	PublicKeyPem string `protobuf:"bytes,1,opt,name=public_key_pem,json=publicKeyPem,proto3" json:"public_key_pem,omitempty"`
+	// If set, provenances without an embedded signature fail.
+	Required bool `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+}
+
+func (x *VerifyBuilderSignature) Reset() {
+	*x = VerifyBuilderSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyBuilderSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBuilderSignature) ProtoMessage() {}
+
+func (x *VerifyBuilderSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBuilderSignature.ProtoReflect.Descriptor instead.
+func (*VerifyBuilderSignature) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VerifyBuilderSignature) GetPublicKeyPem() string {
+	if x != nil {
+		return x.PublicKeyPem
+	}
+	return ""
+}
+
+func (x *VerifyBuilderSignature) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// Verifies that at least the specified number of distinct signing identities
+// appear across all provenances, e.g., to require attestation by multiple
+// parties. Signing identities are only known for provenances whose DSSE
+// envelopes have been verified when loading them, e.g., with the public keys
+// passed to --signer_public_keys of the endorser, so other provenances do not
+// contribute.
+type VerifyDistinctSigners struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *VerifyDistinctSigners) Reset() {
+	*x = VerifyDistinctSigners{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyDistinctSigners) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDistinctSigners) ProtoMessage() {}
+
+func (x *VerifyDistinctSigners) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDistinctSigners.ProtoReflect.Descriptor instead.
+func (*VerifyDistinctSigners) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *VerifyDistinctSigners) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Verifies that the OCI metadata recorded in every provenance has all the
+// specified annotations, with the specified values. For SLSA v1 provenances,
+// these are the annotations of the builder image, and of all resolved
+// dependencies and byproducts with an OCI media type. Additional annotations
+// are permitted. Non-string annotation values are compared by their JSON
+// encoding.
+type VerifyAllWithOCIAnnotations struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Annotations map[string]string `protobuf:"bytes,1,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VerifyAllWithOCIAnnotations) Reset() {
+	*x = VerifyAllWithOCIAnnotations{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithOCIAnnotations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithOCIAnnotations) ProtoMessage() {}
+
+func (x *VerifyAllWithOCIAnnotations) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithOCIAnnotations.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithOCIAnnotations) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerifyAllWithOCIAnnotations) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+// Verifies that every provenance is fresh, i.e., that the build took place
+// within the specified number of days before the verification. The build time
+// is the time the build finished, or the time it started if the former is not
+// available. Provenances without a build time fail.
+type VerifyAllBuiltWithinDays struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+}
+
+func (x *VerifyAllBuiltWithinDays) Reset() {
+	*x = VerifyAllBuiltWithinDays{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllBuiltWithinDays) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllBuiltWithinDays) ProtoMessage() {}
+
+func (x *VerifyAllBuiltWithinDays) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllBuiltWithinDays.ProtoReflect.Descriptor instead.
+func (*VerifyAllBuiltWithinDays) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyAllBuiltWithinDays) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+// Verifies that the binary digest of no provenance appears in the specified
+// blocklist, e.g., to reject compromised artifacts during incident response.
+// Digests of every algorithm are taken into account: a provenance fails if any
+// of the digests of its binary, e.g., its SHA2-256 or SHA2-512 digest, is in
+// the blocklist.
+type VerifyAllNotInDigestBlocklist struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digests []*Digest `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+}
+
+func (x *VerifyAllNotInDigestBlocklist) Reset() {
+	*x = VerifyAllNotInDigestBlocklist{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllNotInDigestBlocklist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllNotInDigestBlocklist) ProtoMessage() {}
+
+func (x *VerifyAllNotInDigestBlocklist) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllNotInDigestBlocklist.ProtoReflect.Descriptor instead.
+func (*VerifyAllNotInDigestBlocklist) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VerifyAllNotInDigestBlocklist) GetDigests() []*Digest {
+	if x != nil {
+		return x.Digests
+	}
+	return nil
+}
+
+// Verifies that every provenance was generated by the specified CI system.
+// The CI system is derived from the builder ID of the provenance, or from its
+// build type if the builder ID is not recognized.
+type VerifyAllWithCISystem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CiSystem VerifyAllWithCISystem_CISystem `protobuf:"varint,1,opt,name=ci_system,json=ciSystem,proto3,enum=oak.release.VerifyAllWithCISystem_CISystem" json:"ci_system,omitempty"`
+}
+
+func (x *VerifyAllWithCISystem) Reset() {
+	*x = VerifyAllWithCISystem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithCISystem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithCISystem) ProtoMessage() {}
+
+func (x *VerifyAllWithCISystem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithCISystem.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithCISystem) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VerifyAllWithCISystem) GetCiSystem() VerifyAllWithCISystem_CISystem {
+	if x != nil {
+		return x.CiSystem
+	}
+	return VerifyAllWithCISystem_UNKNOWN
+}
+
+// Determines how provenances for empty artifacts are handled, i.e.,
+// provenances whose binary digest is the SHA2-256 digest of empty content, as
+// used by builds that produce only metadata. If this option is not set, such
+// provenances are treated like all others. If it is set, they fail unless
+// `allow` is set. Either way, they only pass all_with_binary_digests if the
+// empty digest is among its digests, in which case their subject name is not
+// checked.
+type VerifyEmptyArtifacts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Allow bool `protobuf:"varint,1,opt,name=allow,proto3" json:"allow,omitempty"`
+}
+
+func (x *VerifyEmptyArtifacts) Reset() {
+	*x = VerifyEmptyArtifacts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyEmptyArtifacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmptyArtifacts) ProtoMessage() {}
+
+func (x *VerifyEmptyArtifacts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmptyArtifacts.ProtoReflect.Descriptor instead.
+func (*VerifyEmptyArtifacts) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *VerifyEmptyArtifacts) GetAllow() bool {
+	if x != nil {
+		return x.Allow
+	}
+	return false
+}
+
+// Verifies that the resolved dependencies of every provenance are listed in
+// canonical order, i.e., sorted by URI, and then by digests. This is a
+// tamper-evidence heuristic for builders known to emit canonical lists.
+// Provenances without resolved dependencies pass.
+type VerifyAllWithCanonicalDependencies struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VerifyAllWithCanonicalDependencies) Reset() {
+	*x = VerifyAllWithCanonicalDependencies{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithCanonicalDependencies) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithCanonicalDependencies) ProtoMessage() {}
+
+func (x *VerifyAllWithCanonicalDependencies) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithCanonicalDependencies.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithCanonicalDependencies) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{26}
+}
+
+// Caps the validity of generated endorsements, i.e., the time between their
+// notBefore and notAfter timestamps, at the specified number of days. Unlike
+// the other options, this does not concern the provenances, and is checked
+// when generating the endorsement.
+type VerifyMaxValidity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+}
+
+func (x *VerifyMaxValidity) Reset() {
+	*x = VerifyMaxValidity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyMaxValidity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMaxValidity) ProtoMessage() {}
+
+func (x *VerifyMaxValidity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMaxValidity.ProtoReflect.Descriptor instead.
+func (*VerifyMaxValidity) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *VerifyMaxValidity) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+// Verifies that every provenance records the specified target platform. For
+// SLSA v0.2 provenances the platform is taken from the invocation environment
+// or parameters, and for SLSA v1 provenances from the internal parameters,
+// either as a "platform" entry such as "linux/amd64", or as "os" and "arch"
+// entries. Provenances that do not record a platform fail. Empty fields match
+// any value. The OS is compared case-insensitively, and common aliases of
+// architectures, e.g., "x86_64" and "X64" for "amd64", are recognized.
+type VerifyAllWithPlatform struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Os           string `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Architecture string `protobuf:"bytes,2,opt,name=architecture,proto3" json:"architecture,omitempty"`
+}
+
+func (x *VerifyAllWithPlatform) Reset() {
+	*x = VerifyAllWithPlatform{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithPlatform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithPlatform) ProtoMessage() {}
+
+func (x *VerifyAllWithPlatform) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithPlatform.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithPlatform) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VerifyAllWithPlatform) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *VerifyAllWithPlatform) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+// Verifies that every provenance lists at least the specified number of
+// resolved dependencies, as too few dependencies may indicate an incomplete
+// build record. For SLSA v0.2 provenances the materials are counted.
+// Provenances that do not list resolved dependencies count as having none.
+type VerifyAllWithMinDependencies struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *VerifyAllWithMinDependencies) Reset() {
+	*x = VerifyAllWithMinDependencies{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithMinDependencies) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithMinDependencies) ProtoMessage() {}
+
+func (x *VerifyAllWithMinDependencies) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithMinDependencies.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithMinDependencies) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VerifyAllWithMinDependencies) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Verifies that every provenance lists at least the specified number of
+// byproducts of the build, such as logs and test reports, e.g., for quality
+// gates requiring that tests ran. Only SLSA v1 provenances record byproducts.
+// Provenances that do not list byproducts count as having none.
+type VerifyAllWithMinByproducts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *VerifyAllWithMinByproducts) Reset() {
+	*x = VerifyAllWithMinByproducts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithMinByproducts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithMinByproducts) ProtoMessage() {}
+
+func (x *VerifyAllWithMinByproducts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithMinByproducts.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithMinByproducts) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *VerifyAllWithMinByproducts) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Verifies that the binary was independently reproduced, i.e., that at least
+// the specified number of provenances, which must be two or more, record the
+// same source commit but distinct build invocation IDs. Provenances that do
+// not record a commit or an invocation ID do not count.
+type VerifyIndependentReproduction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *VerifyIndependentReproduction) Reset() {
+	*x = VerifyIndependentReproduction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyIndependentReproduction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyIndependentReproduction) ProtoMessage() {}
+
+func (x *VerifyIndependentReproduction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyIndependentReproduction.ProtoReflect.Descriptor instead.
+func (*VerifyIndependentReproduction) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VerifyIndependentReproduction) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Verifies that the binary digest specified in every provenance matches ANY
+// of a family of acceptable digests, e.g., taken from a manifest of approved
+// nightly builds. The acceptable digests are listed per algorithm, keyed by
+// the values of `Digest.Type`, and may be hex- or base64-encoded as in
+// VerifyAllWithBinaryDigests.
+type VerifyAllWithBinaryDigestsAnyOf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digests map[int32]*VerifyAllWithBinaryDigestsAnyOf_Digests `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf) Reset() {
+	*x = VerifyAllWithBinaryDigestsAnyOf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBinaryDigestsAnyOf) ProtoMessage() {}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBinaryDigestsAnyOf.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBinaryDigestsAnyOf) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf) GetDigests() map[int32]*VerifyAllWithBinaryDigestsAnyOf_Digests {
+	if x != nil {
+		return x.Digests
+	}
+	return nil
+}
+
+// Verifies that every provenance records the specified digest of the build
+// environment, i.e., of the base image, for pinning the environment in
+// reproducibility policies. The digest is taken from a "baseImage" entry in
+// the invocation environment of SLSA v0.2 provenances, and in the internal
+// parameters of SLSA v1 provenances. Provenances that do not record an
+// environment digest fail. Only SHA2-256 digests are supported. For the
+// builder image of Docker-based builds, use all_with_builder_digests.
+type VerifyAllWithEnvironmentDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digest *Digest `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *VerifyAllWithEnvironmentDigest) Reset() {
+	*x = VerifyAllWithEnvironmentDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithEnvironmentDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithEnvironmentDigest) ProtoMessage() {}
+
+func (x *VerifyAllWithEnvironmentDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithEnvironmentDigest.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithEnvironmentDigest) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VerifyAllWithEnvironmentDigest) GetDigest() *Digest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+// Verifies that every provenance records the specified digest of the external
+// parameters of the build, for pinning large build configurations by hash.
+// The digest is taken from the "externalParametersDigest" entry of the
+// internal parameters of SLSA v1 provenances, as recorded by the builder.
+// Provenances that do not record it fail. Only SHA2-256 digests are
+// supported.
+type VerifyAllWithExternalParametersDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digest *Digest `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *VerifyAllWithExternalParametersDigest) Reset() {
+	*x = VerifyAllWithExternalParametersDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithExternalParametersDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithExternalParametersDigest) ProtoMessage() {}
+
+func (x *VerifyAllWithExternalParametersDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithExternalParametersDigest.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithExternalParametersDigest) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *VerifyAllWithExternalParametersDigest) GetDigest() *Digest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+// Verifies that the subject name of every provenance is a reference to the
+// specified OCI image, e.g., "ghcr.io/project-oak/oak:v1.0.0", for provenances
+// of images. References are normalized as by Docker, so that "debian" and
+// "docker.io/library/debian" refer to the same repository. The repositories
+// must be equal. Tags, and likewise digests, are only compared if both
+// references specify one, so that a reference by digest matches a reference
+// by tag.
+type VerifyAllWithImageReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImageReference string `protobuf:"bytes,1,opt,name=image_reference,json=imageReference,proto3" json:"image_reference,omitempty"`
+}
+
+func (x *VerifyAllWithImageReference) Reset() {
+	*x = VerifyAllWithImageReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithImageReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithImageReference) ProtoMessage() {}
+
+func (x *VerifyAllWithImageReference) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithImageReference.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithImageReference) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *VerifyAllWithImageReference) GetImageReference() string {
+	if x != nil {
+		return x.ImageReference
+	}
+	return ""
+}
+
+// Verifies that the source repository of every provenance is listed among its
+// resolved dependencies (materials in SLSA v0.2) with the commit digest of the
+// source, rather than only in the configuration of the build. References to
+// the repository are compared ignoring the Git ref, e.g., "@refs/heads/main".
+// Provenances without a source repository and commit digest fail.
+type VerifyAllWithSourceMaterial struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VerifyAllWithSourceMaterial) Reset() {
+	*x = VerifyAllWithSourceMaterial{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithSourceMaterial) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithSourceMaterial) ProtoMessage() {}
+
+func (x *VerifyAllWithSourceMaterial) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithSourceMaterial.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithSourceMaterial) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{36}
+}
+
+// Verifies that the build recorded in every provenance took between the
+// specified minimum and maximum number of seconds, i.e., the time between the
+// start and the end of the build, as unusually fast or slow builds may
+// indicate caching issues or tampering. A maximum of zero means no upper
+// bound. Provenances without both timestamps are skipped. Provenances in
+// which the build finished before it started fail.
+type VerifyAllWithBuildDuration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinSeconds int64 `protobuf:"varint,1,opt,name=min_seconds,json=minSeconds,proto3" json:"min_seconds,omitempty"`
+	MaxSeconds int64 `protobuf:"varint,2,opt,name=max_seconds,json=maxSeconds,proto3" json:"max_seconds,omitempty"`
+}
+
+func (x *VerifyAllWithBuildDuration) Reset() {
+	*x = VerifyAllWithBuildDuration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBuildDuration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBuildDuration) ProtoMessage() {}
+
+func (x *VerifyAllWithBuildDuration) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBuildDuration.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBuildDuration) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VerifyAllWithBuildDuration) GetMinSeconds() int64 {
+	if x != nil {
+		return x.MinSeconds
+	}
+	return 0
+}
+
+func (x *VerifyAllWithBuildDuration) GetMaxSeconds() int64 {
+	if x != nil {
+		return x.MaxSeconds
+	}
+	return 0
+}
+
+// Verifies that every provenance records a non-empty ID of the build
+// invocation, for traceability. The ID is taken from metadata.buildInvocationId
+// in SLSA v0.2 provenances, and from runDetails.metadata.invocationId in SLSA
+// v1 provenances. See also independent_reproduction.
+type VerifyAllHaveInvocationID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VerifyAllHaveInvocationID) Reset() {
+	*x = VerifyAllHaveInvocationID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllHaveInvocationID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllHaveInvocationID) ProtoMessage() {}
+
+func (x *VerifyAllHaveInvocationID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllHaveInvocationID.ProtoReflect.Descriptor instead.
+func (*VerifyAllHaveInvocationID) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{38}
+}
+
+// Requires that every provenance asserts that its materials are complete,
+// i.e., that the builder captured all inputs of the build, usually by
+// preventing network access. For SLSA v0.2 provenances this is the
+// metadata.completeness.materials flag. SLSA v1 provenances have no such flag,
+// and therefore fail.
+type VerifyMaterialsComplete struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VerifyMaterialsComplete) Reset() {
+	*x = VerifyMaterialsComplete{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyMaterialsComplete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMaterialsComplete) ProtoMessage() {}
+
+func (x *VerifyMaterialsComplete) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMaterialsComplete.ProtoReflect.Descriptor instead.
+func (*VerifyMaterialsComplete) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{39}
+}
+
+// Verifies that the binary of every provenance differs from all of its
+// resolved dependencies (materials in SLSA v0.2), i.e., that no dependency has
+// the same digest as the binary for any digest type. This flags suspicious
+// passthrough builds, in which the output is just a copied input.
+type VerifyAllDistinctFromMaterials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VerifyAllDistinctFromMaterials) Reset() {
+	*x = VerifyAllDistinctFromMaterials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllDistinctFromMaterials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllDistinctFromMaterials) ProtoMessage() {}
+
+func (x *VerifyAllDistinctFromMaterials) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllDistinctFromMaterials.ProtoReflect.Descriptor instead.
+func (*VerifyAllDistinctFromMaterials) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{40}
+}
+
+// Verifies that every provenance was built at or after the specified time,
+// e.g., the build time of the provenance of the previously endorsed release,
+// to prevent rollbacks to older releases. The build time is as in
+// all_built_within_days. Provenances without a build time fail.
+type VerifyNotOlderThan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The prior build time in RFC 3339 format, e.g., "2023-06-01T12:00:00Z".
+	PriorTimestamp string `protobuf:"bytes,1,opt,name=prior_timestamp,json=priorTimestamp,proto3" json:"prior_timestamp,omitempty"`
+}
+
+func (x *VerifyNotOlderThan) Reset() {
+	*x = VerifyNotOlderThan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyNotOlderThan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyNotOlderThan) ProtoMessage() {}
+
+func (x *VerifyNotOlderThan) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyNotOlderThan.ProtoReflect.Descriptor instead.
+func (*VerifyNotOlderThan) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *VerifyNotOlderThan) GetPriorTimestamp() string {
+	if x != nil {
+		return x.PriorTimestamp
+	}
+	return ""
+}
+
+// Verifies that every provenance was signed keylessly by a GitHub Actions
+// workflow of one of the specified GitHub organizations (or users). The
+// identity is taken from the Fulcio signing certificate in the Sigstore bundle
+// of the provenance: its OIDC issuer must be GitHub Actions, and the owner of
+// the repository in its SAN, e.g., "project-oak" in
+// "https://github.com/project-oak/oak/.github/workflows/x.yml@refs/heads/main",
+// must be in the allowlist. Owners are compared case-insensitively. The
+// identity is only known if the bundle was verified against a Sigstore trust
+// root when loading the provenance: the certificate must chain to a trusted
+// Fulcio root, sign the envelope, and be recorded in a trusted Rekor log.
+// Provenances without a verified signing certificate fail.
+type VerifyBuilderOrg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Orgs []string `protobuf:"bytes,1,rep,name=orgs,proto3" json:"orgs,omitempty"`
+}
+
+func (x *VerifyBuilderOrg) Reset() {
+	*x = VerifyBuilderOrg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyBuilderOrg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBuilderOrg) ProtoMessage() {}
+
+func (x *VerifyBuilderOrg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBuilderOrg.ProtoReflect.Descriptor instead.
+func (*VerifyBuilderOrg) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *VerifyBuilderOrg) GetOrgs() []string {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+// Verifies that every provenance that records its own validity window, in the
+// `validity` field of its predicate, is valid at the specified time, so that
+// stale evidence is not endorsed. Provenances without a validity window are
+// skipped. Set the severity of the step to WARN to only flag, rather than
+// reject, such provenances.
+type VerifyAllWithinOwnValidity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The time to check against in RFC 3339 format, e.g.,
+	// "2023-06-01T12:00:00Z". Defaults to the current time if empty.
+	AtTimestamp string `protobuf:"bytes,1,opt,name=at_timestamp,json=atTimestamp,proto3" json:"at_timestamp,omitempty"`
+}
+
+func (x *VerifyAllWithinOwnValidity) Reset() {
+	*x = VerifyAllWithinOwnValidity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithinOwnValidity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithinOwnValidity) ProtoMessage() {}
+
+func (x *VerifyAllWithinOwnValidity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithinOwnValidity.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithinOwnValidity) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *VerifyAllWithinOwnValidity) GetAtTimestamp() string {
+	if x != nil {
+		return x.AtTimestamp
+	}
+	return ""
+}
+
+// Verifies that the build type of every provenance is either allowed or
+// deprecated. Provenances with a deprecated build type pass, but a warning is
+// reported for each of them, regardless of the severity of the step, so that
+// build types can be phased out without breaking verification.
+type VerifyAllWithBuildType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Allowed    []string `protobuf:"bytes,1,rep,name=allowed,proto3" json:"allowed,omitempty"`
+	Deprecated []string `protobuf:"bytes,2,rep,name=deprecated,proto3" json:"deprecated,omitempty"`
+}
+
+func (x *VerifyAllWithBuildType) Reset() {
+	*x = VerifyAllWithBuildType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBuildType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBuildType) ProtoMessage() {}
+
+func (x *VerifyAllWithBuildType) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBuildType.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBuildType) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *VerifyAllWithBuildType) GetAllowed() []string {
+	if x != nil {
+		return x.Allowed
+	}
+	return nil
+}
+
+func (x *VerifyAllWithBuildType) GetDeprecated() []string {
+	if x != nil {
+		return x.Deprecated
+	}
+	return nil
+}
+
+// Verifies that every provenance records a signature of the source commit, in
+// the `commitSignature` field of its predicate, by one of the trusted signers.
+// Signers are identified by the fingerprint of their GPG key, compared
+// ignoring case and spaces, or by the SHA256 fingerprint of their SSH key, in
+// the form "SHA256:<base64>". Provenances without a commit signature fail.
+type VerifyCommitSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrustedSigners []string `protobuf:"bytes,1,rep,name=trusted_signers,json=trustedSigners,proto3" json:"trusted_signers,omitempty"`
+}
+
+func (x *VerifyCommitSignature) Reset() {
+	*x = VerifyCommitSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyCommitSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCommitSignature) ProtoMessage() {}
+
+func (x *VerifyCommitSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCommitSignature.ProtoReflect.Descriptor instead.
+func (*VerifyCommitSignature) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *VerifyCommitSignature) GetTrustedSigners() []string {
+	if x != nil {
+		return x.TrustedSigners
+	}
+	return nil
+}
+
+// Verifies that every provenance was produced by one of the specified
+// builders, e.g., a GitHub Actions reusable workflow such as
+// "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.9.0".
+// Builder IDs that include a ref, following "@", must match exactly, while
+// builder IDs without a ref match the builder at any ref. Provenances without
+// a builder ID fail.
+type VerifyAllWithBuilderID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuilderIds []string `protobuf:"bytes,1,rep,name=builder_ids,json=builderIds,proto3" json:"builder_ids,omitempty"`
+}
+
+func (x *VerifyAllWithBuilderID) Reset() {
+	*x = VerifyAllWithBuilderID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBuilderID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBuilderID) ProtoMessage() {}
+
+func (x *VerifyAllWithBuilderID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBuilderID.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBuilderID) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *VerifyAllWithBuilderID) GetBuilderIds() []string {
+	if x != nil {
+		return x.BuilderIds
+	}
+	return nil
+}
+
+// Verifies that the source repository of every provenance matches all of the
+// specified constraints, so that provenances for the same binary built from a
+// fork cannot be endorsed. The source repository is the first Git repository
+// among the resolved dependencies (materials in SLSA v0.2) with a commit
+// digest, or else the repository recorded in the build configuration.
+// Repositories are compared ignoring the "git+" prefix, the Git ref, e.g.,
+// "@refs/heads/main", and the ".git" suffix. At least one constraint must be
+// specified. Provenances without a source repository fail.
+type VerifyAllWithSourceRepo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The repository, e.g., "https://github.com/project-oak/oak".
+	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	// A prefix of the repository ending at a path segment boundary, e.g.,
+	// "https://github.com/project-oak" matches all repositories of the
+	// project-oak org, but not those of the project-oak-fork org.
+	UriPrefix string `protobuf:"bytes,2,opt,name=uri_prefix,json=uriPrefix,proto3" json:"uri_prefix,omitempty"`
+	// The host of the repository, e.g., "github.com".
+	Host string `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+}
+
+func (x *VerifyAllWithSourceRepo) Reset() {
+	*x = VerifyAllWithSourceRepo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithSourceRepo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithSourceRepo) ProtoMessage() {}
+
+func (x *VerifyAllWithSourceRepo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithSourceRepo.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithSourceRepo) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *VerifyAllWithSourceRepo) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *VerifyAllWithSourceRepo) GetUriPrefix() string {
+	if x != nil {
+		return x.UriPrefix
+	}
+	return ""
+}
+
+func (x *VerifyAllWithSourceRepo) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+// Verifies that the subject digest of every provenance is the digest of the
+// manifest of the specified OCI image, rather than, e.g., the digest of its
+// config or of one of its layers, for provenances of images. The image must
+// be referenced by digest, e.g., "ghcr.io/project-oak/oak@sha256:<hex>", as
+// the digest in a reference always identifies the manifest (or the index of
+// a multi-platform image). Only SHA2-256 digests are supported.
+type VerifyAllWithImageManifestDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImageReference string `protobuf:"bytes,1,opt,name=image_reference,json=imageReference,proto3" json:"image_reference,omitempty"`
+	// The optional content of the manifest, e.g., as returned by the registry
+	// for the reference. Its digest must match the reference. If set, failures
+	// identify subjects with the digest of the config or a layer of the image.
+	Manifest []byte `protobuf:"bytes,2,opt,name=manifest,proto3" json:"manifest,omitempty"`
+}
+
+func (x *VerifyAllWithImageManifestDigest) Reset() {
+	*x = VerifyAllWithImageManifestDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithImageManifestDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithImageManifestDigest) ProtoMessage() {}
+
+func (x *VerifyAllWithImageManifestDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithImageManifestDigest.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithImageManifestDigest) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *VerifyAllWithImageManifestDigest) GetImageReference() string {
+	if x != nil {
+		return x.ImageReference
+	}
+	return ""
+}
+
+func (x *VerifyAllWithImageManifestDigest) GetManifest() []byte {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+type VerifyAllWithBinaryDigestsAnyOf_Digests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digests []string `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf_Digests) Reset() {
+	*x = VerifyAllWithBinaryDigestsAnyOf_Digests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_verification_options_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf_Digests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAllWithBinaryDigestsAnyOf_Digests) ProtoMessage() {}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf_Digests) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_verification_options_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAllWithBinaryDigestsAnyOf_Digests.ProtoReflect.Descriptor instead.
+func (*VerifyAllWithBinaryDigestsAnyOf_Digests) Descriptor() ([]byte, []int) {
+	return file_proto_verification_options_proto_rawDescGZIP(), []int{32, 0}
+}
+
+func (x *VerifyAllWithBinaryDigestsAnyOf_Digests) GetDigests() []string {
+	if x != nil {
+		return x.Digests
 	}
 	return nil
 }
@@ -632,7 +3245,7 @@ var file_proto_verification_options_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x0b, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x1a,
 	0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xfd, 0x09, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x6f, 0x74, 0x6f, 0x22, 0xfd, 0x30, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x69, 0x0a, 0x19, 0x70,
 	0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
 	0x61, 0x74, 0x5f, 0x6c, 0x65, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
@@ -695,24 +3308,336 @@ var file_proto_verification_options_proto_rawDesc = []byte{
 	0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69,
 	0x74, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x48, 0x09, 0x52, 0x11,
 	0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
-	0x79, 0x88, 0x01, 0x01, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61,
-	0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x5f, 0x6c, 0x65, 0x61,
-	0x73, 0x74, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
-	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x5f, 0x6d, 0x6f, 0x73, 0x74, 0x42,
-	0x17, 0x0a, 0x15, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x61, 0x6d, 0x65, 0x5f, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x61, 0x6c, 0x6c,
-	0x5f, 0x73, 0x61, 0x6d, 0x65, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68,
-	0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x42, 0x17,
-	0x0a, 0x15, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x69, 0x6e, 0x61,
-	0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c, 0x6c, 0x5f,
-	0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68,
-	0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x42, 0x1b,
-	0x0a, 0x19, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x42, 0x16, 0x0a, 0x14, 0x5f,
-	0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x6f, 0x72, 0x79, 0x22, 0x34, 0x0a, 0x1c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72, 0x6f,
+	0x79, 0x88, 0x01, 0x01, 0x12, 0x70, 0x0a, 0x1c, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f, 0x61, 0x6b,
+	0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41,
+	0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x48, 0x0a, 0x52, 0x18, 0x61, 0x6c, 0x6c, 0x57, 0x69,
+	0x74, 0x68, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x78, 0x0a, 0x1e, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69,
+	0x74, 0x68, 0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x48, 0x0b,
+	0x52, 0x1b, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x77, 0x0a, 0x1f, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x77,
+	0x69, 0x74, 0x68, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x5f, 0x6f, 0x66, 0x5f, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6f, 0x61, 0x6b, 0x2e,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c,
+	0x6c, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x61, 0x79,
+	0x73, 0x4f, 0x66, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x48, 0x0c, 0x52, 0x1a, 0x61, 0x6c, 0x6c, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x61, 0x79, 0x73, 0x4f,
+	0x66, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x88, 0x01, 0x01, 0x12, 0x55, 0x0a, 0x11, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x65, 0x64, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x0d, 0x52, 0x10, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x88, 0x01, 0x01,
+	0x12, 0x4f, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x61, 0x6b, 0x2e,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x48, 0x0e, 0x52, 0x0e,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x88, 0x01,
+	0x01, 0x12, 0x52, 0x0a, 0x10, 0x61, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x61,
+	0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x69, 0x62, 0x6c, 0x65, 0x48,
+	0x0f, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x69, 0x62,
+	0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x66, 0x0a, 0x18, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x69, 0x6e, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57,
+	0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x7a,
+	0x65, 0x48, 0x10, 0x52, 0x15, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x55, 0x0a,
+	0x11, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x11, 0x52,
+	0x10, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x52, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x73, 0x48, 0x12, 0x52, 0x0f, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x73, 0x88, 0x01, 0x01, 0x12, 0x66, 0x0a, 0x18, 0x61, 0x6c, 0x6c, 0x5f,
+	0x77, 0x69, 0x74, 0x68, 0x5f, 0x6f, 0x63, 0x69, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x61, 0x6b,
+	0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41,
+	0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x43, 0x49, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x48, 0x13, 0x52, 0x15, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4f,
+	0x63, 0x69, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x5d, 0x0a, 0x15, 0x61, 0x6c, 0x6c, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x5f, 0x77, 0x69,
+	0x74, 0x68, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x42, 0x75, 0x69, 0x6c, 0x74, 0x57, 0x69, 0x74, 0x68,
+	0x69, 0x6e, 0x44, 0x61, 0x79, 0x73, 0x48, 0x14, 0x52, 0x12, 0x61, 0x6c, 0x6c, 0x42, 0x75, 0x69,
+	0x6c, 0x74, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x61, 0x79, 0x73, 0x88, 0x01, 0x01, 0x12,
+	0x6d, 0x0a, 0x1b, 0x61, 0x6c, 0x6c, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x5f, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x74, 0x49,
+	0x6e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74,
+	0x48, 0x15, 0x52, 0x17, 0x61, 0x6c, 0x6c, 0x4e, 0x6f, 0x74, 0x49, 0x6e, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x88, 0x01, 0x01, 0x12, 0x54,
+	0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x63, 0x69, 0x5f, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x61, 0x6b,
+	0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41,
+	0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x43, 0x49, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48, 0x16,
+	0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x43, 0x69, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x88, 0x01, 0x01, 0x12, 0x4f, 0x0a, 0x0f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x5f, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73,
+	0x48, 0x17, 0x52, 0x0e, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x7b, 0x0a, 0x1f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x5f, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x43, 0x61, 0x6e, 0x6f, 0x6e, 0x69,
+	0x63, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x48,
+	0x18, 0x52, 0x1c, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x43, 0x61, 0x6e, 0x6f, 0x6e, 0x69,
+	0x63, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x46, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x61, 0x78,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x48, 0x19, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x53, 0x0a, 0x11, 0x61, 0x6c,
+	0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18,
+	0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74,
+	0x68, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x48, 0x1a, 0x52, 0x0f, 0x61, 0x6c, 0x6c,
+	0x57, 0x69, 0x74, 0x68, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x88, 0x01, 0x01, 0x12,
+	0x69, 0x0a, 0x19, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x5f,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x18, 0x1d, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69,
+	0x6e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x48, 0x1b, 0x52,
+	0x16, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x6e, 0x44, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x6a, 0x0a, 0x18, 0x69, 0x6e,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6f,
+	0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x49, 0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x1c, 0x52, 0x17, 0x69, 0x6e, 0x64, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x74, 0x0a, 0x1e, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69,
+	0x74, 0x68, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x73, 0x5f, 0x61, 0x6e, 0x79, 0x5f, 0x6f, 0x66, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c,
+	0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x41, 0x6e, 0x79, 0x4f, 0x66, 0x48, 0x1d, 0x52, 0x19,
+	0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x73, 0x41, 0x6e, 0x79, 0x4f, 0x66, 0x88, 0x01, 0x01, 0x12, 0x6f, 0x0a, 0x1b,
+	0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2b, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x48, 0x1e,
+	0x52, 0x18, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x88, 0x01, 0x01, 0x12, 0x66, 0x0a,
+	0x18, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x1f, 0x52, 0x15, 0x61, 0x6c, 0x6c,
+	0x57, 0x69, 0x74, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x66, 0x0a, 0x18, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57,
+	0x69, 0x74, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x48, 0x20, 0x52, 0x15, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x63, 0x0a,
+	0x17, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27,
+	0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x21, 0x52, 0x14, 0x61, 0x6c, 0x6c, 0x57, 0x69,
+	0x74, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x60, 0x0a, 0x16, 0x61, 0x6c, 0x6c, 0x5f, 0x68, 0x61, 0x76, 0x65, 0x5f, 0x69,
+	0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x24, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x48, 0x61, 0x76, 0x65, 0x49, 0x6e,
+	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x48, 0x22, 0x52, 0x13, 0x61, 0x6c,
+	0x6c, 0x48, 0x61, 0x76, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x58, 0x0a, 0x12, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x48, 0x23, 0x52, 0x11, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x6f,
+	0x0a, 0x1b, 0x61, 0x6c, 0x6c, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x26, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x44, 0x69, 0x73, 0x74, 0x69,
+	0x6e, 0x63, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73,
+	0x48, 0x24, 0x52, 0x18, 0x61, 0x6c, 0x6c, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x46,
+	0x72, 0x6f, 0x6d, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x88, 0x01, 0x01, 0x12,
+	0x4a, 0x0a, 0x0e, 0x6e, 0x6f, 0x74, 0x5f, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x61,
+	0x6e, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4e, 0x6f, 0x74, 0x4f,
+	0x6c, 0x64, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x48, 0x25, 0x52, 0x0c, 0x6e, 0x6f, 0x74, 0x4f,
+	0x6c, 0x64, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x43, 0x0a, 0x0b, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6f, 0x72, 0x67, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x4f, 0x72, 0x67, 0x48,
+	0x26, 0x52, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x4f, 0x72, 0x67, 0x88, 0x01, 0x01,
+	0x12, 0x63, 0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x5f, 0x6f,
+	0x77, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x29, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x4f,
+	0x77, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x48, 0x27, 0x52, 0x14, 0x61, 0x6c,
+	0x6c, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x4f, 0x77, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x69,
+	0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x57, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x2a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x48, 0x28, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x57, 0x69,
+	0x74, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01, 0x12, 0x52,
+	0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x29, 0x52, 0x0f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x57, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x65, 0x72, 0x49, 0x44, 0x48, 0x2a, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x5a, 0x0a, 0x14, 0x61,
+	0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72,
+	0x65, 0x70, 0x6f, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x61, 0x6b, 0x2e,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c,
+	0x6c, 0x57, 0x69, 0x74, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x48,
+	0x2b, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x76, 0x0a, 0x1e, 0x61, 0x6c, 0x6c, 0x5f, 0x77,
+	0x69, 0x74, 0x68, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x48, 0x2c,
+	0x52, 0x1a, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x4d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x63, 0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x5f,
+	0x62, 0x79, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x30, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x6e, 0x42,
+	0x79, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x48, 0x2d, 0x52, 0x14, 0x61, 0x6c, 0x6c,
+	0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x6e, 0x42, 0x79, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x85, 0x01, 0x0a, 0x23, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x31, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x48, 0x2e, 0x52, 0x1f, 0x61, 0x6c, 0x6c, 0x57, 0x69, 0x74,
+	0x68, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x88, 0x01, 0x01, 0x12, 0x50, 0x0a, 0x0a,
+	0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x4e,
+	0x0a, 0x0a, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x2e, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x6e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x09, 0x6f, 0x6e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x1a, 0x54,
+	0x0a, 0x0f, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x58, 0x0a, 0x0e, 0x4f, 0x6e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x1c,
+	0x0a, 0x1a, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x5f, 0x6c, 0x65, 0x61, 0x73, 0x74, 0x42, 0x1b, 0x0a, 0x19,
+	0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x61, 0x74, 0x5f, 0x6d, 0x6f, 0x73, 0x74, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x61, 0x6c,
+	0x6c, 0x5f, 0x73, 0x61, 0x6d, 0x65, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x61, 0x6d, 0x65, 0x5f,
+	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x42, 0x19, 0x0a,
+	0x17, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x61, 0x6c, 0x6c,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x42, 0x19, 0x0a,
+	0x17, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x61, 0x6c, 0x6c,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69,
+	0x74, 0x68, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x1f, 0x0a,
+	0x1d, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x21,
+	0x0a, 0x1f, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x69, 0x6e, 0x76, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x5f, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x5f, 0x6f, 0x66, 0x5f,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65,
+	0x64, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x12, 0x0a, 0x10, 0x5f,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x42,
+	0x13, 0x0a, 0x11, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x69, 0x62, 0x6c, 0x65, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x69, 0x6e, 0x5f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x64, 0x69, 0x73, 0x74,
+	0x69, 0x6e, 0x63, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x42, 0x1b, 0x0a, 0x19,
+	0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6f, 0x63, 0x69, 0x5f, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x61, 0x6c,
+	0x6c, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x5f, 0x64,
+	0x61, 0x79, 0x73, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x6e, 0x6f, 0x74, 0x5f,
+	0x69, 0x6e, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x6c,
+	0x69, 0x73, 0x74, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x5f, 0x63, 0x69, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65,
+	0x6d, 0x70, 0x74, 0x79, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x42, 0x22,
+	0x0a, 0x20, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x63, 0x61, 0x6e, 0x6f,
+	0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69,
+	0x65, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x69, 0x74, 0x79, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x61, 0x6c,
+	0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73,
+	0x5f, 0x61, 0x6e, 0x79, 0x5f, 0x6f, 0x66, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x61, 0x6c, 0x6c, 0x5f,
+	0x77, 0x69, 0x74, 0x68, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x61, 0x6c, 0x6c, 0x5f,
+	0x77, 0x69, 0x74, 0x68, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74,
+	0x68, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x19, 0x0a,
+	0x17, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x68, 0x61, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x76, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x6d, 0x61, 0x74,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x42,
+	0x1e, 0x0a, 0x1c, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x42,
+	0x11, 0x0a, 0x0f, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x68,
+	0x61, 0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6f,
+	0x72, 0x67, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x69,
+	0x6e, 0x5f, 0x6f, 0x77, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x16,
+	0x0a, 0x14, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x16, 0x0a, 0x14, 0x5f,
+	0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x42, 0x21, 0x0a, 0x1f,
+	0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f,
+	0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x42,
+	0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e,
+	0x5f, 0x62, 0x79, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x42, 0x26, 0x0a, 0x24, 0x5f,
+	0x61, 0x6c, 0x6c, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x64, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x22, 0x34, 0x0a, 0x1c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x72, 0x6f,
 	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x74, 0x4c, 0x65,
 	0x61, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x33, 0x0a, 0x1b, 0x56, 0x65, 0x72,
@@ -728,26 +3653,239 @@ var file_proto_verification_options_proto_rawDesc = []byte{
 	0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f,
 	0x0a, 0x0b, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22,
-	0x4b, 0x0a, 0x1a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68,
-	0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x2d, 0x0a,
-	0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x44, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x22, 0x40, 0x0a, 0x17,
-	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x70,
-	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73,
-	0x69, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x55, 0x72, 0x69, 0x22, 0x40,
-	0x0a, 0x19, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42,
-	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x62,
-	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73,
-	0x22, 0x4c, 0x0a, 0x1b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74,
-	0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12,
-	0x2d, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x44,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x42, 0x13,
-	0x5a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x61, 0x6b, 0x2f, 0x72, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x3a, 0x0a, 0x1e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68,
+	0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x8d, 0x02, 0x0a, 0x1a,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x61,
+	0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x62, 0x0a, 0x10, 0x73, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x37, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x53, 0x75, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x0f, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x0a,
+	0x0b, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3b,
+	0x0a, 0x0f, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e,
+	0x67, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x47, 0x45, 0x53, 0x54, 0x5f, 0x41, 0x4e, 0x59, 0x57,
+	0x48, 0x45, 0x52, 0x45, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x41, 0x4d, 0x45, 0x5f, 0x41,
+	0x4e, 0x44, 0x5f, 0x44, 0x49, 0x47, 0x45, 0x53, 0x54, 0x10, 0x01, 0x22, 0x40, 0x0a, 0x17, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x55, 0x72, 0x69, 0x22, 0x40, 0x0a,
+	0x19, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x22,
+	0x4c, 0x0a, 0x1b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x2d,
+	0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x22, 0xc2, 0x01,
+	0x0a, 0x21, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x49,
+	0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x5e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57,
+	0x69, 0x74, 0x68, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x36, 0x0a, 0x20, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x61, 0x79, 0x73, 0x4f,
+	0x66, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x22, 0xc1, 0x01, 0x0a, 0x16, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x63, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x38, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d,
+	0x75, 0x6d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x42, 0x0a, 0x14, 0x4d, 0x69,
+	0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x53,
+	0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x3b, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x61, 0x6b,
+	0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x22, 0x65, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x55, 0x72, 0x69, 0x12, 0x2c, 0x0a, 0x12,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x68, 0x61, 0x31, 0x5f, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x53, 0x68, 0x61, 0x31, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x69,
+	0x62, 0x6c, 0x65, 0x22, 0x3a, 0x0a, 0x1b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c,
+	0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22,
+	0x5a, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x50, 0x65, 0x6d, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22, 0x2d, 0x0a, 0x15, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x1b, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x43, 0x49, 0x41,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5b, 0x0a, 0x0b, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x39, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x43, 0x49, 0x41, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2e, 0x0a, 0x18, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x41, 0x6c, 0x6c, 0x42, 0x75, 0x69, 0x6c, 0x74, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44,
+	0x61, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x22, 0x4e, 0x0a, 0x1d, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x41, 0x6c, 0x6c, 0x4e, 0x6f, 0x74, 0x49, 0x6e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x61, 0x6b, 0x2e,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x07,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x22, 0xb5, 0x01, 0x0a, 0x15, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x43, 0x49, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x12, 0x48, 0x0a, 0x09, 0x63, 0x69, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68,
+	0x43, 0x49, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x43, 0x49, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x52, 0x08, 0x63, 0x69, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x22, 0x52, 0x0a, 0x08, 0x43,
+	0x49, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x47, 0x49, 0x54, 0x48, 0x55, 0x42, 0x5f, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x47, 0x49, 0x54, 0x4c,
+	0x41, 0x42, 0x5f, 0x43, 0x49, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x4f, 0x4f, 0x47, 0x4c,
+	0x45, 0x5f, 0x43, 0x4c, 0x4f, 0x55, 0x44, 0x5f, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x10, 0x03, 0x22,
+	0x2c, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x22, 0x24, 0x0a,
+	0x22, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x43, 0x61,
+	0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63,
+	0x69, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x11, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x61, 0x78,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x22, 0x4b, 0x0a, 0x15,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x50, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65,
+	0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x22, 0x34, 0x0a, 0x1c, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x6e, 0x44, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x32, 0x0a, 0x1a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68,
+	0x4d, 0x69, 0x6e, 0x42, 0x79, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x35, 0x0a, 0x1d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x8d, 0x02, 0x0a, 0x1f, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e, 0x61,
+	0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x41, 0x6e, 0x79, 0x4f, 0x66, 0x12, 0x53,
+	0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x39, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x41, 0x6e, 0x79, 0x4f, 0x66, 0x2e, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x73, 0x1a, 0x23, 0x0a, 0x07, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x70, 0x0a, 0x0c, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x4a, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6f, 0x61, 0x6b, 0x2e,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c,
+	0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x73, 0x41, 0x6e, 0x79, 0x4f, 0x66, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x1e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x06,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f,
+	0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x25, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x61, 0x6b, 0x2e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22,
+	0x46, 0x0a, 0x1b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x27,
+	0x0a, 0x0f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x1d, 0x0a, 0x1b, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4d, 0x61,
+	0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x5e, 0x0a, 0x1a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x1b, 0x0a, 0x19, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x41, 0x6c, 0x6c, 0x48, 0x61, 0x76, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x22, 0x19, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4d, 0x61, 0x74,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x20,
+	0x0a, 0x1e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x44, 0x69, 0x73, 0x74, 0x69,
+	0x6e, 0x63, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4d, 0x61, 0x74, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73,
+	0x22, 0x3d, 0x0a, 0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4e, 0x6f, 0x74, 0x4f, 0x6c, 0x64,
+	0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
+	0x26, 0x0a, 0x10, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72,
+	0x4f, 0x72, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x6f, 0x72, 0x67, 0x73, 0x22, 0x3f, 0x0a, 0x1a, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x4f, 0x77, 0x6e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x52, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x40, 0x0a, 0x15,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e,
+	0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x39,
+	0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x5e, 0x0a, 0x17, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x72, 0x69, 0x5f, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x72, 0x69, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x22, 0x67, 0x0a, 0x20, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x41, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x4d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a,
+	0x0f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x2a, 0x1f, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52,
+	0x4e, 0x10, 0x01, 0x2a, 0x5a, 0x0a, 0x0d, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f,
+	0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x49, 0x53,
+	0x53, 0x49, 0x4e, 0x47, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4d,
+	0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x02, 0x12, 0x10, 0x0a,
+	0x0c, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x03, 0x42,
+	0x13, 0x5a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x61, 0x6b, 0x2f, 0x72, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -762,39 +3900,141 @@ func file_proto_verification_options_proto_rawDescGZIP() []byte {
 	return file_proto_verification_options_proto_rawDescData
 }
 
-var file_proto_verification_options_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_verification_options_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_verification_options_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
 var file_proto_verification_options_proto_goTypes = []interface{}{
-	(*VerificationOptions)(nil),          // 0: oak.release.VerificationOptions
-	(*VerifyProvenanceCountAtLeast)(nil), // 1: oak.release.VerifyProvenanceCountAtLeast
-	(*VerifyProvenanceCountAtMost)(nil),  // 2: oak.release.VerifyProvenanceCountAtMost
-	(*VerifyAllSameBinaryName)(nil),      // 3: oak.release.VerifyAllSameBinaryName
-	(*VerifyAllSameBinaryDigest)(nil),    // 4: oak.release.VerifyAllSameBinaryDigest
-	(*VerifyAllWithBuildCommand)(nil),    // 5: oak.release.VerifyAllWithBuildCommand
-	(*VerifyAllWithBinaryName)(nil),      // 6: oak.release.VerifyAllWithBinaryName
-	(*VerifyAllWithBinaryDigests)(nil),   // 7: oak.release.VerifyAllWithBinaryDigests
-	(*VerifyAllWithRepository)(nil),      // 8: oak.release.VerifyAllWithRepository
-	(*VerifyAllWithBuilderNames)(nil),    // 9: oak.release.VerifyAllWithBuilderNames
-	(*VerifyAllWithBuilderDigests)(nil),  // 10: oak.release.VerifyAllWithBuilderDigests
-	(*Digest)(nil),                       // 11: oak.release.Digest
+	(Severity)(0),      // 0: oak.release.Severity
+	(MissingPolicy)(0), // 1: oak.release.MissingPolicy
+	(VerifyAllWithBinaryDigests_SubjectMatching)(0), // 2: oak.release.VerifyAllWithBinaryDigests.SubjectMatching
+	(VerifyAllWithCISystem_CISystem)(0),             // 3: oak.release.VerifyAllWithCISystem.CISystem
+	(*VerificationOptions)(nil),                     // 4: oak.release.VerificationOptions
+	(*VerifyProvenanceCountAtLeast)(nil),            // 5: oak.release.VerifyProvenanceCountAtLeast
+	(*VerifyProvenanceCountAtMost)(nil),             // 6: oak.release.VerifyProvenanceCountAtMost
+	(*VerifyAllSameBinaryName)(nil),                 // 7: oak.release.VerifyAllSameBinaryName
+	(*VerifyAllSameBinaryDigest)(nil),               // 8: oak.release.VerifyAllSameBinaryDigest
+	(*VerifyAllWithBuildCommand)(nil),               // 9: oak.release.VerifyAllWithBuildCommand
+	(*VerifyAllWithBinaryName)(nil),                 // 10: oak.release.VerifyAllWithBinaryName
+	(*VerifyAllWithBinaryNamePattern)(nil),          // 11: oak.release.VerifyAllWithBinaryNamePattern
+	(*VerifyAllWithBinaryDigests)(nil),              // 12: oak.release.VerifyAllWithBinaryDigests
+	(*VerifyAllWithRepository)(nil),                 // 13: oak.release.VerifyAllWithRepository
+	(*VerifyAllWithBuilderNames)(nil),               // 14: oak.release.VerifyAllWithBuilderNames
+	(*VerifyAllWithBuilderDigests)(nil),             // 15: oak.release.VerifyAllWithBuilderDigests
+	(*VerifyAllWithInvocationParameters)(nil),       // 16: oak.release.VerifyAllWithInvocationParameters
+	(*VerifyAllSignedWithinDaysOfBuild)(nil),        // 17: oak.release.VerifyAllSignedWithinDaysOfBuild
+	(*VerifyTrustedGenerator)(nil),                  // 18: oak.release.VerifyTrustedGenerator
+	(*VerifySourceIdentity)(nil),                    // 19: oak.release.VerifySourceIdentity
+	(*SourceIdentity)(nil),                          // 20: oak.release.SourceIdentity
+	(*VerifyAllReproducible)(nil),                   // 21: oak.release.VerifyAllReproducible
+	(*VerifyAllWithinDocumentSize)(nil),             // 22: oak.release.VerifyAllWithinDocumentSize
+	(*VerifyBuilderSignature)(nil),                  // 23: oak.release.VerifyBuilderSignature
+	(*VerifyDistinctSigners)(nil),                   // 24: oak.release.VerifyDistinctSigners
+	(*VerifyAllWithOCIAnnotations)(nil),             // 25: oak.release.VerifyAllWithOCIAnnotations
+	(*VerifyAllBuiltWithinDays)(nil),                // 26: oak.release.VerifyAllBuiltWithinDays
+	(*VerifyAllNotInDigestBlocklist)(nil),           // 27: oak.release.VerifyAllNotInDigestBlocklist
+	(*VerifyAllWithCISystem)(nil),                   // 28: oak.release.VerifyAllWithCISystem
+	(*VerifyEmptyArtifacts)(nil),                    // 29: oak.release.VerifyEmptyArtifacts
+	(*VerifyAllWithCanonicalDependencies)(nil),      // 30: oak.release.VerifyAllWithCanonicalDependencies
+	(*VerifyMaxValidity)(nil),                       // 31: oak.release.VerifyMaxValidity
+	(*VerifyAllWithPlatform)(nil),                   // 32: oak.release.VerifyAllWithPlatform
+	(*VerifyAllWithMinDependencies)(nil),            // 33: oak.release.VerifyAllWithMinDependencies
+	(*VerifyAllWithMinByproducts)(nil),              // 34: oak.release.VerifyAllWithMinByproducts
+	(*VerifyIndependentReproduction)(nil),           // 35: oak.release.VerifyIndependentReproduction
+	(*VerifyAllWithBinaryDigestsAnyOf)(nil),         // 36: oak.release.VerifyAllWithBinaryDigestsAnyOf
+	(*VerifyAllWithEnvironmentDigest)(nil),          // 37: oak.release.VerifyAllWithEnvironmentDigest
+	(*VerifyAllWithExternalParametersDigest)(nil),   // 38: oak.release.VerifyAllWithExternalParametersDigest
+	(*VerifyAllWithImageReference)(nil),             // 39: oak.release.VerifyAllWithImageReference
+	(*VerifyAllWithSourceMaterial)(nil),             // 40: oak.release.VerifyAllWithSourceMaterial
+	(*VerifyAllWithBuildDuration)(nil),              // 41: oak.release.VerifyAllWithBuildDuration
+	(*VerifyAllHaveInvocationID)(nil),               // 42: oak.release.VerifyAllHaveInvocationID
+	(*VerifyMaterialsComplete)(nil),                 // 43: oak.release.VerifyMaterialsComplete
+	(*VerifyAllDistinctFromMaterials)(nil),          // 44: oak.release.VerifyAllDistinctFromMaterials
+	(*VerifyNotOlderThan)(nil),                      // 45: oak.release.VerifyNotOlderThan
+	(*VerifyBuilderOrg)(nil),                        // 46: oak.release.VerifyBuilderOrg
+	(*VerifyAllWithinOwnValidity)(nil),              // 47: oak.release.VerifyAllWithinOwnValidity
+	(*VerifyAllWithBuildType)(nil),                  // 48: oak.release.VerifyAllWithBuildType
+	(*VerifyCommitSignature)(nil),                   // 49: oak.release.VerifyCommitSignature
+	(*VerifyAllWithBuilderID)(nil),                  // 50: oak.release.VerifyAllWithBuilderID
+	(*VerifyAllWithSourceRepo)(nil),                 // 51: oak.release.VerifyAllWithSourceRepo
+	(*VerifyAllWithImageManifestDigest)(nil),        // 52: oak.release.VerifyAllWithImageManifestDigest
+	nil,                                             // 53: oak.release.VerificationOptions.SeveritiesEntry
+	nil,                                             // 54: oak.release.VerificationOptions.OnMissingEntry
+	nil,                                             // 55: oak.release.VerifyAllWithInvocationParameters.ParametersEntry
+	nil,                                             // 56: oak.release.VerifyTrustedGenerator.MinimumVersionsEntry
+	nil,                                             // 57: oak.release.VerifyAllWithOCIAnnotations.AnnotationsEntry
+	(*VerifyAllWithBinaryDigestsAnyOf_Digests)(nil), // 58: oak.release.VerifyAllWithBinaryDigestsAnyOf.Digests
+	nil,            // 59: oak.release.VerifyAllWithBinaryDigestsAnyOf.DigestsEntry
+	(*Digest)(nil), // 60: oak.release.Digest
 }
 var file_proto_verification_options_proto_depIdxs = []int32{
-	1,  // 0: oak.release.VerificationOptions.provenance_count_at_least:type_name -> oak.release.VerifyProvenanceCountAtLeast
-	2,  // 1: oak.release.VerificationOptions.provenance_count_at_most:type_name -> oak.release.VerifyProvenanceCountAtMost
-	3,  // 2: oak.release.VerificationOptions.all_same_binary_name:type_name -> oak.release.VerifyAllSameBinaryName
-	4,  // 3: oak.release.VerificationOptions.all_same_binary_digest:type_name -> oak.release.VerifyAllSameBinaryDigest
-	5,  // 4: oak.release.VerificationOptions.all_with_build_command:type_name -> oak.release.VerifyAllWithBuildCommand
-	6,  // 5: oak.release.VerificationOptions.all_with_binary_name:type_name -> oak.release.VerifyAllWithBinaryName
-	7,  // 6: oak.release.VerificationOptions.all_with_binary_digests:type_name -> oak.release.VerifyAllWithBinaryDigests
-	9,  // 7: oak.release.VerificationOptions.all_with_builder_names:type_name -> oak.release.VerifyAllWithBuilderNames
-	10, // 8: oak.release.VerificationOptions.all_with_builder_digests:type_name -> oak.release.VerifyAllWithBuilderDigests
-	8,  // 9: oak.release.VerificationOptions.all_with_repository:type_name -> oak.release.VerifyAllWithRepository
-	11, // 10: oak.release.VerifyAllWithBinaryDigests.digests:type_name -> oak.release.Digest
-	11, // 11: oak.release.VerifyAllWithBuilderDigests.digests:type_name -> oak.release.Digest
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	5,  // 0: oak.release.VerificationOptions.provenance_count_at_least:type_name -> oak.release.VerifyProvenanceCountAtLeast
+	6,  // 1: oak.release.VerificationOptions.provenance_count_at_most:type_name -> oak.release.VerifyProvenanceCountAtMost
+	7,  // 2: oak.release.VerificationOptions.all_same_binary_name:type_name -> oak.release.VerifyAllSameBinaryName
+	8,  // 3: oak.release.VerificationOptions.all_same_binary_digest:type_name -> oak.release.VerifyAllSameBinaryDigest
+	9,  // 4: oak.release.VerificationOptions.all_with_build_command:type_name -> oak.release.VerifyAllWithBuildCommand
+	10, // 5: oak.release.VerificationOptions.all_with_binary_name:type_name -> oak.release.VerifyAllWithBinaryName
+	12, // 6: oak.release.VerificationOptions.all_with_binary_digests:type_name -> oak.release.VerifyAllWithBinaryDigests
+	14, // 7: oak.release.VerificationOptions.all_with_builder_names:type_name -> oak.release.VerifyAllWithBuilderNames
+	15, // 8: oak.release.VerificationOptions.all_with_builder_digests:type_name -> oak.release.VerifyAllWithBuilderDigests
+	13, // 9: oak.release.VerificationOptions.all_with_repository:type_name -> oak.release.VerifyAllWithRepository
+	11, // 10: oak.release.VerificationOptions.all_with_binary_name_pattern:type_name -> oak.release.VerifyAllWithBinaryNamePattern
+	16, // 11: oak.release.VerificationOptions.all_with_invocation_parameters:type_name -> oak.release.VerifyAllWithInvocationParameters
+	17, // 12: oak.release.VerificationOptions.all_signed_within_days_of_build:type_name -> oak.release.VerifyAllSignedWithinDaysOfBuild
+	18, // 13: oak.release.VerificationOptions.trusted_generator:type_name -> oak.release.VerifyTrustedGenerator
+	19, // 14: oak.release.VerificationOptions.source_identity:type_name -> oak.release.VerifySourceIdentity
+	21, // 15: oak.release.VerificationOptions.all_reproducible:type_name -> oak.release.VerifyAllReproducible
+	22, // 16: oak.release.VerificationOptions.all_within_document_size:type_name -> oak.release.VerifyAllWithinDocumentSize
+	23, // 17: oak.release.VerificationOptions.builder_signature:type_name -> oak.release.VerifyBuilderSignature
+	24, // 18: oak.release.VerificationOptions.distinct_signers:type_name -> oak.release.VerifyDistinctSigners
+	25, // 19: oak.release.VerificationOptions.all_with_oci_annotations:type_name -> oak.release.VerifyAllWithOCIAnnotations
+	26, // 20: oak.release.VerificationOptions.all_built_within_days:type_name -> oak.release.VerifyAllBuiltWithinDays
+	27, // 21: oak.release.VerificationOptions.all_not_in_digest_blocklist:type_name -> oak.release.VerifyAllNotInDigestBlocklist
+	28, // 22: oak.release.VerificationOptions.all_with_ci_system:type_name -> oak.release.VerifyAllWithCISystem
+	29, // 23: oak.release.VerificationOptions.empty_artifacts:type_name -> oak.release.VerifyEmptyArtifacts
+	30, // 24: oak.release.VerificationOptions.all_with_canonical_dependencies:type_name -> oak.release.VerifyAllWithCanonicalDependencies
+	31, // 25: oak.release.VerificationOptions.max_validity:type_name -> oak.release.VerifyMaxValidity
+	32, // 26: oak.release.VerificationOptions.all_with_platform:type_name -> oak.release.VerifyAllWithPlatform
+	33, // 27: oak.release.VerificationOptions.all_with_min_dependencies:type_name -> oak.release.VerifyAllWithMinDependencies
+	35, // 28: oak.release.VerificationOptions.independent_reproduction:type_name -> oak.release.VerifyIndependentReproduction
+	36, // 29: oak.release.VerificationOptions.all_with_binary_digests_any_of:type_name -> oak.release.VerifyAllWithBinaryDigestsAnyOf
+	37, // 30: oak.release.VerificationOptions.all_with_environment_digest:type_name -> oak.release.VerifyAllWithEnvironmentDigest
+	39, // 31: oak.release.VerificationOptions.all_with_image_reference:type_name -> oak.release.VerifyAllWithImageReference
+	40, // 32: oak.release.VerificationOptions.all_with_source_material:type_name -> oak.release.VerifyAllWithSourceMaterial
+	41, // 33: oak.release.VerificationOptions.all_with_build_duration:type_name -> oak.release.VerifyAllWithBuildDuration
+	42, // 34: oak.release.VerificationOptions.all_have_invocation_id:type_name -> oak.release.VerifyAllHaveInvocationID
+	43, // 35: oak.release.VerificationOptions.materials_complete:type_name -> oak.release.VerifyMaterialsComplete
+	44, // 36: oak.release.VerificationOptions.all_distinct_from_materials:type_name -> oak.release.VerifyAllDistinctFromMaterials
+	45, // 37: oak.release.VerificationOptions.not_older_than:type_name -> oak.release.VerifyNotOlderThan
+	46, // 38: oak.release.VerificationOptions.builder_org:type_name -> oak.release.VerifyBuilderOrg
+	47, // 39: oak.release.VerificationOptions.all_within_own_validity:type_name -> oak.release.VerifyAllWithinOwnValidity
+	48, // 40: oak.release.VerificationOptions.all_with_build_type:type_name -> oak.release.VerifyAllWithBuildType
+	49, // 41: oak.release.VerificationOptions.commit_signature:type_name -> oak.release.VerifyCommitSignature
+	50, // 42: oak.release.VerificationOptions.all_with_builder_id:type_name -> oak.release.VerifyAllWithBuilderID
+	51, // 43: oak.release.VerificationOptions.all_with_source_repo:type_name -> oak.release.VerifyAllWithSourceRepo
+	52, // 44: oak.release.VerificationOptions.all_with_image_manifest_digest:type_name -> oak.release.VerifyAllWithImageManifestDigest
+	34, // 45: oak.release.VerificationOptions.all_with_min_byproducts:type_name -> oak.release.VerifyAllWithMinByproducts
+	38, // 46: oak.release.VerificationOptions.all_with_external_parameters_digest:type_name -> oak.release.VerifyAllWithExternalParametersDigest
+	53, // 47: oak.release.VerificationOptions.severities:type_name -> oak.release.VerificationOptions.SeveritiesEntry
+	54, // 48: oak.release.VerificationOptions.on_missing:type_name -> oak.release.VerificationOptions.OnMissingEntry
+	60, // 49: oak.release.VerifyAllWithBinaryDigests.digests:type_name -> oak.release.Digest
+	2,  // 50: oak.release.VerifyAllWithBinaryDigests.subject_matching:type_name -> oak.release.VerifyAllWithBinaryDigests.SubjectMatching
+	60, // 51: oak.release.VerifyAllWithBuilderDigests.digests:type_name -> oak.release.Digest
+	55, // 52: oak.release.VerifyAllWithInvocationParameters.parameters:type_name -> oak.release.VerifyAllWithInvocationParameters.ParametersEntry
+	56, // 53: oak.release.VerifyTrustedGenerator.minimum_versions:type_name -> oak.release.VerifyTrustedGenerator.MinimumVersionsEntry
+	20, // 54: oak.release.VerifySourceIdentity.identities:type_name -> oak.release.SourceIdentity
+	57, // 55: oak.release.VerifyAllWithOCIAnnotations.annotations:type_name -> oak.release.VerifyAllWithOCIAnnotations.AnnotationsEntry
+	60, // 56: oak.release.VerifyAllNotInDigestBlocklist.digests:type_name -> oak.release.Digest
+	3,  // 57: oak.release.VerifyAllWithCISystem.ci_system:type_name -> oak.release.VerifyAllWithCISystem.CISystem
+	59, // 58: oak.release.VerifyAllWithBinaryDigestsAnyOf.digests:type_name -> oak.release.VerifyAllWithBinaryDigestsAnyOf.DigestsEntry
+	60, // 59: oak.release.VerifyAllWithEnvironmentDigest.digest:type_name -> oak.release.Digest
+	60, // 60: oak.release.VerifyAllWithExternalParametersDigest.digest:type_name -> oak.release.Digest
+	0,  // 61: oak.release.VerificationOptions.SeveritiesEntry.value:type_name -> oak.release.Severity
+	1,  // 62: oak.release.VerificationOptions.OnMissingEntry.value:type_name -> oak.release.MissingPolicy
+	58, // 63: oak.release.VerifyAllWithBinaryDigestsAnyOf.DigestsEntry.value:type_name -> oak.release.VerifyAllWithBinaryDigestsAnyOf.Digests
+	64, // [64:64] is the sub-list for method output_type
+	64, // [64:64] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
 }
 
 func init() { file_proto_verification_options_proto_init() }
@@ -816,8 +4056,200 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyProvenanceCountAtLeast); i {
+		file_proto_verification_options_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyProvenanceCountAtLeast); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyProvenanceCountAtMost); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllSameBinaryName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllSameBinaryDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBuildCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBinaryName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBinaryNamePattern); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBinaryDigests); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithRepository); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBuilderNames); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBuilderDigests); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithInvocationParameters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllSignedWithinDaysOfBuild); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyTrustedGenerator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifySourceIdentity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceIdentity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllReproducible); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -828,8 +4260,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyProvenanceCountAtMost); i {
+		file_proto_verification_options_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithinDocumentSize); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -840,8 +4272,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllSameBinaryName); i {
+		file_proto_verification_options_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyBuilderSignature); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -852,8 +4284,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllSameBinaryDigest); i {
+		file_proto_verification_options_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyDistinctSigners); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -864,8 +4296,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllWithBuildCommand); i {
+		file_proto_verification_options_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithOCIAnnotations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -876,8 +4308,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllWithBinaryName); i {
+		file_proto_verification_options_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllBuiltWithinDays); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -888,8 +4320,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllWithBinaryDigests); i {
+		file_proto_verification_options_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllNotInDigestBlocklist); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -900,8 +4332,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllWithRepository); i {
+		file_proto_verification_options_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithCISystem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -912,8 +4344,8 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllWithBuilderNames); i {
+		file_proto_verification_options_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyEmptyArtifacts); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -924,8 +4356,284 @@ func file_proto_verification_options_proto_init() {
 				return nil
 			}
 		}
-		file_proto_verification_options_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyAllWithBuilderDigests); i {
+		file_proto_verification_options_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithCanonicalDependencies); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyMaxValidity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithPlatform); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithMinDependencies); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithMinByproducts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyIndependentReproduction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBinaryDigestsAnyOf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithEnvironmentDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithExternalParametersDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithImageReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithSourceMaterial); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBuildDuration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllHaveInvocationID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyMaterialsComplete); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllDistinctFromMaterials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyNotOlderThan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyBuilderOrg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithinOwnValidity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBuildType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyCommitSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBuilderID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithSourceRepo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithImageManifestDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_verification_options_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyAllWithBinaryDigestsAnyOf_Digests); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -943,13 +4651,14 @@ func file_proto_verification_options_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_verification_options_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      4,
+			NumMessages:   56,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_verification_options_proto_goTypes,
 		DependencyIndexes: file_proto_verification_options_proto_depIdxs,
+		EnumInfos:         file_proto_verification_options_proto_enumTypes,
 		MessageInfos:      file_proto_verification_options_proto_msgTypes,
 	}.Build()
 	File_proto_verification_options_proto = out.File